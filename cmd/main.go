@@ -24,6 +24,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/cmd/csi/registrar"
 	csiServer "github.com/Dynatrace/dynatrace-operator/cmd/csi/server"
 	"github.com/Dynatrace/dynatrace-operator/cmd/operator"
+	"github.com/Dynatrace/dynatrace-operator/cmd/render"
 	"github.com/Dynatrace/dynatrace-operator/cmd/standalone"
 	startupProbe "github.com/Dynatrace/dynatrace-operator/cmd/startupprobe"
 	supportArchive "github.com/Dynatrace/dynatrace-operator/cmd/supportarchive"
@@ -63,6 +64,7 @@ func main() {
 		standalone.NewStandaloneCommand(),
 		troubleshoot.New(),
 		supportArchive.New(),
+		render.New(),
 		startupProbe.New(),
 		csiInit.New(),
 		csiProvisioner.New(),
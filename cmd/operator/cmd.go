@@ -14,6 +14,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -29,12 +30,18 @@ const (
 	use = "operator"
 )
 
+var tracingConfig tracing.Config
+
 func New() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:          use,
 		RunE:         run(),
 		SilenceUsage: true,
 	}
+
+	tracing.AddFlags(cmd.PersistentFlags(), &tracingConfig)
+
+	return cmd
 }
 
 func run() func(cmd *cobra.Command, args []string) error {
@@ -43,11 +50,20 @@ func run() func(cmd *cobra.Command, args []string) error {
 		version.LogVersion()
 		logd.LogBaseLoggerSettings()
 
+		shutdownTracing, err := tracing.Setup(context.Background(), tracingServiceName, tracingConfig)
+		if err != nil {
+			return err
+		}
+
+		defer flushTraces(shutdownTracing)
+
 		kubeCfg, err := config.GetConfig()
 		if err != nil {
 			return err
 		}
 
+		tracing.WrapConfig(kubeCfg)
+
 		if kubesystem.IsRunLocally() {
 			log.Info("running locally in debug mode")
 
@@ -58,6 +74,15 @@ func run() func(cmd *cobra.Command, args []string) error {
 	}
 }
 
+func flushTraces(shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		log.Info("failed to flush traces", "err", err)
+	}
+}
+
 func runInPod(kubeCfg *rest.Config) error {
 	clt, err := client.New(kubeCfg, client.Options{})
 	if err != nil {
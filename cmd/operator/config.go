@@ -1,6 +1,8 @@
 package operator
 
 import (
+	"time"
+
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 )
 
@@ -20,6 +22,9 @@ const (
 	defaultLeaseDuration = int64(30)
 	defaultRenewDeadline = int64(20)
 	defaultRetryPeriod   = int64(6)
+
+	tracingServiceName     = "dynatrace-operator"
+	tracingShutdownTimeout = 5 * time.Second
 )
 
 var log = logd.Get().WithName("operator-command")
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+)
+
+// offlineClientBuilder builds Dynatrace clients that are served by an offlineTenant instead of the real API.
+// The token scopes are not verified, as there is no tenant that could grant them.
+type offlineClientBuilder struct {
+	tokens  token.Tokens
+	fixture TenantFixture
+	dk      dynakube.DynaKube
+}
+
+var _ dynatraceclient.Builder = offlineClientBuilder{}
+
+func newOfflineClientBuilder(fixture TenantFixture) dynatraceclient.Builder {
+	return offlineClientBuilder{fixture: fixture}
+}
+
+func (builder offlineClientBuilder) SetContext(context.Context) dynatraceclient.Builder {
+	return builder
+}
+
+func (builder offlineClientBuilder) SetDynakube(dk dynakube.DynaKube) dynatraceclient.Builder {
+	builder.dk = dk
+
+	return builder
+}
+
+func (builder offlineClientBuilder) SetTokens(tokens token.Tokens) dynatraceclient.Builder {
+	builder.tokens = tokens
+
+	return builder
+}
+
+func (builder offlineClientBuilder) Build() (dtclient.Client, error) {
+	apiToken := builder.tokens.APIToken().Value
+	paasToken := builder.tokens.PaasToken().Value
+
+	// the offline tenant doesn't authenticate requests, so OAuth credentials are not exchanged for a token
+	if apiToken == "" && builder.tokens.IsOAuth() {
+		apiToken = builder.tokens.OAuthCredentials().ClientID
+	}
+
+	if paasToken == "" {
+		paasToken = apiToken
+	}
+
+	opts := []dtclient.Option{
+		dtclient.Transport(newOfflineTenant(builder.dk.Spec.APIURL, builder.fixture)),
+		dtclient.NetworkZone(builder.dk.Spec.NetworkZone),
+		dtclient.HostGroup(builder.dk.OneAgent().GetHostGroup()),
+	}
+
+	return dtclient.NewClient(builder.dk.Spec.APIURL, apiToken, paasToken, opts...)
+}
+
+func (builder offlineClientBuilder) BuildWithTokenVerification(*dynakube.DynaKubeStatus) (dtclient.Client, error) {
+	if err := builder.tokens.VerifyValues(); err != nil {
+		return nil, err
+	}
+
+	return builder.Build()
+}
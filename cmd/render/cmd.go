@@ -0,0 +1,108 @@
+package render
+
+import (
+	"io"
+	"os"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	use                    = "render"
+	filenameFlagName       = "filename"
+	filenameFlagShorthand  = "f"
+	fixtureFlagName        = "fixture"
+	diffFlagName           = "diff"
+	namespaceFlagName      = "namespace"
+	namespaceFlagShorthand = "n"
+
+	stdinFilename = "-"
+)
+
+var (
+	filenameFlagValue  string
+	fixtureFlagValue   string
+	diffFlagValue      string
+	namespaceFlagValue string
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: "Render the objects the operator creates for the DynaKubes of a manifest, without a cluster or a tenant",
+		RunE:  run(),
+	}
+
+	addFlags(cmd)
+
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&filenameFlagValue, filenameFlagName, filenameFlagShorthand, stdinFilename, "Manifest with the DynaKubes and the objects they reference, '-' reads from stdin.")
+	cmd.PersistentFlags().StringVar(&fixtureFlagValue, fixtureFlagName, "", "YAML file with the responses of the cluster and the Dynatrace API, placeholders are used if not set.")
+	cmd.PersistentFlags().StringVar(&diffFlagValue, diffFlagName, "", "Previous render to diff against, only the differences are printed.")
+	cmd.PersistentFlags().StringVarP(&namespaceFlagValue, namespaceFlagName, namespaceFlagShorthand, env.DefaultNamespace(), "Namespace of the objects in the manifest that don't specify one.")
+}
+
+func run() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		// stdout only holds the rendered objects, so it can be piped to a file or to kubectl
+		logd.RedirectOutput(os.Stderr)
+
+		input, err := readInput(cmd.InOrStdin(), filenameFlagValue)
+		if err != nil {
+			return err
+		}
+
+		fixture, err := readFixture(fixtureFlagValue)
+		if err != nil {
+			return err
+		}
+
+		manifests, err := render(cmd.Context(), input, namespaceFlagValue, fixture)
+		if err != nil {
+			return err
+		}
+
+		if diffFlagValue == "" {
+			_, err = cmd.OutOrStdout().Write(encode(manifests))
+
+			return errors.WithStack(err)
+		}
+
+		content, err := os.ReadFile(diffFlagValue)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		previous, err := parseManifests(content)
+		if err != nil {
+			return err
+		}
+
+		changes, err := diff(previous, manifests)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(cmd.OutOrStdout(), changes)
+
+		return errors.WithStack(err)
+	}
+}
+
+func readInput(stdin io.Reader, filename string) ([]byte, error) {
+	if filename == stdinFilename {
+		content, err := io.ReadAll(stdin)
+
+		return content, errors.WithStack(err)
+	}
+
+	content, err := os.ReadFile(filename)
+
+	return content, errors.WithStack(err)
+}
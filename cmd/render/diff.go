@@ -0,0 +1,141 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	diffContextLines = 3
+	devNull          = "/dev/null"
+)
+
+// parseManifests reads the objects of a previous render, they are re-encoded so formatting differences don't show up in the diff.
+func parseManifests(content []byte) ([]manifest, error) {
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+
+	var manifests []manifest
+
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errors.WithMessage(err, "failed to read previous render")
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		var object struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+
+		if err := yaml.Unmarshal(document, &object); err != nil {
+			return nil, errors.WithMessage(err, "failed to parse previous render")
+		}
+
+		var content map[string]any
+		if err := yaml.Unmarshal(document, &content); err != nil {
+			return nil, errors.WithMessage(err, "failed to parse previous render")
+		}
+
+		encoded, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		manifests = append(manifests, manifest{
+			key:     path.Join(object.Kind, object.Metadata.Namespace, object.Metadata.Name),
+			content: string(encoded),
+		})
+	}
+
+	return manifests, nil
+}
+
+// diff returns a unified diff per object that differs between the previous and the current render.
+// Added and removed objects are diffed against /dev/null.
+func diff(previous, current []manifest) (string, error) {
+	previousByKey := make(map[string]string, len(previous))
+	for _, rendered := range previous {
+		previousByKey[rendered.key] = rendered.content
+	}
+
+	currentByKey := make(map[string]string, len(current))
+	for _, rendered := range current {
+		currentByKey[rendered.key] = rendered.content
+	}
+
+	keys := make([]string, 0, len(previousByKey)+len(currentByKey))
+	for key := range previousByKey {
+		keys = append(keys, key)
+	}
+
+	for key := range currentByKey {
+		if _, ok := previousByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	var result strings.Builder
+
+	for _, key := range keys {
+		previousContent, inPrevious := previousByKey[key]
+		currentContent, inCurrent := currentByKey[key]
+
+		if previousContent == currentContent {
+			continue
+		}
+
+		fromFile, toFile := "a/"+key, "b/"+key
+		if !inPrevious {
+			fromFile = devNull
+		}
+
+		if !inCurrent {
+			toFile = devNull
+		}
+
+		unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(previousContent),
+			B:        splitLines(currentContent),
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  diffContextLines,
+		})
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		result.WriteString(unified)
+	}
+
+	return result.String(), nil
+}
+
+// splitLines keeps the line breaks, unlike difflib.SplitLines no empty line is added after the last one.
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
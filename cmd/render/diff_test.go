@@ -0,0 +1,71 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManifests(t *testing.T) {
+	manifests, err := parseManifests([]byte(`---
+kind: ConfigMap
+metadata:
+  namespace: dynatrace
+  name: config
+data:
+  b: "2"
+  a: "1"
+---
+---
+kind: Namespace
+metadata:
+  name: dynatrace
+`))
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+
+	assert.Equal(t, "ConfigMap/dynatrace/config", manifests[0].key)
+	assert.Equal(t, "data:\n  a: \"1\"\n  b: \"2\"\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: dynatrace\n", manifests[0].content)
+	assert.Equal(t, "Namespace/dynatrace", manifests[1].key)
+}
+
+func TestDiff(t *testing.T) {
+	previous := []manifest{
+		{key: "ConfigMap/dynatrace/changed", content: "a: 1\nb: 2\n"},
+		{key: "ConfigMap/dynatrace/removed", content: "a: 1\n"},
+		{key: "ConfigMap/dynatrace/unchanged", content: "a: 1\n"},
+	}
+	current := []manifest{
+		{key: "ConfigMap/dynatrace/added", content: "a: 1\n"},
+		{key: "ConfigMap/dynatrace/changed", content: "a: 1\nb: 3\n"},
+		{key: "ConfigMap/dynatrace/unchanged", content: "a: 1\n"},
+	}
+
+	t.Run("differences per object", func(t *testing.T) {
+		changes, err := diff(previous, current)
+		require.NoError(t, err)
+
+		assert.Equal(t, `--- /dev/null
++++ b/ConfigMap/dynatrace/added
+@@ -0,0 +1 @@
++a: 1
+--- a/ConfigMap/dynatrace/changed
++++ b/ConfigMap/dynatrace/changed
+@@ -1,2 +1,2 @@
+ a: 1
+-b: 2
++b: 3
+--- a/ConfigMap/dynatrace/removed
++++ /dev/null
+@@ -1 +0,0 @@
+-a: 1
+`, changes)
+	})
+	t.Run("no differences", func(t *testing.T) {
+		changes, err := diff(current, current)
+		require.NoError(t, err)
+
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,155 @@
+package render
+
+import (
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultKubeSystemUUID = "00000000-0000-0000-0000-000000000000"
+	defaultAPIToken       = "api-token"
+	defaultPaasToken      = "paas-token"
+	defaultDataIngest     = "data-ingest-token"
+	defaultClusterMEID    = "KUBERNETES_CLUSTER-0000000000000000"
+	defaultClusterName    = "render"
+	defaultTenantUUID     = "tenant"
+	defaultTenantToken    = "tenant-token"
+	defaultVersion        = "1.0.0.20000101-000000"
+)
+
+// Fixture stubs everything the operator would read from the cluster or the Dynatrace tenant while reconciling a DynaKube.
+// Fields that are not set are filled with placeholders, so an empty fixture is valid.
+type Fixture struct {
+	// UID of the kube-system namespace, used as ID of the cluster.
+	KubeSystemUUID string `json:"kubeSystemUUID,omitempty"`
+
+	// Content of the token secret of the DynaKubes, used if the input does not contain the secret.
+	Tokens map[string]string `json:"tokens,omitempty"`
+
+	// Responses of the Dynatrace API.
+	Tenant TenantFixture `json:"tenant,omitempty"`
+}
+
+// TenantFixture holds the data served by the offline Dynatrace API.
+type TenantFixture struct {
+	TenantUUID             string   `json:"tenantUUID,omitempty"`
+	TenantToken            string   `json:"tenantToken,omitempty"`
+	CommunicationEndpoints []string `json:"communicationEndpoints,omitempty"`
+
+	OneAgentVersion   string `json:"oneAgentVersion,omitempty"`
+	ActiveGateVersion string `json:"activeGateVersion,omitempty"`
+
+	OneAgentImage    *dtclient.LatestImageInfo `json:"oneAgentImage,omitempty"`
+	CodeModulesImage *dtclient.LatestImageInfo `json:"codeModulesImage,omitempty"`
+	ActiveGateImage  *dtclient.LatestImageInfo `json:"activeGateImage,omitempty"`
+
+	ProcessModuleConfig *dtclient.ProcessModuleConfig `json:"processModuleConfig,omitempty"`
+	MonitoredEntities   []dtclient.MonitoredEntity    `json:"monitoredEntities,omitempty"`
+	EnrichmentRules     []dynakube.EnrichmentRule     `json:"enrichmentRules,omitempty"`
+}
+
+func readFixture(path string) (Fixture, error) {
+	var fixture Fixture
+
+	if path == "" {
+		return fixture, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fixture, errors.WithStack(err)
+	}
+
+	if err := yaml.UnmarshalStrict(content, &fixture); err != nil {
+		return fixture, errors.WithMessagef(err, "failed to parse fixture %s", path)
+	}
+
+	return fixture, nil
+}
+
+func (fixture Fixture) kubeSystemUUID() string {
+	if fixture.KubeSystemUUID == "" {
+		return defaultKubeSystemUUID
+	}
+
+	return fixture.KubeSystemUUID
+}
+
+func (fixture Fixture) tokens() map[string][]byte {
+	if len(fixture.Tokens) == 0 {
+		return map[string][]byte{
+			dtclient.APIToken:        []byte(defaultAPIToken),
+			dtclient.PaasToken:       []byte(defaultPaasToken),
+			dtclient.DataIngestToken: []byte(defaultDataIngest),
+		}
+	}
+
+	tokens := make(map[string][]byte, len(fixture.Tokens))
+	for key, value := range fixture.Tokens {
+		tokens[key] = []byte(value)
+	}
+
+	return tokens
+}
+
+// withDefaults fills the unset fields of the tenant, the images are expected in the registry of the tenant.
+func (tenant TenantFixture) withDefaults(apiURL string) TenantFixture {
+	host := "localhost"
+	if parsedURL, err := url.Parse(apiURL); err == nil && parsedURL.Host != "" {
+		host = parsedURL.Host
+	}
+
+	if tenant.TenantUUID == "" {
+		tenant.TenantUUID = defaultTenantUUID
+	}
+
+	if tenant.TenantToken == "" {
+		tenant.TenantToken = defaultTenantToken
+	}
+
+	if len(tenant.CommunicationEndpoints) == 0 {
+		tenant.CommunicationEndpoints = []string{"https://" + host + ":443/communication"}
+	}
+
+	if tenant.OneAgentVersion == "" {
+		tenant.OneAgentVersion = defaultVersion
+	}
+
+	if tenant.ActiveGateVersion == "" {
+		tenant.ActiveGateVersion = defaultVersion
+	}
+
+	if tenant.OneAgentImage == nil {
+		tenant.OneAgentImage = &dtclient.LatestImageInfo{Source: host + "/linux/oneagent", Tag: tenant.OneAgentVersion}
+	}
+
+	if tenant.CodeModulesImage == nil {
+		tenant.CodeModulesImage = &dtclient.LatestImageInfo{Source: host + "/linux/codemodules", Tag: tenant.OneAgentVersion}
+	}
+
+	if tenant.ActiveGateImage == nil {
+		tenant.ActiveGateImage = &dtclient.LatestImageInfo{Source: host + "/linux/activegate", Tag: tenant.ActiveGateVersion}
+	}
+
+	if len(tenant.MonitoredEntities) == 0 {
+		tenant.MonitoredEntities = []dtclient.MonitoredEntity{{EntityID: defaultClusterMEID, DisplayName: defaultClusterName}}
+	}
+
+	if tenant.ProcessModuleConfig == nil {
+		tenant.ProcessModuleConfig = &dtclient.ProcessModuleConfig{
+			Properties: []dtclient.ProcessModuleProperty{{
+				Section: "general",
+				Key:     "serverAddress",
+				Value:   "{" + strings.Join(tenant.CommunicationEndpoints, ";") + "}",
+			}},
+		}
+	}
+
+	return tenant
+}
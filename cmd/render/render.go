@@ -0,0 +1,468 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	dynakubecontroller "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube"
+	extensionconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	k8slabels "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	k8ssecret "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
+	"github.com/pkg/errors"
+	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	generatedPlaceholder = "generated by the operator"
+	agServerCrtDataName  = "server.crt"
+
+	dynakubeKind = "DynaKube"
+)
+
+// manifest is a rendered object, identified by its kind, namespace and name.
+type manifest struct {
+	key     string
+	content string
+}
+
+// renderedLists are the kinds of objects the operator creates or changes while reconciling a DynaKube.
+func renderedLists() []client.ObjectList {
+	return []client.ObjectList{
+		&corev1.NamespaceList{},
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&corev1.ServiceList{},
+		&corev1.ServiceAccountList{},
+		&appsv1.DaemonSetList{},
+		&appsv1.StatefulSetList{},
+		&appsv1.DeploymentList{},
+		&autoscalingv2.HorizontalPodAutoscalerList{},
+		&policyv1.PodDisruptionBudgetList{},
+		&networkingv1.NetworkPolicyList{},
+	}
+}
+
+// render reconciles every DynaKube of the input against an in-memory cluster and returns the objects the operator created or changed.
+// The other objects of the input, e.g. secrets referenced by the DynaKubes, are put into the cluster beforehand.
+func render(ctx context.Context, input []byte, namespace string, fixture Fixture) ([]manifest, error) {
+	objects, dynakubes, err := decodeInput(input, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dynakubes) == 0 {
+		return nil, errors.New("input does not contain a DynaKube")
+	}
+
+	placeholders, err := placeholderObjects(objects, dynakubes)
+	if err != nil {
+		return nil, err
+	}
+
+	objects = append(objects, seedObjects(objects, dynakubes, fixture)...)
+	objects = append(objects, placeholders...)
+
+	kubeClient := fake.NewClientWithIndex(objects...)
+
+	seeded, err := listObjects(ctx, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// placeholders stand in for objects of the operator, so they are rendered although the operator doesn't change them
+	for _, placeholder := range placeholders {
+		key, err := keyOf(placeholder)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(seeded, key)
+	}
+
+	fakeIstio := fakeistio.NewSimpleClientset()
+	fakeIstio.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{GroupVersion: istio.IstioGVR}}
+
+	controller := dynakubecontroller.NewDynaKubeController(kubeClient, kubeClient, nil, fixture.kubeSystemUUID(),
+		dynakubecontroller.WithDynatraceClientBuilder(newOfflineClientBuilder(fixture.Tenant)),
+		dynakubecontroller.WithIstioClientBuilder(func(_ *rest.Config, owner metav1.Object) (*istio.Client, error) {
+			return &istio.Client{IstioClientset: fakeIstio, Owner: owner}, nil
+		}),
+	)
+
+	for _, dk := range dynakubes {
+		_, err := controller.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dk)})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to render DynaKube %s/%s", dk.Namespace, dk.Name)
+		}
+	}
+
+	reconciled, err := listObjects(ctx, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	istioObjects, err := listIstioObjects(ctx, fakeIstio)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]manifest, 0, len(reconciled)+len(istioObjects))
+
+	for key, obj := range reconciled {
+		if previous, ok := seeded[key]; ok && previous.GetResourceVersion() == obj.GetResourceVersion() {
+			continue
+		}
+
+		rendered, err := toManifest(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		manifests = append(manifests, rendered)
+	}
+
+	for _, obj := range istioObjects {
+		rendered, err := toManifest(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		manifests = append(manifests, rendered)
+	}
+
+	slices.SortFunc(manifests, func(a, b manifest) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	return manifests, nil
+}
+
+// decodeInput splits the YAML documents of the input, DynaKubes of older API versions are converted to the latest one.
+func decodeInput(input []byte, namespace string) ([]client.Object, []*dynakube.DynaKube, error) {
+	decoder := serializer.NewCodecFactory(scheme.Scheme).UniversalDeserializer()
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(input)))
+
+	var (
+		objects   []client.Object
+		dynakubes []*dynakube.DynaKube
+	)
+
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed to read input")
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		decoded, _, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed to decode input")
+		}
+
+		obj, ok := decoded.(client.Object)
+		if !ok {
+			return nil, nil, errors.Errorf("unsupported object %s in input", decoded.GetObjectKind().GroupVersionKind())
+		}
+
+		if _, isNamespace := obj.(*corev1.Namespace); !isNamespace && obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+
+		dk, err := toLatestDynaKube(obj)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if dk != nil {
+			dynakubes = append(dynakubes, dk)
+			obj = dk
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, dynakubes, nil
+}
+
+func toLatestDynaKube(obj client.Object) (*dynakube.DynaKube, error) {
+	switch typed := obj.(type) {
+	case *dynakube.DynaKube:
+		return typed, nil
+	case conversion.Convertible:
+		if obj.GetObjectKind().GroupVersionKind().Kind != dynakubeKind {
+			return nil, nil //nolint:nilnil
+		}
+
+		dk := &dynakube.DynaKube{}
+		if err := typed.ConvertTo(dk); err != nil {
+			return nil, errors.WithMessagef(err, "failed to convert DynaKube %s to the latest version", obj.GetName())
+		}
+
+		return dk, nil
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// seedObjects returns the objects that have to exist in the cluster, unless they are part of the input.
+func seedObjects(input []client.Object, dynakubes []*dynakube.DynaKube, fixture Fixture) []client.Object {
+	exists := func(kind client.Object, namespace, name string) bool {
+		return slices.ContainsFunc(input, func(obj client.Object) bool {
+			return obj.GetNamespace() == namespace && obj.GetName() == name &&
+				obj.GetObjectKind().GroupVersionKind().Kind == kind.GetObjectKind().GroupVersionKind().Kind
+		})
+	}
+
+	kubeSystem := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: kubesystem.Namespace, UID: types.UID(fixture.kubeSystemUUID())},
+	}
+
+	var seeds []client.Object
+
+	if !exists(kubeSystem, "", kubesystem.Namespace) {
+		seeds = append(seeds, kubeSystem)
+	}
+
+	for _, dk := range dynakubes {
+		tokens := &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: dk.Tokens(), Namespace: dk.Namespace},
+			Data:       fixture.tokens(),
+		}
+
+		if !exists(tokens, dk.Namespace, dk.Tokens()) && !slices.ContainsFunc(seeds, func(obj client.Object) bool {
+			return obj.GetNamespace() == dk.Namespace && obj.GetName() == dk.Tokens()
+		}) {
+			seeds = append(seeds, tokens)
+		}
+	}
+
+	return seeds
+}
+
+// placeholderObjects returns fixed stand-ins for the objects the operator fills with random data, unless they are part of the input.
+// Otherwise every render would differ, e.g. by the self-signed certificates of the ActiveGate and the extensions.
+func placeholderObjects(input []client.Object, dynakubes []*dynakube.DynaKube) ([]client.Object, error) {
+	var placeholders []client.Object
+
+	addPlaceholder := func(dk *dynakube.DynaKube, name string, secretType corev1.SecretType, keys []string, component string) (*corev1.Secret, error) {
+		if slices.ContainsFunc(input, func(obj client.Object) bool {
+			_, isSecret := obj.(*corev1.Secret)
+
+			return isSecret && obj.GetNamespace() == dk.Namespace && obj.GetName() == name
+		}) {
+			return nil, nil //nolint:nilnil
+		}
+
+		data := make(map[string][]byte, len(keys))
+		for _, key := range keys {
+			data[key] = []byte(generatedPlaceholder)
+		}
+
+		secret, err := k8ssecret.Build(dk, name, data, k8ssecret.SetType(secretType))
+		if err != nil {
+			return nil, err
+		}
+
+		if component != "" {
+			secret.Labels = k8slabels.NewCoreLabels(dk.Name, component).BuildLabels()
+		}
+
+		placeholders = append(placeholders, secret)
+
+		return secret, nil
+	}
+
+	for _, dk := range dynakubes {
+		ag := dk.ActiveGate()
+		if ag.IsEnabled() && ag.IsAutomaticTLSSecretEnabled() && ag.TLSSecretName == "" {
+			_, err := addPlaceholder(dk, ag.GetTLSSecretName(), corev1.SecretTypeOpaque,
+				[]string{consts.TLSCrtDataName, consts.TLSKeyDataName, agServerCrtDataName}, k8slabels.ActiveGateComponentLabel)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if dk.KSPM().IsEnabled() {
+			secret, err := addPlaceholder(dk, dk.KSPM().GetTokenSecretName(), corev1.SecretTypeOpaque,
+				[]string{kspm.TokenSecretKey}, k8slabels.KSPMComponentLabel)
+			if err != nil {
+				return nil, err
+			}
+
+			// the operator only records the hash of the token when it creates the secret
+			if secret != nil {
+				dk.KSPM().TokenSecretHash, err = hasher.GenerateHash(secret.Data)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if !dk.IsExtensionsEnabled() {
+			continue
+		}
+
+		if dk.ExtensionsNeedsSelfSignedTLS() {
+			_, err := addPlaceholder(dk, dk.ExtensionsSelfSignedTLSSecretName(), corev1.SecretTypeTLS,
+				[]string{consts.TLSCrtDataName, consts.TLSKeyDataName}, k8slabels.ExtensionComponentLabel)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err := addPlaceholder(dk, dk.ExtensionsTokenSecretName(), corev1.SecretTypeOpaque,
+			[]string{extensionconsts.TokenSecretKey, consts.OtelcTokenSecretKey}, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return placeholders, nil
+}
+
+func listObjects(ctx context.Context, kubeClient client.Client) (map[string]client.Object, error) {
+	objects := map[string]client.Object{}
+
+	for _, list := range renderedLists() {
+		if err := kubeClient.List(ctx, list); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			key, err := keyOf(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			objects[key] = obj
+		}
+	}
+
+	return objects, nil
+}
+
+func listIstioObjects(ctx context.Context, fakeIstio *fakeistio.Clientset) ([]client.Object, error) {
+	var objects []client.Object
+
+	serviceEntries, err := fakeIstio.NetworkingV1beta1().ServiceEntries("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, serviceEntry := range serviceEntries.Items {
+		objects = append(objects, serviceEntry)
+	}
+
+	virtualServices, err := fakeIstio.NetworkingV1beta1().VirtualServices("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, virtualService := range virtualServices.Items {
+		objects = append(objects, virtualService)
+	}
+
+	return objects, nil
+}
+
+func keyOf(obj client.Object) (string, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return path.Join(gvk.Kind, obj.GetNamespace(), obj.GetName()), nil
+}
+
+// toManifest encodes the object without the fields set by the cluster, so renders of the same input are identical.
+func toManifest(obj client.Object) (manifest, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return manifest{}, errors.WithStack(err)
+	}
+
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return manifest{}, errors.WithStack(err)
+	}
+
+	unstructured.RemoveNestedField(content, "status")
+
+	for _, field := range []string{"creationTimestamp", "resourceVersion", "uid", "generation", "managedFields"} {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+
+	encoded, err := yaml.Marshal(content)
+	if err != nil {
+		return manifest{}, errors.WithStack(err)
+	}
+
+	return manifest{
+		key:     path.Join(gvk.Kind, obj.GetNamespace(), obj.GetName()),
+		content: string(encoded),
+	}, nil
+}
+
+// encode joins the manifests to a multi-document YAML.
+func encode(manifests []manifest) []byte {
+	var buffer bytes.Buffer
+
+	for _, rendered := range manifests {
+		buffer.WriteString("---\n")
+		buffer.WriteString(rendered.content)
+	}
+
+	return buffer.Bytes()
+}
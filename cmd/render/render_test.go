@@ -0,0 +1,174 @@
+package render
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDynaKube = `
+apiVersion: dynatrace.com/v1beta5
+kind: DynaKube
+metadata:
+  name: dynakube
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    classicFullStack: {}
+  activeGate:
+    capabilities:
+      - routing
+`
+	testAllComponents = `
+apiVersion: dynatrace.com/v1beta5
+kind: DynaKube
+metadata:
+  name: dynakube
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    cloudNativeFullStack: {}
+  activeGate:
+    capabilities:
+      - kubernetes-monitoring
+  extensions:
+    prometheus: {}
+  kspm: {}
+  telemetryIngest: {}
+  templates:
+    kspmNodeConfigurationCollector:
+      imageRef:
+        repository: test/kspm
+        tag: "1.0"
+    otelCollector:
+      imageRef:
+        repository: test/otelc
+        tag: "1.0"
+    extensionExecutionController:
+      imageRef:
+        repository: test/eec
+        tag: "1.0"
+`
+	testAvailability = `
+apiVersion: dynatrace.com/v1beta5
+kind: DynaKube
+metadata:
+  name: dynakube
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    classicFullStack: {}
+  activeGate:
+    capabilities:
+      - routing
+    autoscaling:
+      maxReplicas: 3
+    podDisruptionBudget:
+      maxUnavailable: 1
+  networkPolicies:
+    enabled: true
+`
+	testTokens = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: dynakube
+data:
+  apiToken: dGVzdA==
+`
+)
+
+func manifestKeys(manifests []manifest) []string {
+	keys := make([]string, 0, len(manifests))
+	for _, rendered := range manifests {
+		keys = append(keys, rendered.key)
+	}
+
+	return keys
+}
+
+func TestRender(t *testing.T) {
+	t.Run("renders the objects of the operator", func(t *testing.T) {
+		manifests, err := render(t.Context(), []byte(testDynaKube), "dynatrace", Fixture{})
+		require.NoError(t, err)
+
+		keys := manifestKeys(manifests)
+		assert.Contains(t, keys, "DaemonSet/dynatrace/dynakube-oneagent")
+		assert.Contains(t, keys, "StatefulSet/dynatrace/dynakube-activegate")
+		assert.Contains(t, keys, "Secret/dynatrace/dynakube-activegate-tls-secret")
+		assert.Contains(t, keys, "ConfigMap/dynatrace/dynakube-oneagent-connection-info")
+		assert.NotContains(t, keys, "Secret/dynatrace/dynakube")
+		assert.NotContains(t, keys, "Namespace/kube-system")
+		assert.IsIncreasing(t, keys)
+	})
+	t.Run("renders are reproducible", func(t *testing.T) {
+		first, err := render(t.Context(), []byte(testAllComponents), "dynatrace", Fixture{})
+		require.NoError(t, err)
+
+		second, err := render(t.Context(), []byte(testAllComponents), "dynatrace", Fixture{})
+		require.NoError(t, err)
+
+		keys := manifestKeys(first)
+		assert.Contains(t, keys, "StatefulSet/dynatrace/dynakube-extensions-controller")
+		assert.Contains(t, keys, "StatefulSet/dynatrace/dynakube-otel-collector")
+		assert.Contains(t, keys, "DaemonSet/dynatrace/dynakube-node-config-collector")
+		assert.Contains(t, keys, "Secret/dynatrace/dynakube-kspm-token")
+		assert.Equal(t, string(encode(first)), string(encode(second)))
+	})
+	t.Run("renders autoscaling, disruption budgets and network policies", func(t *testing.T) {
+		manifests, err := render(t.Context(), []byte(testAvailability), "dynatrace", Fixture{})
+		require.NoError(t, err)
+
+		keys := manifestKeys(manifests)
+		assert.Contains(t, keys, "HorizontalPodAutoscaler/dynatrace/dynakube-activegate")
+		assert.Contains(t, keys, "PodDisruptionBudget/dynatrace/dynakube-activegate")
+		assert.True(t, slices.ContainsFunc(keys, func(key string) bool {
+			return strings.HasPrefix(key, "NetworkPolicy/dynatrace/")
+		}), keys)
+	})
+	t.Run("uses the data of the fixture", func(t *testing.T) {
+		fixture := Fixture{
+			KubeSystemUUID: "cluster-uuid",
+			Tenant: TenantFixture{
+				TenantUUID:      "fixture-tenant",
+				OneAgentVersion: "1.2.3.20250101-000000",
+			},
+		}
+
+		manifests, err := render(t.Context(), []byte(testDynaKube), "dynatrace", fixture)
+		require.NoError(t, err)
+
+		content := string(encode(manifests))
+		assert.Contains(t, content, "app.kubernetes.io/version: 1.2.3.20250101-000000")
+		assert.Contains(t, content, "fixture-tenant")
+		assert.Contains(t, content, "cluster-uuid")
+	})
+	t.Run("secrets of the input are used but not rendered", func(t *testing.T) {
+		manifests, err := render(t.Context(), []byte(testDynaKube+"---"+testTokens), "other", Fixture{})
+		require.NoError(t, err)
+
+		keys := manifestKeys(manifests)
+		assert.Contains(t, keys, "DaemonSet/other/dynakube-oneagent")
+		assert.NotContains(t, keys, "Secret/other/dynakube")
+	})
+	t.Run("converts older DynaKubes", func(t *testing.T) {
+		input := strings.Replace(testDynaKube, "v1beta5", "v1beta4", 1)
+
+		manifests, err := render(t.Context(), []byte(input), "dynatrace", Fixture{})
+		require.NoError(t, err)
+
+		assert.Contains(t, manifestKeys(manifests), "StatefulSet/dynatrace/dynakube-activegate")
+	})
+	t.Run("input without DynaKube", func(t *testing.T) {
+		_, err := render(t.Context(), []byte(testTokens), "dynatrace", Fixture{})
+		require.Error(t, err)
+	})
+	t.Run("input with unknown kind", func(t *testing.T) {
+		_, err := render(t.Context(), []byte("apiVersion: example.com/v1\nkind: Unknown\n"), "dynatrace", Fixture{})
+		require.Error(t, err)
+	})
+}
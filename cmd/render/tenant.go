@@ -0,0 +1,187 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+)
+
+const (
+	agentInstallerPath   = "/v1/deployment/installer/agent"
+	gatewayInstallerPath = "/v1/deployment/installer/gateway"
+
+	entitiesEndpoint         = "/v2/entities"
+	settingsObjectsEndpoint  = "/v2/settings/objects"
+	tokenLookupEndpoint      = "/v2/apiTokens/lookup"
+	activeGateTokensEndpoint = "/v2/activeGateTokens"
+)
+
+// offlineTenant answers the requests of the Dynatrace client from a TenantFixture, without any network access.
+// Settings objects and ActiveGate tokens are not stored, every request gets the same answer, so renders are reproducible.
+type offlineTenant struct {
+	handler http.Handler
+	apiPath string
+
+	settingsObjects int
+	mutex           sync.Mutex
+}
+
+var _ http.RoundTripper = &offlineTenant{}
+
+func newOfflineTenant(apiURL string, fixture TenantFixture) *offlineTenant {
+	apiPath := ""
+	if parsedURL, err := url.Parse(apiURL); err == nil {
+		apiPath = strings.TrimSuffix(parsedURL.Path, "/")
+	}
+
+	tenant := &offlineTenant{apiPath: apiPath}
+	tenant.handler = tenant.routes(fixture.withDefaults(apiURL))
+
+	return tenant
+}
+
+// RoundTrip serves the request with the handler of the tenant, the path of the API URL is stripped beforehand.
+func (tenant *offlineTenant) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.URL.Path = strings.TrimPrefix(request.URL.Path, tenant.apiPath)
+
+	recorder := httptest.NewRecorder()
+	tenant.handler.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	response.Request = request
+
+	return response, nil
+}
+
+func (tenant *offlineTenant) routes(fixture TenantFixture) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+dtclient.OneAgentConnectionInfoEndpoint, respondWith(map[string]any{
+		"tenantUUID":             fixture.TenantUUID,
+		"tenantToken":            fixture.TenantToken,
+		"communicationEndpoints": fixture.CommunicationEndpoints,
+	}))
+	mux.HandleFunc("GET "+dtclient.ProcessModuleConfigEndpoint, respondWith(fixture.ProcessModuleConfig))
+	mux.HandleFunc("GET "+agentInstallerPath+"/versions/{os}/{type}", respondWith(map[string]any{
+		"availableVersions": []string{fixture.OneAgentVersion},
+	}))
+	mux.HandleFunc("GET "+agentInstallerPath+"/{os}/{type}/latest/metainfo", respondWith(map[string]any{
+		"latestAgentVersion": fixture.OneAgentVersion,
+	}))
+	mux.HandleFunc("GET "+gatewayInstallerPath+"/connectioninfo", respondWith(map[string]any{
+		"tenantUUID":             fixture.TenantUUID,
+		"tenantToken":            fixture.TenantToken,
+		"communicationEndpoints": strings.Join(fixture.CommunicationEndpoints, ","),
+	}))
+	mux.HandleFunc("GET "+gatewayInstallerPath+"/{os}/latest/metainfo", respondWith(map[string]any{
+		"latestGatewayVersion": fixture.ActiveGateVersion,
+	}))
+	mux.HandleFunc("GET "+dtclient.LatestOneAgentImageEndpoint, respondWith(fixture.OneAgentImage))
+	mux.HandleFunc("GET "+dtclient.LatestCodeModulesImageEndpoint, respondWith(fixture.CodeModulesImage))
+	mux.HandleFunc("GET "+dtclient.LatestActiveGateImageEndpoint, respondWith(fixture.ActiveGateImage))
+	mux.HandleFunc("GET "+entitiesEndpoint, respondWith(map[string]any{
+		"entities":   fixture.MonitoredEntities,
+		"totalCount": len(fixture.MonitoredEntities),
+		"pageSize":   len(fixture.MonitoredEntities),
+	}))
+	mux.HandleFunc("GET "+settingsObjectsEndpoint, respondWith(map[string]any{
+		"items":      []any{},
+		"totalCount": 0,
+	}))
+	mux.HandleFunc("POST "+settingsObjectsEndpoint, tenant.createSettingsObjects)
+	mux.HandleFunc("GET "+dtclient.EffectiveSettingsEndpoint, tenant.effectiveSettings(fixture))
+	mux.HandleFunc("POST "+tokenLookupEndpoint, respondWith(map[string]any{
+		"scopes": []string{},
+	}))
+	mux.HandleFunc("POST "+activeGateTokensEndpoint, tenant.createActiveGateToken)
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("%s %s is not served by the render fixture", request.Method, request.URL.Path))
+	})
+
+	return mux
+}
+
+func (tenant *offlineTenant) createSettingsObjects(writer http.ResponseWriter, request *http.Request) {
+	var objects []json.RawMessage
+	if err := json.NewDecoder(request.Body).Decode(&objects); err != nil {
+		writeError(writer, http.StatusBadRequest, "could not parse settings objects: "+err.Error())
+
+		return
+	}
+
+	tenant.mutex.Lock()
+	defer tenant.mutex.Unlock()
+
+	response := make([]map[string]string, 0, len(objects))
+	for range objects {
+		tenant.settingsObjects++
+		response = append(response, map[string]string{"objectId": fmt.Sprintf("render-%d", tenant.settingsObjects)})
+	}
+
+	writeJSON(writer, http.StatusOK, response)
+}
+
+func (tenant *offlineTenant) effectiveSettings(fixture TenantFixture) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("schemaIds") != dtclient.MetadataEnrichmentSettingsSchemaID {
+			writeJSON(writer, http.StatusOK, map[string]any{"items": []any{}, "totalCount": 0})
+
+			return
+		}
+
+		writeJSON(writer, http.StatusOK, dtclient.GetRulesSettingsResponse{
+			Items:      []dtclient.RuleItem{{Value: dtclient.RulesResponseValue{Rules: fixture.EnrichmentRules}}},
+			TotalCount: 1,
+		})
+	}
+}
+
+func (tenant *offlineTenant) createActiveGateToken(writer http.ResponseWriter, request *http.Request) {
+	var params dtclient.ActiveGateAuthTokenParams
+	if err := json.NewDecoder(request.Body).Decode(&params); err != nil {
+		writeError(writer, http.StatusBadRequest, "could not parse token parameters: "+err.Error())
+
+		return
+	}
+
+	writeJSON(writer, http.StatusCreated, dtclient.ActiveGateAuthTokenInfo{
+		TokenID: "dt0g02.render",
+		Token:   "dt0g02.render." + params.Name,
+	})
+}
+
+func respondWith(response any) http.HandlerFunc {
+	return func(writer http.ResponseWriter, _ *http.Request) {
+		writeJSON(writer, http.StatusOK, response)
+	}
+}
+
+func writeJSON(writer http.ResponseWriter, status int, response any) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_, _ = writer.Write(body)
+}
+
+func writeError(writer http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(map[string]dtclient.ServerError{
+		"error": {Code: status, Message: message},
+	})
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_, _ = writer.Write(body)
+}
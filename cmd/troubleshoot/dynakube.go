@@ -100,6 +100,12 @@ func checkIfDynatraceAPISecretHasAPIToken(ctx context.Context, baseLog logd.Logg
 		return nil, errors.Wrapf(err, "'%s:%s' secret is missing or invalid", dk.Namespace, dk.Tokens())
 	}
 
+	if tokens.IsOAuth() {
+		logInfof(log, "secret holds OAuth client credentials")
+
+		return tokens, nil
+	}
+
 	_, hasAPIToken := tokens[dtclient.APIToken]
 	if !hasAPIToken {
 		return nil, errors.New(fmt.Sprintf("'%s' token is missing in '%s:%s' secret", dtclient.APIToken, dk.Namespace, dk.Tokens()))
@@ -9,12 +9,14 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
+	"github.com/Dynatrace/dynatrace-operator/test/helpers/faketenant"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -140,6 +142,51 @@ func TestDynatraceSecret(t *testing.T) {
 	})
 }
 
+func TestDynatraceAPI(t *testing.T) {
+	setup := func(t *testing.T, apiToken, paasToken string) (*dynakube.DynaKube, client.Client) {
+		tenant := faketenant.New(t)
+
+		dk := testNewDynakubeBuilder(testNamespace, testDynakube).withAPIURL(tenant.APIURL()).withTokens(testDynatraceSecret).build()
+		clt := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(
+				dk,
+				testBuildNamespace(testNamespace),
+				testNewSecretBuilder(testNamespace, testDynatraceSecret).dataAppend("apiToken", apiToken).dataAppend("paasToken", paasToken).build(),
+			).
+			Build()
+
+		return dk, clt
+	}
+
+	t.Run("token scopes are valid", func(t *testing.T) {
+		dk, clt := setup(t, faketenant.DefaultAPIToken, faketenant.DefaultPaasToken)
+
+		tokens, err := checkIfDynatraceAPISecretHasAPIToken(context.Background(), getNullLogger(t), clt, dk)
+		require.NoError(t, err)
+
+		require.NoError(t, checkDynatraceAPITokenScopes(context.Background(), getNullLogger(t), clt, tokens, dk))
+		require.NoError(t, checkAPIURLForLatestAgentVersion(context.Background(), getNullLogger(t), clt, dk, tokens))
+	})
+	t.Run("token scopes are missing", func(t *testing.T) {
+		dk, clt := setup(t, faketenant.DefaultPaasToken, faketenant.DefaultPaasToken)
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName}
+
+		tokens, err := checkIfDynatraceAPISecretHasAPIToken(context.Background(), getNullLogger(t), clt, dk)
+		require.NoError(t, err)
+
+		require.Error(t, checkDynatraceAPITokenScopes(context.Background(), getNullLogger(t), clt, tokens, dk))
+	})
+	t.Run("unknown token cannot pull latest agent version", func(t *testing.T) {
+		dk, clt := setup(t, "unknown", "unknown")
+
+		tokens, err := checkIfDynatraceAPISecretHasAPIToken(context.Background(), getNullLogger(t), clt, dk)
+		require.NoError(t, err)
+
+		require.Error(t, checkAPIURLForLatestAgentVersion(context.Background(), getNullLogger(t), clt, dk, tokens))
+	})
+}
+
 func TestPullSecret(t *testing.T) {
 	t.Run("custom pull secret exists", func(t *testing.T) {
 		dk := testNewDynakubeBuilder(testNamespace, testDynakube).withCustomPullSecret(testSecretName).build()
@@ -3,6 +3,7 @@ package webhook
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/cmd/webhook/certificates"
 	dynakubelatest "github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
@@ -19,6 +20,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	namespacemutator "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/namespace"
@@ -39,18 +41,23 @@ const (
 	FlagCertificateKeyFileName = "cert-key"
 
 	openshiftSecurityGVR = "security.openshift.io/v1"
+
+	tracingServiceName     = "dynatrace-webhook"
+	tracingShutdownTimeout = 5 * time.Second
 )
 
 var (
 	certificateDirectory   string
 	certificateFileName    string
 	certificateKeyFileName string
+	tracingConfig          tracing.Config
 )
 
 func addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&certificateDirectory, FlagCertificateDirectory, "/tmp/webhook/certs", "Directory to look certificates for.")
 	cmd.PersistentFlags().StringVar(&certificateFileName, FlagCertificateFileName, "tls.crt", "File name for the public certificate.")
 	cmd.PersistentFlags().StringVar(&certificateKeyFileName, FlagCertificateKeyFileName, "tls.key", "File name for the private key.")
+	tracing.AddFlags(cmd.PersistentFlags(), &tracingConfig)
 }
 
 func New() *cobra.Command {
@@ -71,6 +78,13 @@ func run() func(*cobra.Command, []string) error {
 		version.LogVersion()
 		logd.LogBaseLoggerSettings()
 
+		shutdownTracing, err := tracing.Setup(context.Background(), tracingServiceName, tracingConfig)
+		if err != nil {
+			return err
+		}
+
+		defer flushTraces(shutdownTracing)
+
 		podName := os.Getenv(env.PodName)
 		namespace := os.Getenv(env.PodNamespace)
 
@@ -79,6 +93,8 @@ func run() func(*cobra.Command, []string) error {
 			return err
 		}
 
+		tracing.WrapConfig(kubeConfig)
+
 		isOpenShift := false
 
 		client, err := discovery.NewDiscoveryClientForConfig(kubeConfig)
@@ -134,6 +150,15 @@ func run() func(*cobra.Command, []string) error {
 	}
 }
 
+func flushTraces(shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		logd.Get().WithName("tracing").Info("failed to flush traces", "err", err)
+	}
+}
+
 func startCertificateWatcher(webhookManager manager.Manager, namespace string, podName string) error {
 	webhookPod, err := pod.Get(context.TODO(), webhookManager.GetAPIReader(), podName, namespace)
 	if err != nil {
@@ -5,4 +5,17 @@ const (
 	RawTag                         = "raw"
 	InternalFlagPrefix             = "internal.operator.dynatrace.com/"
 	AnnotationExtensionsSecretHash = InternalFlagPrefix + "extensions-secret-hash"
+
+	// AnnotationPaused set to "true" on a DynaKube or EdgeConnect stops the operator from changing the objects it manages for it,
+	// only the status is kept up to date. Removing the annotation resumes the reconciliation.
+	AnnotationPaused = "dynatrace.com/paused"
+
+	// AnnotationRolloutNow set to "true" on a DynaKube rolls out new versions immediately, even if no maintenance window is open.
+	// The operator removes it again once the pending versions are rolled out.
+	AnnotationRolloutNow = "dynatrace.com/rollout-now"
 )
+
+// IsPaused checks the annotations of a custom resource for AnnotationPaused.
+func IsPaused(annotations map[string]string) bool {
+	return annotations[AnnotationPaused] == "true"
+}
@@ -11,6 +11,10 @@ const (
 	PublicRegistryKey = FFPrefix + "public-registry"
 	NoProxyKey        = FFPrefix + "no-proxy"
 
+	AutomaticRollbackKey        = FFPrefix + "automatic-rollback"
+	RollbackReadinessTimeoutKey = FFPrefix + "rollback-readiness-timeout"
+	RollbackRestartThresholdKey = FFPrefix + "rollback-restart-threshold"
+
 	// Deprecated: Dedicated field since v1beta2.
 	APIRequestThresholdKey = FFPrefix + "dynatrace-api-request-threshold"
 
@@ -18,6 +22,9 @@ const (
 	failPhrase   = "fail"
 
 	DefaultMinRequestThresholdMinutes = 15
+
+	DefaultRollbackReadinessTimeoutMinutes = 15
+	DefaultRollbackRestartThreshold        = 3
 )
 
 type FeatureFlags struct {
@@ -47,6 +54,31 @@ func (ff *FeatureFlags) IsPublicRegistry() bool {
 	return ff.getBoolWithDefault(PublicRegistryKey, false)
 }
 
+// IsAutomaticRollback is a feature flag to disable the rollback of OneAgent and ActiveGate versions that fail to roll out.
+func (ff *FeatureFlags) IsAutomaticRollback() bool {
+	return ff.getBoolWithDefault(AutomaticRollbackKey, true)
+}
+
+// GetRollbackReadinessTimeout is a feature flag to configure how long the pods of a new version may take to become ready, before the version is rolled back.
+func (ff *FeatureFlags) GetRollbackReadinessTimeout() time.Duration {
+	timeout := ff.getIntWithDefault(RollbackReadinessTimeoutKey, DefaultRollbackReadinessTimeoutMinutes)
+	if timeout <= 0 {
+		timeout = DefaultRollbackReadinessTimeoutMinutes
+	}
+
+	return time.Duration(timeout) * time.Minute
+}
+
+// GetRollbackRestartThreshold is a feature flag to configure how often a pod of a new version may restart, before the version is rolled back.
+func (ff *FeatureFlags) GetRollbackRestartThreshold() int32 {
+	threshold := ff.getIntWithDefault(RollbackRestartThresholdKey, DefaultRollbackRestartThreshold)
+	if threshold <= 0 {
+		threshold = DefaultRollbackRestartThreshold
+	}
+
+	return int32(threshold) //nolint:gosec
+}
+
 // Deprecated: Do not use "disable" feature flags.
 func (ff *FeatureFlags) getDisableFlagWithDeprecatedAnnotation(annotation string, deprecatedAnnotation string) bool {
 	if ff.getRaw(annotation) != "" {
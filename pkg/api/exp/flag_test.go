@@ -93,6 +93,72 @@ func TestGetApiRequestThreshold(t *testing.T) {
 	}
 }
 
+func TestGetRollbackReadinessTimeout(t *testing.T) {
+	type testCase struct {
+		title string
+		in    string
+		out   time.Duration
+	}
+
+	cases := []testCase{
+		{
+			title: "default",
+			in:    "",
+			out:   DefaultRollbackReadinessTimeoutMinutes * time.Minute,
+		},
+		{
+			title: "with incorrect value type, zero",
+			in:    "0",
+			out:   DefaultRollbackReadinessTimeoutMinutes * time.Minute,
+		},
+		{
+			title: "with incorrect value type, go time format",
+			in:    "1h",
+			out:   DefaultRollbackReadinessTimeoutMinutes * time.Minute,
+		},
+		{
+			title: "overrule",
+			in:    "30",
+			out:   30 * time.Minute,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			ff := FeatureFlags{annotations: map[string]string{
+				RollbackReadinessTimeoutKey: c.in,
+			}}
+
+			assert.Equal(t, c.out, ff.GetRollbackReadinessTimeout())
+		})
+	}
+}
+
+func TestGetRollbackRestartThreshold(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		ff := FeatureFlags{}
+
+		assert.Equal(t, int32(DefaultRollbackRestartThreshold), ff.GetRollbackRestartThreshold())
+		assert.True(t, ff.IsAutomaticRollback())
+	})
+	t.Run("overrule", func(t *testing.T) {
+		ff := FeatureFlags{annotations: map[string]string{
+			RollbackRestartThresholdKey: "5",
+			AutomaticRollbackKey:        "false",
+		}}
+
+		assert.Equal(t, int32(5), ff.GetRollbackRestartThreshold())
+		assert.False(t, ff.IsAutomaticRollback())
+	})
+	t.Run("with incorrect value type, negative int", func(t *testing.T) {
+		ff := FeatureFlags{annotations: map[string]string{
+			RollbackRestartThresholdKey: "-1",
+		}}
+
+		assert.Equal(t, int32(DefaultRollbackRestartThreshold), ff.GetRollbackRestartThreshold())
+	})
+}
+
 func TestGetIntWithDefault(t *testing.T) {
 	type testCase struct {
 		title       string
@@ -2,6 +2,7 @@ package activegate
 
 import (
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
@@ -42,12 +43,22 @@ func (ag *Spec) apiURLHost() string {
 
 // NeedsActiveGate returns true when a feature requires ActiveGate instances.
 func (ag *Spec) IsEnabled() bool {
+	return len(ag.Capabilities) > 0 || len(ag.Groups) > 0 || ag.enabledDependencies.Any()
+}
+
+// IsDefaultEnabled returns true when the default ActiveGate is needed, next to the ActiveGate groups.
+func (ag *Spec) IsDefaultEnabled() bool {
 	return len(ag.Capabilities) > 0 || ag.enabledDependencies.Any()
 }
 
+// IsMode returns true if the capability is enabled, either for the default ActiveGate or for one of the groups.
 func (ag *Spec) IsMode(mode CapabilityDisplayName) bool {
-	for _, capability := range ag.Capabilities {
-		if capability == mode {
+	if slices.Contains(ag.Capabilities, mode) {
+		return true
+	}
+
+	for _, group := range ag.Groups {
+		if slices.Contains(group.Capabilities, mode) {
 			return true
 		}
 	}
@@ -55,6 +66,18 @@ func (ag *Spec) IsMode(mode CapabilityDisplayName) bool {
 	return false
 }
 
+// GetCapabilityGroupName returns the name of the group the capability is enabled for.
+// An empty name is returned for the default ActiveGate, or if the capability isn't enabled at all.
+func (ag *Spec) GetCapabilityGroupName(mode CapabilityDisplayName) string {
+	for _, group := range ag.Groups {
+		if slices.Contains(group.Capabilities, mode) {
+			return group.Name
+		}
+	}
+
+	return ""
+}
+
 func (ag *Spec) GetServiceAccountOwner() string {
 	if ag.IsKubernetesMonitoringEnabled() {
 		return string(KubeMonCapability.DisplayName)
@@ -72,6 +95,11 @@ func (ag *Spec) GetReplicas() int32 {
 	return *ag.Replicas
 }
 
+// IsAutoscalingEnabled returns true if the replicas of the ActiveGate are managed by a HorizontalPodAutoscaler.
+func (ag *Spec) IsAutoscalingEnabled() bool {
+	return ag.Autoscaling != nil
+}
+
 func (ag *Spec) GetServiceAccountName() string {
 	return "dynatrace-" + ag.GetServiceAccountOwner()
 }
@@ -110,6 +138,15 @@ func (ag *Spec) GetAuthTokenSecretName() string {
 	return ag.name + AuthTokenSecretSuffix
 }
 
+// GetGroupAuthTokenSecretName returns the name of the secret containing the ActiveGateAuthToken of the given group, the default group has an empty name.
+func (ag *Spec) GetGroupAuthTokenSecretName(groupName string) string {
+	if groupName == "" {
+		return ag.GetAuthTokenSecretName()
+	}
+
+	return ag.name + "-activegate-" + groupName + "-authtoken-secret"
+}
+
 // GetTLSSecretName returns the name of the AG TLS secret.
 func (ag *Spec) GetTLSSecretName() string {
 	if ag.TLSSecretName != "" {
@@ -152,3 +189,7 @@ func (ag *Spec) GetCustomImage() string {
 
 // GetTerminationGracePeriodSeconds provides the configured value for the terminatGracePeriodSeconds parameter of the pod.
 func (ag *Spec) GetTerminationGracePeriodSeconds() *int64 { return ag.TerminationGracePeriodSeconds }
+
+func (ag *Spec) HasPodTemplateOverride() bool {
+	return ag.PodTemplateOverride != nil && len(ag.PodTemplateOverride.Raw) > 0
+}
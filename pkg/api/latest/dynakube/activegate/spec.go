@@ -1,8 +1,12 @@
 package activegate
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type CapabilityDisplayName string
@@ -113,6 +117,118 @@ type Spec struct {
 
 	// UseEphemeralVolume
 	UseEphemeralVolume bool `json:"useEphemeralVolume,omitempty"`
+
+	// Restricts which versions are rolled out by automatic updates, by a version constraint, an update mode and a minimum release age.
+	// +kubebuilder:validation:Optional
+	UpdatePolicy *update.Policy `json:"updatePolicy,omitempty"`
+
+	// Scales the ActiveGate pods with a HorizontalPodAutoscaler. If set, the replicas of the StatefulSet are managed by the HorizontalPodAutoscaler instead of the replicas field.
+	// Can't be combined with ActiveGate groups.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling",order=32,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// Limits how many ActiveGate pods may be unavailable during voluntary disruptions, like node drains.
+	// Defaults to one unavailable pod at a time, if there is more than one replica.
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *pdb.Spec `json:"podDisruptionBudget,omitempty"`
+
+	// Strategic merge patch applied to the pod template of the ActiveGate StatefulSet, after all settings of the operator.
+	// It is applied to the StatefulSets of all ActiveGate groups as well.
+	// Allows to set, for example, affinity, security contexts, additional volumes and containers, hostAliases, runtimeClassName or serviceAccountName.
+	// Fields managed by the operator, like the image, env, ports and volumeMounts of the activegate container, the volumes of the operator or the selector labels, can't be overridden.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Template Override",order=33,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// Additional ActiveGate groups, each deployed as its own StatefulSet and Service, with its own capabilities and pod settings.
+	// The other settings of the ActiveGate section, like the image, TLS, custom properties and the podDisruptionBudget, apply to all groups.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups",order=41,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Groups []GroupSpec `json:"groups,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// GroupSpec configures an additional ActiveGate StatefulSet, so its capabilities are scaled and scheduled independently of the other ActiveGates.
+type GroupSpec struct {
+
+	// Name of the group, used as suffix for the names of the StatefulSet and Service of the group.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Activegate capabilities enabled for the group (routing, kubernetes-monitoring, metrics-ingest, dynatrace-api)
+	// +kubebuilder:validation:MinItems=1
+	Capabilities []CapabilityDisplayName `json:"capabilities"`
+
+	// Node selector to control the selection of nodes
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Adds additional labels for the ActiveGate pods of the group
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Amount of replicas for the ActiveGates of the group
+	// +kubebuilder:validation:Optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Set activation group for the ActiveGates of the group
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
+
+	// Define resources requests and limits for single ActiveGate pods of the group
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Set tolerations for the ActiveGate pods of the group
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// List of environment variables to set for the ActiveGates of the group
+	// +kubebuilder:validation:Optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Adds TopologySpreadConstraints for the ActiveGate pods of the group
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// AutoscalingSpec configures the HorizontalPodAutoscaler of the ActiveGate StatefulSet.
+type AutoscalingSpec struct {
+
+	// Lower limit for the number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Upper limit for the number of replicas. Cannot be less than minReplicas.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Target average CPU utilization of the ActiveGate pods, in percent of the requested CPU.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// Target average memory utilization of the ActiveGate pods, in percent of the requested memory.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Additional metrics, like custom or external metrics, to scale the ActiveGate pods by.
+	// If neither a target utilization nor metrics are set, Kubernetes scales by an average CPU utilization of 80%.
+	// +kubebuilder:validation:Optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
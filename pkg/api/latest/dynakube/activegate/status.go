@@ -2,18 +2,51 @@ package activegate
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +kubebuilder:object:generate=true
 type Status struct {
 	status.VersionStatus `json:",inline"`
 
+	update.RolloutStatus `json:",inline"`
+
 	// Information about Active Gate's connections
 	ConnectionInfo communication.ConnectionInfo `json:"connectionInfoStatus,omitempty"`
 
 	// The ClusterIPs set by Kubernetes on the ActiveGate Service created by the Operator
 	ServiceIPs []string `json:"serviceIPs,omitempty"`
+
+	// Replicas reported by the HorizontalPodAutoscaler of the ActiveGate, if autoscaling is enabled
+	Autoscaling *AutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// Status of the additional ActiveGate groups
+	// +listType=map
+	// +listMapKey=name
+	Groups []GroupStatus `json:"groups,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type GroupStatus struct {
+	// Name of the ActiveGate group
+	Name string `json:"name"`
+
+	// The ClusterIPs set by Kubernetes on the Service of the ActiveGate group
+	ServiceIPs []string `json:"serviceIPs,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type AutoscalingStatus struct {
+	// Last time the HorizontalPodAutoscaler scaled the ActiveGate
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Number of ActiveGate replicas currently managed by the HorizontalPodAutoscaler
+	CurrentReplicas int32 `json:"currentReplicas"`
+
+	// Number of ActiveGate replicas the HorizontalPodAutoscaler wants to scale to
+	DesiredReplicas int32 `json:"desiredReplicas"`
 }
 
 // Image provides the image reference set in Status for the ActiveGate.
@@ -26,3 +59,37 @@ func (ag *Status) GetImage() string {
 func (ag *Status) GetVersion() string {
 	return ag.Version
 }
+
+// GetServiceIPs provides the ClusterIPs of the Service of the given ActiveGate group, the default group has an empty name.
+func (ag *Status) GetServiceIPs(groupName string) []string {
+	if groupName == "" {
+		return ag.ServiceIPs
+	}
+
+	for _, group := range ag.Groups {
+		if group.Name == groupName {
+			return group.ServiceIPs
+		}
+	}
+
+	return nil
+}
+
+// SetServiceIPs sets the ClusterIPs of the Service of the given ActiveGate group, the default group has an empty name.
+func (ag *Status) SetServiceIPs(groupName string, serviceIPs []string) {
+	if groupName == "" {
+		ag.ServiceIPs = serviceIPs
+
+		return
+	}
+
+	for i := range ag.Groups {
+		if ag.Groups[i].Name == groupName {
+			ag.Groups[i].ServiceIPs = serviceIPs
+
+			return
+		}
+	}
+
+	ag.Groups = append(ag.Groups, GroupStatus{Name: groupName, ServiceIPs: serviceIPs})
+}
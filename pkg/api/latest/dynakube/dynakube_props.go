@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
@@ -36,6 +37,11 @@ func (dk *DynaKube) APIURL() string {
 
 func (dk *DynaKube) Conditions() *[]metav1.Condition { return &dk.Status.Conditions }
 
+// IsPaused is true while the reconciliation of the DynaKube is paused by the api.AnnotationPaused annotation.
+func (dk *DynaKube) IsPaused() bool {
+	return api.IsPaused(dk.Annotations)
+}
+
 // APIURLHost returns the host of dk.Spec.APIURL
 // E.g. if the APIURL is set to "https://my-tenant.dynatrace.com/api", it returns "my-tenant.dynatrace.com"
 // If the URL cannot be parsed, it returns an empty string.
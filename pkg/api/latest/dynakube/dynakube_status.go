@@ -58,6 +58,56 @@ type DynaKubeStatus struct { //nolint:revive
 
 	// Conditions includes status about the current state of the instance
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Components contains the rollout state of the workloads deployed for the DynaKube
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+type ComponentName string
+
+const (
+	OneAgentComponent                      ComponentName = "OneAgent"
+	ActiveGateComponent                    ComponentName = "ActiveGate"
+	ExtensionsExecutionControllerComponent ComponentName = "ExtensionsExecutionController"
+	OtelCollectorComponent                 ComponentName = "OtelCollector"
+	LogMonitoringComponent                 ComponentName = "LogMonitoring"
+	KSPMComponent                          ComponentName = "KSPM"
+	CSIDriverComponent                     ComponentName = "CSIDriver"
+)
+
+// ComponentStatus defines the observed rollout state of a workload deployed for the DynaKube
+type ComponentStatus struct {
+	// Time of the last change of the phase
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Name of the component
+	Name ComponentName `json:"name"`
+
+	// Kind of the workload running the component (StatefulSet, DaemonSet)
+	Kind string `json:"kind,omitempty"`
+
+	// Rollout state of the component (Running, Deploying, Error)
+	Phase status.DeploymentPhase `json:"phase,omitempty"`
+
+	// Image used by the pods of the component
+	Image string `json:"image,omitempty"`
+
+	// Version of the component, if known to the operator
+	Version string `json:"version,omitempty"`
+
+	// Details about the phase, e.g. why the component is not running
+	Message string `json:"message,omitempty"`
+
+	// Number of pods that should be running
+	Desired int32 `json:"desired"`
+
+	// Number of ready pods
+	Ready int32 `json:"ready"`
+
+	// Number of pods running the latest pod template
+	Updated int32 `json:"updated"`
 }
 
 type DynatraceAPIStatus struct {
@@ -110,6 +160,42 @@ func (dk *DynaKubeStatus) SetPhase(phase status.DeploymentPhase) bool {
 	return upd
 }
 
+// SetComponents replaces the component statuses, keeping the transition time of components whose phase did not change.
+func (dk *DynaKubeStatus) SetComponents(components []ComponentStatus) {
+	now := metav1.Now()
+
+	for i := range components {
+		components[i].LastTransitionTime = now
+
+		for _, previous := range dk.Components {
+			if previous.Name == components[i].Name && previous.Phase == components[i].Phase {
+				components[i].LastTransitionTime = previous.LastTransitionTime
+
+				break
+			}
+		}
+	}
+
+	dk.Components = components
+}
+
+// ComponentsPhase rolls the phases of all components up into a single phase.
+// Any component in Error results in Error, otherwise any Deploying component results in Deploying.
+func (dk *DynaKubeStatus) ComponentsPhase() status.DeploymentPhase {
+	phase := status.Running
+
+	for _, component := range dk.Components {
+		switch component.Phase {
+		case status.Error:
+			return status.Error
+		case status.Deploying:
+			phase = status.Deploying
+		}
+	}
+
+	return phase
+}
+
 func (dk *DynaKube) UpdateStatus(ctx context.Context, client client.Client) error {
 	dk.Status.UpdatedTimestamp = metav1.Now()
 	err := client.Status().Update(ctx, dk)
@@ -0,0 +1,69 @@
+package dynakube
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetComponents(t *testing.T) {
+	lastTransition := metav1.Unix(1, 0)
+
+	dkStatus := DynaKubeStatus{
+		Components: []ComponentStatus{
+			{Name: OneAgentComponent, Phase: status.Running, Ready: 2, LastTransitionTime: lastTransition},
+			{Name: ActiveGateComponent, Phase: status.Deploying, LastTransitionTime: lastTransition},
+		},
+	}
+
+	dkStatus.SetComponents([]ComponentStatus{
+		{Name: OneAgentComponent, Phase: status.Running, Ready: 3},
+		{Name: ActiveGateComponent, Phase: status.Running},
+		{Name: KSPMComponent, Phase: status.Deploying},
+	})
+
+	require.Len(t, dkStatus.Components, 3)
+	assert.Equal(t, lastTransition, dkStatus.Components[0].LastTransitionTime)
+	assert.Equal(t, int32(3), dkStatus.Components[0].Ready)
+	assert.True(t, lastTransition.Before(&dkStatus.Components[1].LastTransitionTime))
+	assert.False(t, dkStatus.Components[2].LastTransitionTime.IsZero())
+}
+
+func TestComponentsPhase(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []ComponentStatus
+		phase      status.DeploymentPhase
+	}{
+		{
+			name:  "no components",
+			phase: status.Running,
+		},
+		{
+			name:       "all running",
+			components: []ComponentStatus{{Phase: status.Running}, {Phase: status.Running}},
+			phase:      status.Running,
+		},
+		{
+			name:       "deploying wins over running",
+			components: []ComponentStatus{{Phase: status.Running}, {Phase: status.Deploying}},
+			phase:      status.Deploying,
+		},
+		{
+			name:       "error wins over deploying",
+			components: []ComponentStatus{{Phase: status.Deploying}, {Phase: status.Error}, {Phase: status.Running}},
+			phase:      status.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dkStatus := DynaKubeStatus{Components: test.components}
+
+			assert.Equal(t, test.phase, dkStatus.ComponentsPhase())
+		})
+	}
+}
@@ -48,6 +48,12 @@ const (
 // +kubebuilder:resource:path=dynakubes,scope=Namespaced,categories=dynatrace,shortName={dk,dks}
 // +kubebuilder:printcolumn:name="ApiUrl",type=string,JSONPath=`.spec.apiUrl`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="OneAgent",type=string,JSONPath=`.status.components[?(@.name=="OneAgent")].phase`
+// +kubebuilder:printcolumn:name="ActiveGate",type=string,JSONPath=`.status.components[?(@.name=="ActiveGate")].phase`
+// +kubebuilder:printcolumn:name="EEC",type=string,JSONPath=`.status.components[?(@.name=="ExtensionsExecutionController")].phase`,priority=1
+// +kubebuilder:printcolumn:name="OtelCollector",type=string,JSONPath=`.status.components[?(@.name=="OtelCollector")].phase`,priority=1
+// +kubebuilder:printcolumn:name="LogMonitoring",type=string,JSONPath=`.status.components[?(@.name=="LogMonitoring")].phase`,priority=1
+// +kubebuilder:printcolumn:name="KSPM",type=string,JSONPath=`.status.components[?(@.name=="KSPM")].phase`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +operator-sdk:csv:customresourcedefinitions:displayName="Dynatrace DynaKube"
 // +operator-sdk:csv:customresourcedefinitions:resources={{StatefulSet,v1,},{DaemonSet,v1,},{Pod,v1,}}
@@ -88,6 +94,17 @@ type DynaKubeSpec struct { //nolint:revive
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dynatrace API Request Threshold",order=9,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	DynatraceAPIRequestThreshold *uint16 `json:"dynatraceApiRequestThreshold,omitempty"`
 
+	// Time windows in which new versions of the OneAgent, ActiveGate and code modules are rolled out when auto-update is enabled.
+	// Outside of them a new version is only recorded as pending in the status. If not set, new versions are rolled out at any time.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maintenance Windows",order=9,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Configuration of the NetworkPolicies generated for clusters that deny traffic by default.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Policies",order=9,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+
 	// When an (empty) ExtensionsSpec is provided, the extensions related components (extensions controller and extensions collector)
 	// are deployed by the operator.
 	// +kubebuilder:validation:Optional
@@ -2,6 +2,7 @@ package dynakube
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -51,6 +52,11 @@ type ExtensionExecutionControllerSpec struct {
 	// Selects EmptyDir volume to be storage device
 	// +kubebuilder:validation:Optional
 	UseEphemeralVolume bool `json:"useEphemeralVolume,omitempty"`
+
+	// Limits how many ExtensionExecutionController pods may be unavailable during voluntary disruptions, like node drains.
+	// As there is a single replica, no PodDisruptionBudget is created unless it is configured.
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *pdb.Spec `json:"podDisruptionBudget,omitempty"`
 }
 
 type OpenTelemetryCollectorSpec struct {
@@ -85,4 +91,9 @@ type OpenTelemetryCollectorSpec struct {
 	// Adds TopologySpreadConstraints for the OtelCollector pods
 	// +kubebuilder:validation:Optional
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Limits how many OtelCollector pods may be unavailable during voluntary disruptions, like node drains.
+	// Defaults to one unavailable pod at a time, if there is more than one replica.
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *pdb.Spec `json:"podDisruptionBudget,omitempty"`
 }
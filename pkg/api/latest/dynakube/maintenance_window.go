@@ -0,0 +1,32 @@
+package dynakube
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// MaintenanceWindow is a recurring time range in which automatic updates are rolled out.
+// Either a cron schedule with a duration, or a start and end time (optionally limited to some days of the week) has to be set.
+type MaintenanceWindow struct {
+	// Cron expression (minute hour day-of-month month day-of-week) for the start of the window, e.g. `0 22 * * 1-5`.
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// How long the window stays open after each start of the schedule, e.g. `4h`. Required together with schedule.
+	// +kubebuilder:validation:Optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Days of the week on which the window starts, all days if not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	Days []string `json:"days,omitempty"`
+
+	// Start of the window as HH:MM.
+	// +kubebuilder:validation:Optional
+	Start string `json:"start,omitempty"`
+
+	// End of the window as HH:MM. If it isn't after the start, the window ends on the next day.
+	// +kubebuilder:validation:Optional
+	End string `json:"end,omitempty"`
+
+	// IANA name of the time zone of the window, e.g. `Europe/Vienna`. Defaults to UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
@@ -0,0 +1,241 @@
+package dynakube
+
+import (
+	"time"
+	// the operator image has no time zone database, the time zones of the maintenance windows have to be resolved anyway
+	_ "time/tzdata"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/cron"
+	"github.com/pkg/errors"
+)
+
+const (
+	timeOfDayLayout = "15:04"
+
+	// maxWindowDuration limits how far back the start of a scheduled window is searched
+	maxWindowDuration = 31 * 24 * time.Hour
+)
+
+var weekdays = map[string]time.Weekday{
+	time.Monday.String():    time.Monday,
+	time.Tuesday.String():   time.Tuesday,
+	time.Wednesday.String(): time.Wednesday,
+	time.Thursday.String():  time.Thursday,
+	time.Friday.String():    time.Friday,
+	time.Saturday.String():  time.Saturday,
+	time.Sunday.String():    time.Sunday,
+}
+
+// IsRolloutForced checks for the annotation that rolls out pending versions regardless of the maintenance windows.
+func (dk *DynaKube) IsRolloutForced() bool {
+	return dk.Annotations[api.AnnotationRolloutNow] == "true"
+}
+
+// IsInMaintenanceWindow checks if any of the maintenance windows is open at the given time.
+// Without maintenance windows, updates are allowed at any time.
+func (dk *DynaKube) IsInMaintenanceWindow(now time.Time) (bool, error) {
+	if len(dk.Spec.MaintenanceWindows) == 0 {
+		return true, nil
+	}
+
+	for _, window := range dk.Spec.MaintenanceWindows {
+		isOpen, err := window.IsOpen(now)
+		if err != nil {
+			return false, err
+		}
+
+		if isOpen {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// NextMaintenanceWindowStart returns the earliest start of any of the maintenance windows after the given time.
+// False is returned if there are no maintenance windows or none of them starts within the searched time.
+func (dk *DynaKube) NextMaintenanceWindowStart(now time.Time) (time.Time, bool, error) {
+	var next time.Time
+
+	for _, window := range dk.Spec.MaintenanceWindows {
+		start, ok, err := window.NextStart(now)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		if ok && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+
+	return next, !next.IsZero(), nil
+}
+
+// Validate checks that exactly one of the two kinds of windows is configured and that its fields can be parsed.
+func (window MaintenanceWindow) Validate() error {
+	_, err := window.IsOpen(time.Time{})
+
+	return err
+}
+
+func (window MaintenanceWindow) IsOpen(now time.Time) (bool, error) {
+	now, err := window.inTimeZone(now)
+	if err != nil {
+		return false, err
+	}
+
+	if window.Schedule != "" {
+		return window.isScheduleOpen(now)
+	}
+
+	return window.isTimeRangeOpen(now)
+}
+
+// NextStart returns the first start of the window after the given time.
+// A schedule is only searched as far ahead as a window may last, so false is returned for schedules that start less often.
+func (window MaintenanceWindow) NextStart(now time.Time) (time.Time, bool, error) {
+	now, err := window.inTimeZone(now)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if window.Schedule != "" {
+		return window.nextScheduleStart(now)
+	}
+
+	return window.nextTimeRangeStart(now)
+}
+
+// inTimeZone checks that exactly one of the two kinds of windows is configured and converts the time to the time zone of the window.
+func (window MaintenanceWindow) inTimeZone(now time.Time) (time.Time, error) {
+	location, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return now, errors.Errorf("unknown time zone '%s'", window.TimeZone)
+	}
+
+	switch {
+	case window.Schedule != "" && (window.Start != "" || window.End != "" || len(window.Days) > 0):
+		return now, errors.New("schedule can't be combined with start, end or days")
+	case window.Schedule == "" && (window.Start == "" || window.End == ""):
+		return now, errors.New("either schedule and duration or start and end have to be set")
+	}
+
+	return now.In(location), nil
+}
+
+// isScheduleOpen looks for a start of the schedule within the duration before now.
+func (window MaintenanceWindow) isScheduleOpen(now time.Time) (bool, error) {
+	schedule, err := cron.Parse(window.Schedule)
+	if err != nil {
+		return false, err
+	}
+
+	if window.Duration == nil || window.Duration.Duration < time.Minute {
+		return false, errors.New("duration of at least one minute has to be set together with schedule")
+	}
+
+	if window.Duration.Duration > maxWindowDuration {
+		return false, errors.Errorf("duration can't be longer than %s", maxWindowDuration)
+	}
+
+	for start := now.Truncate(time.Minute); now.Sub(start) < window.Duration.Duration; start = start.Add(-time.Minute) {
+		if schedule.Matches(start) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// nextScheduleStart looks for a start of the schedule within the maximum duration after now.
+func (window MaintenanceWindow) nextScheduleStart(now time.Time) (time.Time, bool, error) {
+	schedule, err := cron.Parse(window.Schedule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	for start := now.Truncate(time.Minute).Add(time.Minute); start.Sub(now) <= maxWindowDuration; start = start.Add(time.Minute) {
+		if schedule.Matches(start) {
+			return start, true, nil
+		}
+	}
+
+	return time.Time{}, false, nil
+}
+
+// isTimeRangeOpen checks the window starting today and the one starting yesterday, as it may end after midnight.
+func (window MaintenanceWindow) isTimeRangeOpen(now time.Time) (bool, error) {
+	start, end, days, err := window.parseTimeRange()
+	if err != nil {
+		return false, err
+	}
+
+	for _, dayOffset := range []int{0, -1} {
+		year, month, day := now.AddDate(0, 0, dayOffset).Date()
+
+		opens := time.Date(year, month, day, start.Hour(), start.Minute(), 0, 0, now.Location())
+		if len(days) > 0 && !days[opens.Weekday()] {
+			continue
+		}
+
+		closes := time.Date(year, month, day, end.Hour(), end.Minute(), 0, 0, now.Location())
+		if !closes.After(opens) {
+			closes = closes.AddDate(0, 0, 1)
+		}
+
+		if !now.Before(opens) && now.Before(closes) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// nextTimeRangeStart checks the starts of the next 8 days, the start of today may already be over.
+func (window MaintenanceWindow) nextTimeRangeStart(now time.Time) (time.Time, bool, error) {
+	start, _, days, err := window.parseTimeRange()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	for dayOffset := range 8 {
+		year, month, day := now.AddDate(0, 0, dayOffset).Date()
+
+		opens := time.Date(year, month, day, start.Hour(), start.Minute(), 0, 0, now.Location())
+		if len(days) > 0 && !days[opens.Weekday()] {
+			continue
+		}
+
+		if opens.After(now) {
+			return opens, true, nil
+		}
+	}
+
+	return time.Time{}, false, nil
+}
+
+func (window MaintenanceWindow) parseTimeRange() (start, end time.Time, days map[time.Weekday]bool, err error) {
+	start, err = time.Parse(timeOfDayLayout, window.Start)
+	if err != nil {
+		return start, end, nil, errors.Errorf("invalid start '%s', expected HH:MM", window.Start)
+	}
+
+	end, err = time.Parse(timeOfDayLayout, window.End)
+	if err != nil {
+		return start, end, nil, errors.Errorf("invalid end '%s', expected HH:MM", window.End)
+	}
+
+	days = make(map[time.Weekday]bool, len(window.Days))
+
+	for _, day := range window.Days {
+		weekday, ok := weekdays[day]
+		if !ok {
+			return start, end, nil, errors.Errorf("unknown day '%s'", day)
+		}
+
+		days[weekday] = true
+	}
+
+	return start, end, days, nil
+}
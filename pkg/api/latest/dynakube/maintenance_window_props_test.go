@@ -0,0 +1,179 @@
+package dynakube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindowIsOpen(t *testing.T) {
+	// 2025-01-06 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 6, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("time range", func(t *testing.T) {
+		window := MaintenanceWindow{Start: "08:00", End: "10:00"}
+
+		assertOpen(t, window, monday(8, 0))
+		assertOpen(t, window, monday(9, 59))
+		assertClosed(t, window, monday(10, 0))
+		assertClosed(t, window, monday(7, 59))
+	})
+	t.Run("time range over midnight", func(t *testing.T) {
+		window := MaintenanceWindow{Start: "22:00", End: "02:00", Days: []string{"Sunday"}}
+
+		// the window starting on Sunday ends on Monday
+		assertOpen(t, window, monday(1, 0))
+		assertClosed(t, window, monday(2, 0))
+		assertClosed(t, window, monday(23, 0))
+	})
+	t.Run("time range with time zone", func(t *testing.T) {
+		window := MaintenanceWindow{Start: "08:00", End: "10:00", TimeZone: "Asia/Tokyo"}
+
+		assertOpen(t, window, monday(0, 30))
+		assertClosed(t, window, monday(8, 30))
+	})
+	t.Run("schedule", func(t *testing.T) {
+		window := MaintenanceWindow{Schedule: "30 22 * * 1-5", Duration: &metav1.Duration{Duration: 4 * time.Hour}}
+
+		assertOpen(t, window, monday(22, 30))
+		assertOpen(t, window, monday(23, 59))
+		assertClosed(t, window, monday(22, 29))
+		assertOpen(t, window, monday(24+2, 29))
+		assertClosed(t, window, monday(24+2, 30))
+	})
+	t.Run("invalid windows", func(t *testing.T) {
+		for _, window := range []MaintenanceWindow{
+			{},
+			{Start: "08:00"},
+			{Start: "08:00", End: "10:00", Days: []string{"Mon"}},
+			{Start: "08:00", End: "10:00", TimeZone: "Mars/Olympus_Mons"},
+			{Schedule: "0 22 * * *"},
+			{Schedule: "0 22 * *", Duration: &metav1.Duration{Duration: time.Hour}},
+			{Schedule: "0 22 * * *", Duration: &metav1.Duration{Duration: time.Hour}, Start: "08:00"},
+			{Schedule: "0 22 * * *", Duration: &metav1.Duration{Duration: 32 * 24 * time.Hour}},
+		} {
+			require.Error(t, window.Validate(), "%+v", window)
+		}
+	})
+}
+
+func TestIsInMaintenanceWindow(t *testing.T) {
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	t.Run("no windows => always open", func(t *testing.T) {
+		isOpen, err := (&DynaKube{}).IsInMaintenanceWindow(now)
+		require.NoError(t, err)
+		assert.True(t, isOpen)
+	})
+	t.Run("any window open", func(t *testing.T) {
+		dk := &DynaKube{Spec: DynaKubeSpec{MaintenanceWindows: []MaintenanceWindow{
+			{Start: "22:00", End: "23:00"},
+			{Start: "08:00", End: "10:00"},
+		}}}
+
+		isOpen, err := dk.IsInMaintenanceWindow(now)
+		require.NoError(t, err)
+		assert.True(t, isOpen)
+
+		isOpen, err = dk.IsInMaintenanceWindow(now.Add(3 * time.Hour))
+		require.NoError(t, err)
+		assert.False(t, isOpen)
+	})
+}
+
+func TestMaintenanceWindowNextStart(t *testing.T) {
+	// 2025-01-06 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 6, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("time range starting later today", func(t *testing.T) {
+		window := MaintenanceWindow{Start: "08:00", End: "08:10"}
+
+		assertNextStart(t, window, monday(7, 30), monday(8, 0))
+	})
+	t.Run("time range already started today", func(t *testing.T) {
+		window := MaintenanceWindow{Start: "08:00", End: "08:10"}
+
+		assertNextStart(t, window, monday(8, 0), monday(24+8, 0))
+	})
+	t.Run("time range on other days", func(t *testing.T) {
+		window := MaintenanceWindow{Start: "08:00", End: "08:10", Days: []string{"Sunday"}}
+
+		assertNextStart(t, window, monday(9, 0), monday(6*24+8, 0))
+	})
+	t.Run("time range with time zone", func(t *testing.T) {
+		window := MaintenanceWindow{Start: "08:00", End: "10:00", TimeZone: "Asia/Tokyo"}
+
+		assertNextStart(t, window, monday(0, 0), monday(23, 0))
+	})
+	t.Run("schedule", func(t *testing.T) {
+		window := MaintenanceWindow{Schedule: "30 22 * * 1-5", Duration: &metav1.Duration{Duration: 5 * time.Minute}}
+
+		assertNextStart(t, window, monday(9, 0), monday(22, 30))
+		assertNextStart(t, window, monday(22, 30), monday(24+22, 30))
+	})
+	t.Run("schedule starting later than the maximum duration", func(t *testing.T) {
+		window := MaintenanceWindow{Schedule: "0 0 1 6 *", Duration: &metav1.Duration{Duration: time.Hour}}
+
+		_, ok, err := window.NextStart(monday(0, 0))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("invalid window", func(t *testing.T) {
+		_, _, err := MaintenanceWindow{Start: "08:00"}.NextStart(monday(0, 0))
+		require.Error(t, err)
+	})
+}
+
+func TestNextMaintenanceWindowStart(t *testing.T) {
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	t.Run("no windows => no start", func(t *testing.T) {
+		_, ok, err := (&DynaKube{}).NextMaintenanceWindowStart(now)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("earliest start of all windows", func(t *testing.T) {
+		dk := &DynaKube{Spec: DynaKubeSpec{MaintenanceWindows: []MaintenanceWindow{
+			{Start: "22:00", End: "23:00"},
+			{Start: "10:00", End: "10:10"},
+			{Start: "08:00", End: "10:00"},
+		}}}
+
+		next, ok, err := dk.NextMaintenanceWindowStart(now)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, now.Add(time.Hour), next.UTC())
+	})
+}
+
+func assertNextStart(t *testing.T, window MaintenanceWindow, now, expected time.Time) {
+	t.Helper()
+
+	next, ok, err := window.NextStart(now)
+	require.NoError(t, err)
+	require.True(t, ok, now)
+	assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+}
+
+func assertOpen(t *testing.T, window MaintenanceWindow, now time.Time) {
+	t.Helper()
+
+	isOpen, err := window.IsOpen(now)
+	require.NoError(t, err)
+	assert.True(t, isOpen, now)
+}
+
+func assertClosed(t *testing.T, window MaintenanceWindow, now time.Time) {
+	t.Helper()
+
+	isOpen, err := window.IsOpen(now)
+	require.NoError(t, err)
+	assert.False(t, isOpen, now)
+}
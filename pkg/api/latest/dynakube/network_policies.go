@@ -0,0 +1,10 @@
+package dynakube
+
+type NetworkPoliciesSpec struct {
+	// Enables the generation of NetworkPolicies, `false` by default.
+	// They allow the traffic of the components deployed for the DynaKube, and the traffic of the monitored pods to the ActiveGate and the Dynatrace environment.
+	// The NetworkPolicy created in the namespaces injected by the DynaKube only selects the injected pods, so it isolates their egress traffic
+	// to the DNS, the ActiveGate, the Dynatrace environment and the destinations allowed by other NetworkPolicies.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+}
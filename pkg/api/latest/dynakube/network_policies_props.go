@@ -0,0 +1,21 @@
+package dynakube
+
+func (dk *DynaKube) NetworkPoliciesEnabled() bool {
+	return dk.Spec.NetworkPolicies != nil && dk.Spec.NetworkPolicies.Enabled
+}
+
+func (dk *DynaKube) ActiveGateNetworkPolicyName() string {
+	return dk.Name + "-activegate"
+}
+
+func (dk *DynaKube) ExtensionsExecutionControllerNetworkPolicyName() string {
+	return dk.ExtensionsExecutionControllerStatefulsetName()
+}
+
+func (dk *DynaKube) OtelCollectorNetworkPolicyName() string {
+	return dk.OtelCollectorStatefulsetName()
+}
+
+func (dk *DynaKube) WebhookNetworkPolicyName() string {
+	return dk.Name + "-webhook"
+}
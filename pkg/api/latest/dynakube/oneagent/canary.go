@@ -0,0 +1,66 @@
+package oneagent
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+type CanaryPhase string
+
+const (
+	// CanaryPhaseRollingOut is set while the OneAgent pods on the canary nodes are replaced with the new version.
+	CanaryPhaseRollingOut CanaryPhase = "RollingOut"
+	// CanaryPhaseBaking is set while the updated canary pods have to stay healthy for the bake time.
+	CanaryPhaseBaking CanaryPhase = "Baking"
+	// CanaryPhaseCompleted is set once the new version is rolled out to the rest of the nodes.
+	CanaryPhaseCompleted CanaryPhase = "Completed"
+	// CanaryPhaseAborted is set if the canary pods became unhealthy, the previous version is rolled back.
+	CanaryPhaseAborted CanaryPhase = "Aborted"
+)
+
+// +kubebuilder:object:generate=true
+type CanarySpec struct {
+	// Nodes that get a new OneAgent version first.
+	// +kubebuilder:validation:Optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// Percentage of the OneAgent nodes that get a new version first, only used if no node selector is set. Defaults to 10.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// How long the updated canary pods have to stay ready before the rest of the nodes are updated. Defaults to 10m.
+	// +kubebuilder:validation:Optional
+	BakeTime *metav1.Duration `json:"bakeTime,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type CanaryStatus struct {
+	// Time when the canary rollout was started
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Time when all canary pods were updated and ready, start of the bake time
+	BakeStartTimestamp *metav1.Time `json:"bakeStartTimestamp,omitempty"`
+
+	// Phase of the canary rollout (RollingOut, Baking, Completed, Aborted)
+	Phase CanaryPhase `json:"phase,omitempty"`
+
+	// Version that is rolled out
+	Version string `json:"version,omitempty"`
+
+	// Image ID that is rolled out, an aborted image isn't rolled out again
+	ImageID string `json:"imageID,omitempty"`
+
+	// Version that ran before the rollout
+	PreviousVersion string `json:"previousVersion,omitempty"`
+
+	// Image ID that ran before the rollout, it is restored if the rollout is aborted
+	PreviousImageID string `json:"previousImageID,omitempty"`
+
+	// Details about the progress of the rollout or why it was aborted
+	Message string `json:"message,omitempty"`
+
+	// Nodes that get the new version first
+	Nodes []string `json:"nodes,omitempty"`
+
+	// Number of canary nodes with an updated and ready OneAgent pod
+	UpdatedNodes int32 `json:"updatedNodes,omitempty"`
+}
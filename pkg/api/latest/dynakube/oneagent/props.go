@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/dtversion"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
 	corev1 "k8s.io/api/core/v1"
@@ -95,6 +96,43 @@ func (oa *OneAgent) IsAutoUpdateEnabled() bool {
 	}
 }
 
+// GetUpdatePolicy returns the policy restricting which versions are rolled out automatically, nil if the latest version is used.
+func (oa *OneAgent) GetUpdatePolicy() *update.Policy {
+	switch {
+	case oa.IsCloudNativeFullstackMode():
+		return oa.CloudNativeFullStack.UpdatePolicy
+	case oa.IsHostMonitoringMode():
+		return oa.HostMonitoring.UpdatePolicy
+	case oa.IsClassicFullStackMode():
+		return oa.ClassicFullStack.UpdatePolicy
+	default:
+		return nil
+	}
+}
+
+// GetCanary returns the canary rollout configuration of the OneAgent DaemonSet, nil if new versions are rolled out to all nodes at once.
+func (oa *OneAgent) GetCanary() *CanarySpec {
+	switch {
+	case oa.IsCloudNativeFullstackMode():
+		return oa.CloudNativeFullStack.Canary
+	case oa.IsHostMonitoringMode():
+		return oa.HostMonitoring.Canary
+	case oa.IsClassicFullStackMode():
+		return oa.ClassicFullStack.Canary
+	default:
+		return nil
+	}
+}
+
+// IsCanaryRolloutInProgress returns true while a new version is rolled out to the canary nodes or is baking there.
+func (oa *OneAgent) IsCanaryRolloutInProgress() bool {
+	if oa.Status == nil || oa.Status.Canary == nil {
+		return false
+	}
+
+	return oa.Status.Canary.Phase == CanaryPhaseRollingOut || oa.Status.Canary.Phase == CanaryPhaseBaking
+}
+
 // GetTenantSecret returns the name of the secret containing the token for the OneAgent.
 func (oa *OneAgent) GetTenantSecret() string {
 	return oa.name + OneAgentTenantSecretSuffix
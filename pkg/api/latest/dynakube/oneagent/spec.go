@@ -1,6 +1,7 @@
 package oneagent
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -80,6 +81,11 @@ type HostInjectSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Automatically update Agent",order=13,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	AutoUpdate *bool `json:"autoUpdate"`
 
+	// Restricts which versions are rolled out by automatic updates, by a version constraint, an update mode and a minimum release age.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Update policy",order=14,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	UpdatePolicy *update.Policy `json:"updatePolicy,omitempty"`
+
 	// Use a specific OneAgent version. Defaults to the latest version from the Dynatrace cluster.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OneAgent version",order=11,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
@@ -126,6 +132,12 @@ type HostInjectSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OneAgent environment variable installer arguments",order=22,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Roll out new OneAgent versions to a canary set of nodes first. The rest of the nodes are only updated
+	// if the canary pods stay healthy for the bake time, otherwise the rollout is aborted and the previous version is restored.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Canary rollout",order=29,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Canary *CanarySpec `json:"canary,omitempty"`
+
 	// Set additional arguments to the OneAgent installer.
 	// For available options, see Linux custom installation (https://www.dynatrace.com/support/help/setup-and-configuration/dynatrace-oneagent/installation-and-operation/linux/installation/customize-oneagent-installation-on-linux).
 	// For the list of limitations, see Limitations (https://www.dynatrace.com/support/help/setup-and-configuration/setup-on-container-platforms/docker/set-up-dynatrace-oneagent-as-docker-container#limitations).
@@ -168,4 +180,6 @@ type AppInjectionSpec struct {
 // +kubebuilder:object:generate=true
 type CodeModulesStatus struct {
 	status.VersionStatus `json:",inline"`
+
+	update.RolloutStatus `json:",inline"`
 }
@@ -2,6 +2,7 @@ package oneagent
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	containerv1 "github.com/google/go-containerregistry/pkg/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,6 +12,8 @@ import (
 type Status struct {
 	status.VersionStatus `json:",inline"`
 
+	update.RolloutStatus `json:",inline"`
+
 	// List of deployed OneAgent instances
 	Instances map[string]Instance `json:"instances,omitempty"`
 
@@ -25,6 +28,9 @@ type Status struct {
 
 	// Information about OneAgent's connections
 	ConnectionInfoStatus ConnectionInfoStatus `json:"connectionInfoStatus,omitempty"`
+
+	// Progress of the canary rollout of the last OneAgent version
+	Canary *CanaryStatus `json:"canary,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
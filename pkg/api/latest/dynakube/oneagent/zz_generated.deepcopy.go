@@ -19,8 +19,10 @@ limitations under the License.
 package oneagent
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	pkgv1 "github.com/google/go-containerregistry/pkg/v1"
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -28,7 +30,7 @@ func (in *AppInjectionSpec) DeepCopyInto(out *AppInjectionSpec) {
 	*out = *in
 	if in.InitResources != nil {
 		in, out := &in.InitResources, &out.InitResources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
@@ -60,6 +62,64 @@ func (in *ApplicationMonitoringSpec) DeepCopy() *ApplicationMonitoringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BakeTime != nil {
+		in, out := &in.BakeTime, &out.BakeTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.BakeStartTimestamp != nil {
+		in, out := &in.BakeStartTimestamp, &out.BakeStartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudNativeFullStackSpec) DeepCopyInto(out *CloudNativeFullStackSpec) {
 	*out = *in
@@ -81,6 +141,7 @@ func (in *CloudNativeFullStackSpec) DeepCopy() *CloudNativeFullStackSpec {
 func (in *CodeModulesStatus) DeepCopyInto(out *CodeModulesStatus) {
 	*out = *in
 	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+	in.RolloutStatus.DeepCopyInto(&out.RolloutStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodeModulesStatus.
@@ -158,21 +219,31 @@ func (in *HostInjectSpec) DeepCopyInto(out *HostInjectSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UpdatePolicy != nil {
+		in, out := &in.UpdatePolicy, &out.UpdatePolicy
+		*out = new(update.Policy)
+		**out = **in
+	}
 	in.OneAgentResources.DeepCopyInto(&out.OneAgentResources)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
 		*out = make([]string, len(*in))
@@ -244,6 +315,7 @@ func (in *Spec) DeepCopy() *Spec {
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
 	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+	in.RolloutStatus.DeepCopyInto(&out.RolloutStatus)
 	if in.Instances != nil {
 		in, out := &in.Instances, &out.Instances
 		*out = make(map[string]Instance, len(*in))
@@ -261,6 +333,11 @@ func (in *Status) DeepCopyInto(out *Status) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.ConnectionInfoStatus.DeepCopyInto(&out.ConnectionInfoStatus)
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Status.
@@ -0,0 +1,41 @@
+package pdb
+
+import "k8s.io/apimachinery/pkg/util/intstr"
+
+// +kubebuilder:object:generate=true
+type Spec struct {
+	// Number or percentage of pods that have to stay available during voluntary disruptions, like node drains.
+	// Cannot be set together with maxUnavailable.
+	// +kubebuilder:validation:Optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Number or percentage of pods that may be unavailable during voluntary disruptions, like node drains.
+	// Defaults to 1, if minAvailable isn't set either.
+	// +kubebuilder:validation:Optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// GetBudget returns the budget to apply to a workload with the given number of replicas, or nil if no PodDisruptionBudget is needed.
+// Without a configured budget, one pod may be unavailable at a time, if there is more than one replica.
+func GetBudget(spec *Spec, replicas int32) *Spec {
+	if spec == nil {
+		if replicas <= 1 {
+			return nil
+		}
+
+		spec = &Spec{}
+	}
+
+	budget := spec.DeepCopy()
+	if budget.MinAvailable == nil && budget.MaxUnavailable == nil {
+		defaultMaxUnavailable := intstr.FromInt32(1)
+		budget.MaxUnavailable = &defaultMaxUnavailable
+	}
+
+	return budget
+}
+
+// IsConflicting checks if both minAvailable and maxUnavailable are set, which isn't allowed by Kubernetes.
+func (spec *Spec) IsConflicting() bool {
+	return spec != nil && spec.MinAvailable != nil && spec.MaxUnavailable != nil
+}
@@ -0,0 +1,31 @@
+package pdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestGetBudget(t *testing.T) {
+	defaultMaxUnavailable := intstr.FromInt32(1)
+	minAvailable := intstr.FromString("50%")
+
+	t.Run("no budget and single replica => no budget needed", func(t *testing.T) {
+		assert.Nil(t, GetBudget(nil, 1))
+	})
+	t.Run("no budget and multiple replicas => one pod may be unavailable", func(t *testing.T) {
+		assert.Equal(t, &Spec{MaxUnavailable: &defaultMaxUnavailable}, GetBudget(nil, 3))
+	})
+	t.Run("empty budget => one pod may be unavailable", func(t *testing.T) {
+		assert.Equal(t, &Spec{MaxUnavailable: &defaultMaxUnavailable}, GetBudget(&Spec{}, 1))
+	})
+	t.Run("configured budget => used as is", func(t *testing.T) {
+		spec := &Spec{MinAvailable: &minAvailable}
+
+		budget := GetBudget(spec, 1)
+
+		assert.Equal(t, spec, budget)
+		assert.NotSame(t, spec, budget)
+	})
+}
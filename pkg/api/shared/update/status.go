@@ -0,0 +1,44 @@
+package update
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +kubebuilder:object:generate=true
+
+// RolloutStatus records how automatic updates of a DynaKube component were held back or rolled back.
+type RolloutStatus struct {
+	// Version that is available, but not rolled out yet as no maintenance window is open
+	PendingVersion string `json:"pendingVersion,omitempty"`
+	// Image ID that is available, but not rolled out yet as no maintenance window is open
+	PendingImageID string `json:"pendingImageID,omitempty"`
+	// Why the version was selected, or no update was done, by the update policy
+	UpdatePolicyReason string `json:"updatePolicyReason,omitempty"`
+	// Last version whose pods were all running and ready, it is restored if the rollout of a newer version fails
+	LastKnownGood *KnownVersion `json:"lastKnownGood,omitempty"`
+	// Version whose rollout failed and was rolled back, it isn't rolled out again until the rollout is forced
+	RolledBack *RolledBackVersion `json:"rolledBack,omitempty"`
+}
+
+// HasPendingUpdate checks if a version is held back until a maintenance window opens.
+func (rollout RolloutStatus) HasPendingUpdate() bool {
+	return rollout.PendingVersion != "" || rollout.PendingImageID != ""
+}
+
+// +kubebuilder:object:generate=true
+type KnownVersion struct {
+	// Image ID
+	ImageID string `json:"imageID,omitempty"`
+	// Image version
+	Version string `json:"version,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type RolledBackVersion struct {
+	// Indicates when the version was rolled back
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+	// Image ID
+	ImageID string `json:"imageID,omitempty"`
+	// Image version
+	Version string `json:"version,omitempty"`
+	// Why the rollout of the version failed
+	Reason string `json:"reason,omitempty"`
+}
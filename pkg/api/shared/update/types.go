@@ -0,0 +1,27 @@
+package update
+
+type Mode string
+
+const (
+	// PatchOnlyMode allows updates within the minor version that is currently rolled out.
+	PatchOnlyMode Mode = "patch-only"
+	// MinorOnlyMode allows updates within the major version that is currently rolled out.
+	MinorOnlyMode Mode = "minor-only"
+)
+
+// +kubebuilder:object:generate=true
+type Policy struct {
+	// Version constraint new versions have to satisfy, like "~1.311", "<1.320" or ">=1.300, <1.320".
+	// +kubebuilder:validation:Optional
+	Constraint string `json:"constraint,omitempty"`
+
+	// Restricts updates to the current minor version (patch-only) or the current major version (minor-only).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=patch-only;minor-only
+	Mode Mode `json:"mode,omitempty"`
+
+	// Minimum number of days since a version was built, before it is rolled out.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinReleaseAgeDays int32 `json:"minReleaseAgeDays,omitempty"`
+}
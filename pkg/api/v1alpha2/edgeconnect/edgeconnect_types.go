@@ -6,6 +6,7 @@ package edgeconnect
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/proxy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2"
@@ -79,6 +80,11 @@ type EdgeConnectSpec struct { //nolint:revive
 	// Host patterns to be set in the tenant, only considered when provisioning is enabled.
 	// +kubebuilder:validation:Optional
 	HostPatterns []string `json:"hostPatterns,omitempty"`
+
+	// Limits how many EdgeConnect pods may be unavailable during voluntary disruptions, like node drains.
+	// Defaults to one unavailable pod at a time, if there is more than one replica.
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *pdb.Spec `json:"podDisruptionBudget,omitempty"`
 }
 
 type OAuthSpec struct {
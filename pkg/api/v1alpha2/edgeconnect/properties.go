@@ -32,6 +32,11 @@ func (ec *EdgeConnect) Image() string {
 	return fmt.Sprintf("%s:%s", repository, tag)
 }
 
+// IsPaused is true while the reconciliation of the EdgeConnect is paused by the api.AnnotationPaused annotation.
+func (ec *EdgeConnect) IsPaused() bool {
+	return api.IsPaused(ec.Annotations)
+}
+
 func (ec *EdgeConnect) IsCustomImage() bool {
 	return ec.Spec.ImageRef.Repository != ""
 }
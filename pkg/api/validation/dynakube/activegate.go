@@ -1,12 +1,25 @@
 package validation
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -16,15 +29,58 @@ Make sure you correctly specify the ActiveGate capabilities in your custom resou
 
 	errorDuplicateActiveGateCapability = `The DynaKube's specification tries to specify duplicate capabilities in the ActiveGate section, duplicate capability=%s.
 Make sure you don't duplicate an Activegate capability in your custom resource.
+`
+	errorDuplicateActiveGateGroupName = `The DynaKube's specification defines the ActiveGate group '%s' more than once.
+Make sure the names of the ActiveGate groups are unique in your custom resource.
 `
 	errorActiveGateInvalidPVCConfiguration = ` DynaKube specifies a PVC for the ActiveGate while ephemeral volume is also enabled. These settings are mutually exclusive, please choose only one.`
 
+	errorActiveGateInvalidAutoscaling = `The DynaKube's specification sets minReplicas=%d for the ActiveGate autoscaling, which is greater than maxReplicas=%d.`
+
+	errorActiveGateAutoscalingWithGroups = `The DynaKube's specification enables autoscaling for the ActiveGate together with ActiveGate groups. Autoscaling isn't supported for ActiveGate groups, set the replicas of the groups instead.`
+
+	errorActiveGateInvalidPodTemplateOverride = `The DynaKube's specification has an invalid pod template override for the ActiveGate: %s`
+
+	errorActiveGateForbiddenPodTemplateOverride = `The DynaKube's specification overrides '%s' in the pod template of the ActiveGate, which is managed by the operator.`
+
 	warningMissingActiveGateMemoryLimit = `ActiveGate specification missing memory limits. Can cause excess memory usage.`
+
+	warningActiveGateReplicasIgnored = `The DynaKube's specification sets replicas for the ActiveGate while autoscaling is enabled. The replicas are ignored, as they are managed by the HorizontalPodAutoscaler.`
+)
+
+var (
+	// operatorVolumeNames are the volumes the operator adds to the ActiveGate pods.
+	operatorVolumeNames = []string{
+		agconsts.AuthTokenSecretVolumeName,
+		agconsts.TLSCertsVolumeName,
+		agconsts.TrustedCAsVolumeName,
+		agconsts.TrustStoreVolumeName,
+		agconsts.CertLoaderWorkDirVolumeName,
+		agconsts.KSPMTokenVolumeName,
+		agconsts.EECTokenVolumeName,
+		agconsts.CustomPropertiesVolumeName,
+		agconsts.GatewayConfigVolumeName,
+		agconsts.GatewayLibTempVolumeName,
+		agconsts.GatewayDataVolumeName,
+		agconsts.GatewaySslVolumeName,
+		agconsts.GatewayLogVolumeName,
+		agconsts.GatewayTmpVolumeName,
+		connectioninfo.TenantSecretVolumeName,
+		proxy.SecretVolumeName,
+	}
+
+	// operatorMountDirs are the directories of the activegate container the operator mounts its volumes into.
+	operatorMountDirs = []string{
+		"/var/lib/dynatrace",
+		"/var/log/dynatrace",
+		"/var/tmp/dynatrace",
+		"/opt/dynatrace",
+	}
 )
 
 func duplicateActiveGateCapabilities(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
 	if dk.ActiveGate().IsEnabled() {
-		capabilities := dk.Spec.ActiveGate.Capabilities
+		capabilities := allActiveGateCapabilities(dk)
 		duplicateChecker := map[activegate.CapabilityDisplayName]bool{}
 
 		for _, capability := range capabilities {
@@ -43,7 +99,7 @@ func duplicateActiveGateCapabilities(_ context.Context, _ *Validator, dk *dynaku
 
 func invalidActiveGateCapabilities(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
 	if dk.ActiveGate().IsEnabled() {
-		capabilities := dk.Spec.ActiveGate.Capabilities
+		capabilities := allActiveGateCapabilities(dk)
 		for _, capability := range capabilities {
 			if _, ok := activegate.CapabilityDisplayNames[capability]; !ok {
 				log.Info("requested dynakube has invalid active gate capability", "name", dk.Name, "namespace", dk.Namespace)
@@ -56,12 +112,45 @@ func invalidActiveGateCapabilities(_ context.Context, _ *Validator, dk *dynakube
 	return ""
 }
 
+// allActiveGateCapabilities returns the capabilities of the default ActiveGate and of all ActiveGate groups,
+// as a capability can only be deployed once per DynaKube.
+func allActiveGateCapabilities(dk *dynakube.DynaKube) []activegate.CapabilityDisplayName {
+	capabilities := append([]activegate.CapabilityDisplayName{}, dk.Spec.ActiveGate.Capabilities...)
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		capabilities = append(capabilities, group.Capabilities...)
+	}
+
+	return capabilities
+}
+
+func duplicateActiveGateGroupNames(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	duplicateChecker := map[string]bool{}
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if duplicateChecker[group.Name] {
+			log.Info("requested dynakube has duplicate ActiveGate group names", "name", dk.Name, "namespace", dk.Namespace)
+
+			return fmt.Sprintf(errorDuplicateActiveGateGroupName, group.Name)
+		}
+
+		duplicateChecker[group.Name] = true
+	}
+
+	return ""
+}
+
 func missingActiveGateMemoryLimit(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
-	if dk.ActiveGate().IsEnabled() &&
+	if dk.ActiveGate().IsDefaultEnabled() &&
 		!memoryLimitSet(dk.Spec.ActiveGate.Resources) {
 		return warningMissingActiveGateMemoryLimit
 	}
 
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if !memoryLimitSet(group.Resources) {
+			return warningMissingActiveGateMemoryLimit
+		}
+	}
+
 	return ""
 }
 
@@ -82,3 +171,159 @@ func mutuallyExclusiveActiveGatePVsettings(_ context.Context, _ *Validator, dk *
 
 	return ""
 }
+
+func invalidActiveGateAutoscaling(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	autoscaling := dk.Spec.ActiveGate.Autoscaling
+	if !dk.ActiveGate().IsEnabled() || autoscaling == nil {
+		return ""
+	}
+
+	if len(dk.Spec.ActiveGate.Groups) > 0 {
+		log.Info("requested dynakube enables ActiveGate autoscaling together with ActiveGate groups", "name", dk.Name, "namespace", dk.Namespace)
+
+		return errorActiveGateAutoscalingWithGroups
+	}
+
+	if autoscaling.MinReplicas == nil {
+		return ""
+	}
+
+	if *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+		log.Info("requested dynakube has invalid ActiveGate autoscaling", "name", dk.Name, "namespace", dk.Namespace)
+
+		return fmt.Sprintf(errorActiveGateInvalidAutoscaling, *autoscaling.MinReplicas, autoscaling.MaxReplicas)
+	}
+
+	return ""
+}
+
+func ignoredActiveGateReplicas(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.ActiveGate().IsEnabled() && dk.ActiveGate().IsAutoscalingEnabled() && dk.Spec.ActiveGate.Replicas != nil {
+		return warningActiveGateReplicasIgnored
+	}
+
+	return ""
+}
+
+func invalidActiveGatePodTemplateOverride(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if !dk.ActiveGate().IsEnabled() || !dk.ActiveGate().HasPodTemplateOverride() {
+		return ""
+	}
+
+	template, err := decodePodTemplateOverride(dk.Spec.ActiveGate.PodTemplateOverride.Raw)
+	if err != nil {
+		log.Info("requested dynakube has invalid ActiveGate pod template override", "name", dk.Name, "namespace", dk.Namespace)
+
+		return fmt.Sprintf(errorActiveGateInvalidPodTemplateOverride, err.Error())
+	}
+
+	if field := forbiddenPodTemplateOverrideField(template); field != "" {
+		log.Info("requested dynakube overrides a field of the ActiveGate pod template managed by the operator", "name", dk.Name, "namespace", dk.Namespace, "field", field)
+
+		return fmt.Sprintf(errorActiveGateForbiddenPodTemplateOverride, field)
+	}
+
+	return ""
+}
+
+// decodePodTemplateOverride rejects unknown fields, so typos and patch directives like $patch aren't silently ignored.
+func decodePodTemplateOverride(raw []byte) (*corev1.PodTemplateSpec, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var template corev1.PodTemplateSpec
+
+	err := decoder.Decode(&template)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range append(template.Spec.InitContainers, template.Spec.Containers...) {
+		if container.Name == "" {
+			return nil, errors.New("containers need a name to be merged")
+		}
+	}
+
+	for _, volume := range template.Spec.Volumes {
+		if volume.Name == "" {
+			return nil, errors.New("volumes need a name to be merged")
+		}
+	}
+
+	return &template, nil
+}
+
+// forbiddenPodTemplateOverrideField returns the first field of the override that is managed by the operator, or an empty string.
+func forbiddenPodTemplateOverrideField(template *corev1.PodTemplateSpec) string {
+	metadata := template.ObjectMeta.DeepCopy()
+	metadata.Labels = nil
+	metadata.Annotations = nil
+
+	if !reflect.DeepEqual(*metadata, metav1.ObjectMeta{}) {
+		return "metadata"
+	}
+
+	for _, key := range []string{labels.AppNameLabel, labels.AppCreatedByLabel, labels.AppManagedByLabel, labels.AppComponentLabel, labels.AppVersionLabel} {
+		if _, ok := template.Labels[key]; ok {
+			return "metadata.labels." + key
+		}
+	}
+
+	for key := range template.Annotations {
+		if strings.HasPrefix(key, api.InternalFlagPrefix) {
+			return "metadata.annotations." + key
+		}
+	}
+
+	for _, volume := range template.Spec.Volumes {
+		if slices.Contains(operatorVolumeNames, volume.Name) {
+			return fmt.Sprintf("spec.volumes[%s]", volume.Name)
+		}
+	}
+
+	for _, container := range template.Spec.Containers {
+		if container.Name != agconsts.ActiveGateContainerName {
+			continue
+		}
+
+		// volumeMounts are merged by their mountPath, so mounts into the directories of the ActiveGate could replace the ones of the operator
+		for _, volumeMount := range container.VolumeMounts {
+			if slices.Contains(operatorVolumeNames, volumeMount.Name) || isOperatorMountPath(volumeMount.MountPath) {
+				return fmt.Sprintf("spec.containers[%s].volumeMounts[%s]", agconsts.ActiveGateContainerName, volumeMount.MountPath)
+			}
+		}
+
+		managedFields := []struct {
+			name string
+			set  bool
+		}{
+			{"image", container.Image != ""},
+			{"imagePullPolicy", container.ImagePullPolicy != ""},
+			{"command", len(container.Command) > 0},
+			{"args", len(container.Args) > 0},
+			{"env", len(container.Env) > 0},
+			{"envFrom", len(container.EnvFrom) > 0},
+			{"ports", len(container.Ports) > 0},
+			{"readinessProbe", container.ReadinessProbe != nil},
+		}
+		for _, field := range managedFields {
+			if field.set {
+				return fmt.Sprintf("spec.containers[%s].%s", agconsts.ActiveGateContainerName, field.name)
+			}
+		}
+	}
+
+	return ""
+}
+
+func isOperatorMountPath(mountPath string) bool {
+	cleanPath := path.Clean(mountPath)
+
+	for _, dir := range operatorMountDirs {
+		if cleanPath == dir || strings.HasPrefix(cleanPath, dir+"/") {
+			return true
+		}
+	}
+
+	return false
+}
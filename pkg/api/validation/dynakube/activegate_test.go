@@ -8,6 +8,8 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
 func TestDuplicateActiveGateCapabilities(t *testing.T) {
@@ -47,6 +49,82 @@ func TestInvalidActiveGateCapabilities(t *testing.T) {
 	})
 }
 
+func TestActiveGateGroups(t *testing.T) {
+	memoryLimit := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceLimitsMemory: *resource.NewMilliQuantity(1, ""),
+		},
+	}
+	newGroup := func(name string, capabilities ...activegate.CapabilityDisplayName) activegate.GroupSpec {
+		return activegate.GroupSpec{
+			Name:         name,
+			Capabilities: capabilities,
+			Resources:    memoryLimit,
+		}
+	}
+	newDynaKube := func(capabilities []activegate.CapabilityDisplayName, groups ...activegate.GroupSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				ActiveGate: activegate.Spec{
+					Capabilities: capabilities,
+					CapabilityProperties: activegate.CapabilityProperties{
+						Resources: memoryLimit,
+					},
+					Groups: groups,
+				},
+			},
+		}
+	}
+
+	t.Run(`valid groups`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube(
+			[]activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+			newGroup("routing", activegate.RoutingCapability.DisplayName),
+			newGroup("api", activegate.DynatraceAPICapability.DisplayName),
+		))
+	})
+	t.Run(`only groups`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube(nil, newGroup("routing", activegate.RoutingCapability.DisplayName)))
+	})
+	t.Run(`duplicate group names`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorDuplicateActiveGateGroupName, "routing")},
+			newDynaKube(nil,
+				newGroup("routing", activegate.RoutingCapability.DisplayName),
+				newGroup("routing", activegate.DynatraceAPICapability.DisplayName),
+			))
+	})
+	t.Run(`capability of the default ActiveGate duplicated in a group`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorDuplicateActiveGateCapability, activegate.RoutingCapability.DisplayName)},
+			newDynaKube(
+				[]activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+				newGroup("routing", activegate.RoutingCapability.DisplayName),
+			))
+	})
+	t.Run(`capability duplicated across groups`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorDuplicateActiveGateCapability, activegate.RoutingCapability.DisplayName)},
+			newDynaKube(nil,
+				newGroup("routing", activegate.RoutingCapability.DisplayName),
+				newGroup("other", activegate.RoutingCapability.DisplayName),
+			))
+	})
+	t.Run(`invalid capability in group`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorInvalidActiveGateCapability, "invalid-capability")},
+			newDynaKube(nil, newGroup("routing", "invalid-capability")))
+	})
+	t.Run(`memory warning for group without memory limit`, func(t *testing.T) {
+		group := newGroup("routing", activegate.RoutingCapability.DisplayName)
+		group.Resources = corev1.ResourceRequirements{}
+
+		assertAllowedWithWarnings(t, 1, newDynaKube(nil, group))
+	})
+}
+
 func TestMissingActiveGateMemoryLimit(t *testing.T) {
 	t.Run(`memory warning in activeGate mode`, func(t *testing.T) {
 		assertAllowedWithWarnings(t, 1,
@@ -130,3 +208,118 @@ func TestActiveGatePVCSettings(t *testing.T) {
 			})
 	})
 }
+
+func TestActiveGateAutoscaling(t *testing.T) {
+	newDynaKube := func(replicas *int32, autoscaling *activegate.AutoscalingSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.RoutingCapability.DisplayName,
+					},
+					CapabilityProperties: activegate.CapabilityProperties{
+						Replicas: replicas,
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceLimitsMemory: *resource.NewMilliQuantity(1, ""),
+							},
+						},
+					},
+					Autoscaling: autoscaling,
+				},
+			},
+		}
+	}
+
+	t.Run(`valid autoscaling`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube(nil, &activegate.AutoscalingSpec{MinReplicas: ptr.To(int32(2)), MaxReplicas: 5}))
+	})
+	t.Run(`minReplicas greater than maxReplicas`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorActiveGateInvalidAutoscaling, 6, 5)},
+			newDynaKube(nil, &activegate.AutoscalingSpec{MinReplicas: ptr.To(int32(6)), MaxReplicas: 5}))
+	})
+	t.Run(`replicas set together with autoscaling`, func(t *testing.T) {
+		assertAllowedWithWarnings(t, 1, newDynaKube(ptr.To(int32(3)), &activegate.AutoscalingSpec{MaxReplicas: 5}))
+	})
+	t.Run(`autoscaling together with groups`, func(t *testing.T) {
+		dk := newDynaKube(nil, &activegate.AutoscalingSpec{MaxReplicas: 5})
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{{Name: "metrics", Capabilities: []activegate.CapabilityDisplayName{activegate.MetricsIngestCapability.DisplayName}}}
+
+		assertDenied(t, []string{errorActiveGateAutoscalingWithGroups}, dk)
+	})
+}
+
+func TestActiveGatePodTemplateOverride(t *testing.T) {
+	newDynaKube := func(override string) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.RoutingCapability.DisplayName,
+					},
+					CapabilityProperties: activegate.CapabilityProperties{
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceLimitsMemory: *resource.NewMilliQuantity(1, ""),
+							},
+						},
+					},
+					PodTemplateOverride: &runtime.RawExtension{Raw: []byte(override)},
+				},
+			},
+		}
+	}
+
+	t.Run(`valid override`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube(`{
+			"metadata": {"labels": {"team": "platform"}, "annotations": {"example.com/owner": "platform"}},
+			"spec": {
+				"serviceAccountName": "custom-sa",
+				"securityContext": {"runAsNonRoot": true},
+				"volumes": [{"name": "extra-certs", "emptyDir": {}}],
+				"containers": [
+					{"name": "activegate", "securityContext": {"readOnlyRootFilesystem": true}, "volumeMounts": [{"name": "extra-certs", "mountPath": "/mnt/extra-certs"}]},
+					{"name": "sidecar", "image": "sidecar:latest", "env": [{"name": "KEY", "value": "value"}]}
+				]
+			}
+		}`))
+	})
+	t.Run(`unknown field`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{"invalid pod template override", "unknown field"},
+			newDynaKube(`{"spec": {"serviceAcountName": "typo"}}`))
+	})
+	t.Run(`patch directive`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{"invalid pod template override"},
+			newDynaKube(`{"spec": {"containers": [{"name": "activegate", "$patch": "delete"}]}}`))
+	})
+	t.Run(`container without name`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{"invalid pod template override", "containers need a name"},
+			newDynaKube(`{"spec": {"containers": [{"image": "sidecar:latest"}]}}`))
+	})
+	t.Run(`managed fields`, func(t *testing.T) {
+		testCases := map[string]string{
+			"metadata":                               `{"metadata": {"name": "other"}}`,
+			"metadata.labels.app.kubernetes.io/name": `{"metadata": {"labels": {"app.kubernetes.io/name": "other"}}}`,
+			"metadata.annotations.internal.operator.dynatrace.com/activegate-configuration-hash": `{"metadata": {"annotations": {"internal.operator.dynatrace.com/activegate-configuration-hash": "other"}}}`,
+			"spec.containers[activegate].image":                                                  `{"spec": {"containers": [{"name": "activegate", "image": "other"}]}}`,
+			"spec.containers[activegate].env":                                                    `{"spec": {"containers": [{"name": "activegate", "env": [{"name": "KEY"}]}]}}`,
+			"spec.containers[activegate].ports":                                                  `{"spec": {"containers": [{"name": "activegate", "ports": [{"containerPort": 80}]}]}}`,
+			"spec.volumes[ag-authtoken-secret]":                                                  `{"spec": {"volumes": [{"name": "ag-authtoken-secret", "emptyDir": {}}]}}`,
+			"spec.volumes[server-certs]":                                                         `{"spec": {"volumes": [{"name": "server-certs", "emptyDir": {}}]}}`,
+			"spec.containers[activegate].volumeMounts[/var/lib/dynatrace/secrets/tls]":           `{"spec": {"containers": [{"name": "activegate", "volumeMounts": [{"name": "other", "mountPath": "/var/lib/dynatrace/secrets/tls"}]}]}}`,
+			"spec.containers[activegate].volumeMounts[/mnt/certs]":                               `{"spec": {"containers": [{"name": "activegate", "volumeMounts": [{"name": "truststore-volume", "mountPath": "/mnt/certs"}]}]}}`,
+		}
+
+		for field, override := range testCases {
+			assertDenied(t, []string{fmt.Sprintf(errorActiveGateForbiddenPodTemplateOverride, field)}, newDynaKube(override))
+		}
+	})
+}
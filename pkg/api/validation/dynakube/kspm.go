@@ -19,7 +19,15 @@ const (
 )
 
 func tooManyAGReplicas(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
-	if dk.KSPM().IsEnabled() && dk.ActiveGate().GetReplicas() > 1 {
+	if !dk.KSPM().IsEnabled() {
+		return ""
+	}
+
+	if dk.ActiveGate().IsAutoscalingEnabled() && dk.Spec.ActiveGate.Autoscaling.MaxReplicas > 1 {
+		return errorTooManyAGReplicas
+	}
+
+	if dk.ActiveGate().GetReplicas() > 1 {
 		return errorTooManyAGReplicas
 	}
 
@@ -66,6 +66,31 @@ func TestTooManyAGReplicas(t *testing.T) {
 				},
 			})
 	})
+	t.Run("activegate autoscaling to more than 1 replica and kspm enabled", func(t *testing.T) {
+		assertDenied(t,
+			[]string{errorTooManyAGReplicas},
+			&dynakube.DynaKube{
+				ObjectMeta: defaultDynakubeObjectMeta,
+				Spec: dynakube.DynaKubeSpec{
+					APIURL: testAPIURL,
+					Kspm:   &kspm.Spec{},
+					ActiveGate: activegate.Spec{
+						Capabilities: []activegate.CapabilityDisplayName{
+							activegate.KubeMonCapability.DisplayName,
+						},
+						Autoscaling: &activegate.AutoscalingSpec{MaxReplicas: 3},
+					},
+					Templates: dynakube.TemplatesSpec{
+						KspmNodeConfigurationCollector: kspm.NodeConfigurationCollectorSpec{
+							ImageRef: image.Ref{
+								Repository: "repo/image",
+								Tag:        "version",
+							},
+						},
+					},
+				},
+			})
+	})
 }
 
 func TestMissingKSPMDependency(t *testing.T) {
@@ -0,0 +1,25 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+)
+
+const (
+	errorInvalidMaintenanceWindow = `The DynaKube's specification has an invalid maintenance window at index %d: %s.
+	Either set a cron schedule and a duration, or a start and end time (HH:MM) optionally limited to some days of the week.`
+)
+
+func invalidMaintenanceWindows(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	for i, window := range dk.Spec.MaintenanceWindows {
+		if err := window.Validate(); err != nil {
+			log.Info("requested dynakube has an invalid maintenance window", "index", i, "err", err.Error())
+
+			return fmt.Sprintf(errorInvalidMaintenanceWindow, i, err.Error())
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInvalidMaintenanceWindows(t *testing.T) {
+	t.Run(`valid maintenance windows`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t,
+			&dynakube.DynaKube{
+				ObjectMeta: defaultDynakubeObjectMeta,
+				Spec: dynakube.DynaKubeSpec{
+					APIURL: testAPIURL,
+					MaintenanceWindows: []dynakube.MaintenanceWindow{
+						{Schedule: "0 22 * * 1-5", Duration: &metav1.Duration{Duration: 4 * time.Hour}, TimeZone: "Europe/Vienna"},
+						{Start: "22:00", End: "06:00", Days: []string{"Saturday", "Sunday"}},
+					},
+				},
+			})
+	})
+	t.Run(`invalid maintenance window`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{"invalid maintenance window at index 1"},
+			&dynakube.DynaKube{
+				ObjectMeta: defaultDynakubeObjectMeta,
+				Spec: dynakube.DynaKubeSpec{
+					APIURL: testAPIURL,
+					MaintenanceWindows: []dynakube.MaintenanceWindow{
+						{Start: "22:00", End: "06:00"},
+						{Schedule: "0 22 * * 1-5"},
+					},
+				},
+			})
+	})
+}
@@ -0,0 +1,21 @@
+package validation
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+)
+
+const (
+	warningInjectedNamespacesEgressIsolated = `The DynaKube's specification enables NetworkPolicies together with application monitoring. The NetworkPolicy created in the injected namespaces isolates the egress traffic of the injected pods to the DNS, the ActiveGate and the Dynatrace environment. Other destinations of the injected pods have to be allowed by additional NetworkPolicies.`
+)
+
+func injectedNamespacesEgressIsolated(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.NetworkPoliciesEnabled() && dk.OneAgent().IsAppInjectionNeeded() {
+		log.Info("requested dynakube isolates the egress traffic of the injected namespaces", "name", dk.Name, "namespace", dk.Namespace)
+
+		return warningInjectedNamespacesEgressIsolated
+	}
+
+	return ""
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+)
+
+func TestInjectedNamespacesEgressIsolated(t *testing.T) {
+	newDynaKube := func(networkPoliciesEnabled bool) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:          testAPIURL,
+				NetworkPolicies: &dynakube.NetworkPoliciesSpec{Enabled: networkPoliciesEnabled},
+				OneAgent: oneagent.Spec{
+					ApplicationMonitoring: &oneagent.ApplicationMonitoringSpec{},
+				},
+			},
+		}
+	}
+
+	t.Run(`network policies disabled => no warning`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube(false))
+	})
+	t.Run(`network policies enabled with application monitoring => warning`, func(t *testing.T) {
+		assertAllowedWithWarnings(t, 1, newDynaKube(true))
+	})
+	t.Run(`network policies enabled without application monitoring => no warning`, func(t *testing.T) {
+		dk := newDynaKube(true)
+		dk.Spec.OneAgent.ApplicationMonitoring = nil
+
+		assertAllowedWithoutWarnings(t, dk)
+	})
+}
@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+)
+
+const (
+	errorConflictingPodDisruptionBudget = `The DynaKube's specification sets both minAvailable and maxUnavailable in the podDisruptionBudget of the %s. Only one of them can be set.`
+)
+
+func conflictingPodDisruptionBudget(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	var component string
+
+	switch {
+	case dk.Spec.ActiveGate.PodDisruptionBudget.IsConflicting():
+		component = "ActiveGate"
+	case dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget.IsConflicting():
+		component = "ExtensionExecutionController"
+	case dk.Spec.Templates.OpenTelemetryCollector.PodDisruptionBudget.IsConflicting():
+		component = "OpenTelemetryCollector"
+	default:
+		return ""
+	}
+
+	log.Info("requested dynakube has conflicting podDisruptionBudget settings", "name", dk.Name, "namespace", dk.Namespace, "component", component)
+
+	return fmt.Sprintf(errorConflictingPodDisruptionBudget, component)
+}
@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestConflictingPodDisruptionBudget(t *testing.T) {
+	minAvailable := intstr.FromInt32(1)
+	maxUnavailable := intstr.FromString("50%")
+
+	newDynaKube := func(activeGateBudget, otelCollectorBudget *pdb.Spec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.RoutingCapability.DisplayName,
+					},
+					CapabilityProperties: activegate.CapabilityProperties{
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceLimitsMemory: *resource.NewMilliQuantity(1, ""),
+							},
+						},
+					},
+					PodDisruptionBudget: activeGateBudget,
+				},
+				Templates: dynakube.TemplatesSpec{
+					OpenTelemetryCollector: dynakube.OpenTelemetryCollectorSpec{
+						PodDisruptionBudget: otelCollectorBudget,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run(`valid podDisruptionBudget`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube(&pdb.Spec{MinAvailable: &minAvailable}, &pdb.Spec{MaxUnavailable: &maxUnavailable}))
+	})
+	t.Run(`conflicting ActiveGate podDisruptionBudget`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorConflictingPodDisruptionBudget, "ActiveGate")},
+			newDynaKube(&pdb.Spec{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable}, nil))
+	})
+	t.Run(`conflicting OpenTelemetryCollector podDisruptionBudget`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorConflictingPodDisruptionBudget, "OpenTelemetryCollector")},
+			newDynaKube(nil, &pdb.Spec{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable}))
+	})
+}
@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+)
+
+const (
+	errorInvalidUpdatePolicyConstraint = `The DynaKube's specification has an invalid version constraint in the update policy of %s: %s.
+	Use conditions like "~1.311", "<1.320" or ">=1.300, <1.320".`
+)
+
+func invalidUpdatePolicyConstraint(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	policies := []struct {
+		policy    *update.Policy
+		component string
+	}{
+		{dk.OneAgent().GetUpdatePolicy(), "oneAgent"},
+		{dk.Spec.ActiveGate.UpdatePolicy, "activeGate"},
+	}
+
+	for _, entry := range policies {
+		if entry.policy == nil || entry.policy.Constraint == "" {
+			continue
+		}
+
+		if _, err := version.ParseConstraint(entry.policy.Constraint); err != nil {
+			log.Info("requested dynakube has an invalid update policy constraint", "component", entry.component, "err", err.Error())
+
+			return fmt.Sprintf(errorInvalidUpdatePolicyConstraint, entry.component, err.Error())
+		}
+	}
+
+	return ""
+}
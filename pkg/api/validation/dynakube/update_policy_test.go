@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
+)
+
+func TestInvalidUpdatePolicyConstraint(t *testing.T) {
+	t.Run(`valid update policy`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t,
+			&dynakube.DynaKube{
+				ObjectMeta: defaultDynakubeObjectMeta,
+				Spec: dynakube.DynaKubeSpec{
+					APIURL: testAPIURL,
+					OneAgent: oneagent.Spec{
+						HostMonitoring: &oneagent.HostInjectSpec{
+							UpdatePolicy: &update.Policy{Constraint: ">=1.300, <1.320", Mode: update.MinorOnlyMode, MinReleaseAgeDays: 7},
+						},
+					},
+				},
+			})
+	})
+	t.Run(`invalid OneAgent update policy constraint`, func(t *testing.T) {
+		assertDenied(t,
+			[]string{"invalid version constraint in the update policy of oneAgent"},
+			&dynakube.DynaKube{
+				ObjectMeta: defaultDynakubeObjectMeta,
+				Spec: dynakube.DynaKubeSpec{
+					APIURL: testAPIURL,
+					OneAgent: oneagent.Spec{
+						HostMonitoring: &oneagent.HostInjectSpec{
+							UpdatePolicy: &update.Policy{Constraint: "latest"},
+						},
+					},
+				},
+			})
+	})
+}
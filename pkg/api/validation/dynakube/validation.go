@@ -39,7 +39,11 @@ var (
 		disabledCSIForReadonlyCSIVolume,
 		invalidActiveGateCapabilities,
 		duplicateActiveGateCapabilities,
+		duplicateActiveGateGroupNames,
 		mutuallyExclusiveActiveGatePVsettings,
+		invalidActiveGateAutoscaling,
+		invalidActiveGatePodTemplateOverride,
+		conflictingPodDisruptionBudget,
 		invalidActiveGateProxyURL,
 		conflictingOneAgentConfiguration,
 		conflictingOneAgentNodeSelector,
@@ -65,9 +69,12 @@ var (
 		invalidTelemetryIngestName,
 		forbiddenTelemetryIngestServiceNameSuffix,
 		conflictingTelemetryIngestServiceNames,
+		invalidMaintenanceWindows,
+		invalidUpdatePolicyConstraint,
 	}
 	validatorWarningFuncs = []validatorFunc{
 		missingActiveGateMemoryLimit,
+		ignoredActiveGateReplicas,
 		unsupportedOneAgentImage,
 		conflictingHostGroupSettings,
 		deprecatedFeatureFlag,
@@ -77,6 +84,7 @@ var (
 		kspmWithoutK8SMonitoring,
 		noMappedHostPaths,
 		extensionsWithoutK8SMonitoring,
+		injectedNamespacesEgressIsolated,
 	}
 	updateValidatorErrorFuncs = []updateValidatorFunc{
 		IsMutatedAPIURL,
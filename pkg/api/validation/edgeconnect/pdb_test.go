@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestConflictingPodDisruptionBudget(t *testing.T) {
+	minAvailable := intstr.FromInt32(1)
+	maxUnavailable := intstr.FromString("50%")
+
+	t.Run("accept edgeconnect without podDisruptionBudget", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{}
+		require.Empty(t, conflictingPodDisruptionBudget(context.Background(), nil, ec))
+	})
+	t.Run("accept podDisruptionBudget with maxUnavailable", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				PodDisruptionBudget: &pdb.Spec{MaxUnavailable: &maxUnavailable},
+			},
+		}
+		require.Empty(t, conflictingPodDisruptionBudget(context.Background(), nil, ec))
+	})
+	t.Run("reject podDisruptionBudget with minAvailable and maxUnavailable", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				PodDisruptionBudget: &pdb.Spec{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable},
+			},
+		}
+		require.Equal(t, errorConflictingPodDisruptionBudget, conflictingPodDisruptionBudget(context.Background(), nil, ec))
+	})
+}
@@ -29,6 +29,7 @@ var validatorErrorFuncs = []validatorFunc{
 	checkHostPatternsValue,
 	isInvalidServiceName,
 	automationRequiresProvisionerValidation,
+	conflictingPodDisruptionBudget,
 }
 
 func New(apiReader client.Reader, cfg *rest.Config) admission.CustomValidator {
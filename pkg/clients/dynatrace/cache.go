@@ -0,0 +1,240 @@
+package dynatrace
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultCacheTTL = 5 * time.Minute
+
+	// maxCacheEntries bounds the memory used by a ResponseCache, the cache is emptied when it is reached.
+	maxCacheEntries = 1000
+
+	etagHeader            = "ETag"
+	lastModifiedHeader    = "Last-Modified"
+	ifNoneMatchHeader     = "If-None-Match"
+	ifModifiedSinceHeader = "If-Modified-Since"
+)
+
+// Cacheable endpoints, matched against the end of the request path, as the path of the API URL differs between SaaS and Managed.
+const (
+	ProcessModuleConfigEndpoint    = "/v1/deployment/installer/agent/processmoduleconfig"
+	OneAgentConnectionInfoEndpoint = "/v1/deployment/installer/agent/connectioninfo"
+	LatestOneAgentImageEndpoint    = "/v1/deployment/image/agent/oneAgent/latest"
+	LatestCodeModulesImageEndpoint = "/v1/deployment/image/agent/codeModules/latest"
+	LatestActiveGateImageEndpoint  = "/v1/deployment/image/gateway/latest"
+	EffectiveSettingsEndpoint      = "/v2/settings/effectiveValues"
+)
+
+// DefaultCacheTTLs returns the endpoints cached by default, mapped to how long their responses are used without asking the server.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		ProcessModuleConfigEndpoint:    defaultCacheTTL,
+		OneAgentConnectionInfoEndpoint: defaultCacheTTL,
+		LatestOneAgentImageEndpoint:    defaultCacheTTL,
+		LatestCodeModulesImageEndpoint: defaultCacheTTL,
+		LatestActiveGateImageEndpoint:  defaultCacheTTL,
+		EffectiveSettingsEndpoint:      defaultCacheTTL,
+	}
+}
+
+// CacheStats holds the number of requests answered by a ResponseCache.
+type CacheStats struct {
+	// Hits counts responses served from the cache, either because they were fresh or because the server confirmed them with 304.
+	Hits uint64
+	// Misses counts requests to cacheable endpoints that had to be fully downloaded.
+	Misses uint64
+}
+
+type cacheEntry struct {
+	storedAt time.Time
+	header   http.Header
+	body     []byte
+}
+
+func (entry cacheEntry) hasValidators() bool {
+	return entry.header.Get(etagHeader) != "" || entry.header.Get(lastModifiedHeader) != ""
+}
+
+// ResponseCache keeps successful GET responses of selected endpoints in memory.
+// Within the TTL of an endpoint the cached response is used without contacting the server,
+// afterwards it is revalidated with If-None-Match/If-Modified-Since if the server sent an ETag or Last-Modified header.
+// A ResponseCache is safe for concurrent use and may be shared between clients.
+type ResponseCache struct {
+	entries map[string]cacheEntry
+	ttls    map[string]time.Duration
+
+	// Set for testing purposes, leave nil to use the current time.
+	now func() time.Time
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+
+	mutex sync.Mutex
+}
+
+// NewResponseCache creates a ResponseCache for the given endpoints and their TTLs, see DefaultCacheTTLs.
+func NewResponseCache(ttls map[string]time.Duration) *ResponseCache {
+	return &ResponseCache{
+		entries: map[string]cacheEntry{},
+		ttls:    ttls,
+	}
+}
+
+// Cache creates an Option that serves the responses of cacheable endpoints from the given cache.
+func Cache(cache *ResponseCache) Option {
+	return func(c *dynatraceClient) {
+		c.cache = cache
+	}
+}
+
+// Stats returns the hit and miss counts of the cache.
+func (cache *ResponseCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   cache.hits.Load(),
+		Misses: cache.misses.Load(),
+	}
+}
+
+func (cache *ResponseCache) currentTime() time.Time {
+	if cache.now != nil {
+		return cache.now()
+	}
+
+	return time.Now()
+}
+
+func (cache *ResponseCache) ttl(req *http.Request) (time.Duration, bool) {
+	if req.Method != http.MethodGet {
+		return 0, false
+	}
+
+	for endpoint, ttl := range cache.ttls {
+		if strings.HasSuffix(req.URL.Path, endpoint) {
+			return ttl, true
+		}
+	}
+
+	return 0, false
+}
+
+// cacheKey identifies a response by its URL and credentials, as different tokens may see different data.
+func cacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+
+	return req.URL.String() + "#" + hex.EncodeToString(auth[:])
+}
+
+func (cache *ResponseCache) get(key string) (cacheEntry, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.entries[key]
+
+	return entry, ok
+}
+
+func (cache *ResponseCache) store(key string, entry cacheEntry) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if _, ok := cache.entries[key]; !ok && len(cache.entries) >= maxCacheEntries {
+		cache.entries = map[string]cacheEntry{}
+	}
+
+	cache.entries[key] = entry
+}
+
+// do answers the request from the cache if possible, otherwise it is sent using next.
+func (cache *ResponseCache) do(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	ttl, ok := cache.ttl(req)
+	if !ok {
+		return next(req)
+	}
+
+	key := cacheKey(req)
+	now := cache.currentTime()
+
+	entry, cached := cache.get(key)
+	if cached && now.Sub(entry.storedAt) < ttl {
+		cache.hits.Add(1)
+
+		return entry.response(req), nil
+	}
+
+	revalidate := cached && entry.hasValidators()
+	if revalidate {
+		req = req.Clone(req.Context())
+
+		if etag := entry.header.Get(etagHeader); etag != "" {
+			req.Header.Set(ifNoneMatchHeader, etag)
+		}
+
+		if lastModified := entry.header.Get(lastModifiedHeader); lastModified != "" {
+			req.Header.Set(ifModifiedSinceHeader, lastModified)
+		}
+	}
+
+	resp, err := next(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if revalidate && resp.StatusCode == http.StatusNotModified {
+		utils.DiscardBody(resp)
+
+		entry.storedAt = now
+		cache.store(key, entry)
+		cache.hits.Add(1)
+
+		return entry.response(req), nil
+	}
+
+	cache.misses.Add(1)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	utils.DiscardBody(resp)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "error reading response")
+	}
+
+	cache.store(key, cacheEntry{
+		storedAt: now,
+		header:   resp.Header.Clone(),
+		body:     body,
+	})
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+func (entry cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}
+}
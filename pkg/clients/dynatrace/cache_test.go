@@ -0,0 +1,124 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testETag = `"v1"`
+
+func etagHandler(calls *atomic.Int32, conditionalCalls *atomic.Int32) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		calls.Add(1)
+
+		if request.Header.Get(ifNoneMatchHeader) == testETag {
+			conditionalCalls.Add(1)
+			writer.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		writer.Header().Set(etagHeader, testETag)
+		_, _ = writer.Write([]byte(`{"tenantUUID": "abc", "tenantToken": "token", "communicationEndpoints": ["https://abc.dev.dynatracelabs.com/communication"]}`))
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, calls, conditionalCalls *atomic.Int32) (*ResponseCache, *time.Time, *dynatraceClient) {
+		server := httptest.NewServer(etagHandler(calls, conditionalCalls))
+		t.Cleanup(server.Close)
+
+		now := time.Now()
+		cache := NewResponseCache(DefaultCacheTTLs())
+		cache.now = func() time.Time { return now }
+
+		dtc := &dynatraceClient{url: server.URL, paasToken: paasToken, httpClient: server.Client()}
+		Cache(cache)(dtc)
+
+		return cache, &now, dtc
+	}
+
+	t.Run("fresh responses are served from cache", func(t *testing.T) {
+		calls, conditionalCalls := atomic.Int32{}, atomic.Int32{}
+		cache, _, dtc := setup(t, &calls, &conditionalCalls)
+
+		first, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+
+		second, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, "abc", second.TenantUUID)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, cache.Stats())
+	})
+	t.Run("stale responses are revalidated with etag", func(t *testing.T) {
+		calls, conditionalCalls := atomic.Int32{}, atomic.Int32{}
+		cache, now, dtc := setup(t, &calls, &conditionalCalls)
+
+		_, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+
+		*now = now.Add(2 * defaultCacheTTL)
+
+		connectionInfo, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "abc", connectionInfo.TenantUUID)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, int32(1), conditionalCalls.Load())
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, cache.Stats())
+	})
+	t.Run("different tokens do not share entries", func(t *testing.T) {
+		calls, conditionalCalls := atomic.Int32{}, atomic.Int32{}
+		cache, _, dtc := setup(t, &calls, &conditionalCalls)
+
+		_, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+
+		dtc.paasToken = "other"
+
+		_, err = dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, CacheStats{Misses: 2}, cache.Stats())
+	})
+	t.Run("other endpoints are not cached", func(t *testing.T) {
+		calls, conditionalCalls := atomic.Int32{}, atomic.Int32{}
+		cache, _, dtc := setup(t, &calls, &conditionalCalls)
+
+		_, _ = dtc.GetActiveGateConnectionInfo(ctx)
+		_, _ = dtc.GetActiveGateConnectionInfo(ctx)
+
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, CacheStats{}, cache.Stats())
+	})
+	t.Run("error responses are not cached", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(1, http.StatusNotFound, &calls, nil))
+		defer server.Close()
+
+		cache := NewResponseCache(DefaultCacheTTLs())
+		dtc := &dynatraceClient{url: server.URL, paasToken: paasToken, httpClient: server.Client()}
+		Cache(cache)(dtc)
+
+		_, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.Error(t, err)
+
+		_, _ = dtc.GetOneAgentConnectionInfo(ctx)
+
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, CacheStats{Misses: 2}, cache.Stats())
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/logmonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
 	"github.com/pkg/errors"
 	"golang.org/x/net/http/httpproxy"
 )
@@ -56,6 +57,10 @@ type Client interface {
 	// GetTokenScopes returns the list of scopes assigned to a token if successful.
 	GetTokenScopes(ctx context.Context, token string) (TokenScopes, error)
 
+	// GetOAuthScopes returns the list of scopes granted to the OAuth client the client authenticates with,
+	// using the names of the corresponding token scopes.
+	GetOAuthScopes(ctx context.Context) (TokenScopes, error)
+
 	// GetActiveGateConnectionInfo returns AgentTenantInfo for ActiveGate that holds UUID, Tenant Token and Endpoints
 	GetActiveGateConnectionInfo(ctx context.Context) (ActiveGateConnectionInfo, error)
 
@@ -124,7 +129,7 @@ type NewFunc func(url, apiToken, paasToken string, opts ...Option) (Client, erro
 var _ NewFunc = NewClient
 
 // NewClient creates a REST client for the given API base URL and authentication tokens.
-// Returns an error if a token or the URL is empty.
+// Returns an error if the URL is empty, or if both tokens are empty and no OAuth credentials are given via the OAuth option.
 //
 // The API base URL is different for managed and SaaS environments:
 //   - SaaS: https://{environment-id}.live.dynatrace.com/api
@@ -136,10 +141,6 @@ func NewClient(url, apiToken, paasToken string, opts ...Option) (Client, error)
 		return nil, errors.New("url is empty")
 	}
 
-	if len(apiToken) == 0 && len(paasToken) == 0 {
-		return nil, errors.New("tokens are empty")
-	}
-
 	url = strings.TrimSuffix(url, "/")
 
 	dc := &dynatraceClient{
@@ -157,6 +158,23 @@ func NewClient(url, apiToken, paasToken string, opts ...Option) (Client, error)
 		opt(dc)
 	}
 
+	if dc.transport != nil {
+		if dc.transportConfigured {
+			return nil, errors.New("custom transport can't be combined with proxy, certificate or certificate validation settings")
+		}
+
+		dc.httpClient.Transport = dc.transport
+	}
+
+	// wrapped after all options were applied, as they configure the underlying *http.Transport
+	if dc.instrumentationName != "" {
+		dc.httpClient.Transport = utils.NewInstrumentedTransport(dc.httpClient.Transport, instrumentationClient, dc.instrumentationNamespace, dc.instrumentationName, "", endpointResolver)
+	}
+
+	if len(apiToken) == 0 && len(paasToken) == 0 && dc.tokenSource == nil {
+		return nil, errors.New("tokens are empty")
+	}
+
 	return dc, nil
 }
 
@@ -174,6 +192,7 @@ func SkipCertificateValidation(skip bool) Option {
 			}
 
 			t.TLSClientConfig.InsecureSkipVerify = true
+			c.transportConfigured = true
 		}
 	}
 }
@@ -198,6 +217,7 @@ func Proxy(proxyURL string, noProxy string) Option {
 			NoProxy:    noProxy,
 		}
 		transport.Proxy = proxyWrapper(proxyConfig)
+		dtclient.transportConfigured = true
 	}
 }
 
@@ -226,6 +246,7 @@ func Certs(certs []byte) Option {
 		}
 
 		t.TLSClientConfig.RootCAs = rootCAs
+		c.transportConfigured = true
 	}
 }
 
@@ -240,3 +261,11 @@ func HostGroup(hostGroup string) Option {
 		c.hostGroup = hostGroup
 	}
 }
+
+// Transport creates an Option that replaces the transport of the client, e.g. to answer requests without network access.
+// NewClient returns an error if it is combined with SkipCertificateValidation, Proxy or Certs, as they configure the default *http.Transport.
+func Transport(transport http.RoundTripper) Option {
+	return func(c *dynatraceClient) {
+		c.transport = transport
+	}
+}
@@ -105,6 +105,33 @@ func TestCerts(t *testing.T) {
 	assert.NotNil(t, transport.TLSClientConfig.RootCAs)
 }
 
+func TestTransport(t *testing.T) {
+	transport := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, nil //nolint:nilnil
+	})
+
+	dtc, err := NewClient("https://aabb.live.dynatrace.com/api", "foo", "bar", Transport(transport))
+	require.NoError(t, err)
+
+	assert.NotNil(t, dtc.(*dynatraceClient).httpClient.Transport.(roundTripperFunc))
+
+	t.Run("can't be combined with options configuring the default transport", func(t *testing.T) {
+		for _, opt := range []Option{SkipCertificateValidation(true), Proxy("http://proxy.example.com", ""), Certs(nil)} {
+			_, err := NewClient("https://aabb.live.dynatrace.com/api", "foo", "bar", opt, Transport(transport))
+			require.Error(t, err)
+
+			_, err = NewClient("https://aabb.live.dynatrace.com/api", "foo", "bar", Transport(transport), opt)
+			require.Error(t, err)
+		}
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
 func checkProxyForURL(t *testing.T, transport http.Transport, proxyRawURL, targetRawURL string, noProxy bool) {
 	targetURL, err := url.Parse(targetRawURL)
 	require.NoError(t, err)
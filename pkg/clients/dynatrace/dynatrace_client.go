@@ -15,6 +15,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 )
 
 const APITokenHeader = "Api-Token "
@@ -34,6 +35,25 @@ type dynatraceClient struct {
 	networkZone string
 
 	hostGroup string
+
+	retryPolicy RetryPolicy
+
+	limiter RequestLimiter
+
+	tokenSource oauth2.TokenSource
+	oauthScopes []string
+
+	cache *ResponseCache
+
+	// transport replaces the default *http.Transport after all options were applied, see Transport.
+	transport http.RoundTripper
+	// transportConfigured is set if an option changed the default *http.Transport, which would be lost by replacing it.
+	transportConfigured bool
+
+	// instrumentationName is the DynaKube name used to label the request metrics, the metrics are only recorded if set.
+	instrumentationName string
+	// instrumentationNamespace is the DynaKube namespace used to label the request metrics.
+	instrumentationNamespace string
 }
 
 type tokenType int
@@ -49,45 +69,69 @@ const (
 // The response body must be closed by the caller when no longer used.
 func (dtc *dynatraceClient) makeRequest(ctx context.Context, url string, tokenType tokenType) (*http.Response, error) {
 	// TODO: introduce ctx into dynatrace client
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(dtc.withTokenType(ctx, tokenType), http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error initializing http request")
 	}
 
-	var authHeader string
+	var apiToken string
 
 	switch tokenType {
 	case dynatraceAPIToken:
-		if dtc.apiToken == "" {
+		if dtc.apiToken == "" && dtc.tokenSource == nil {
 			return nil, errors.Errorf("not able to set token since api token is empty for request: %s", url)
 		}
 
-		authHeader = APITokenHeader + dtc.apiToken
+		apiToken = dtc.apiToken
 	case dynatracePaaSToken:
-		if dtc.paasToken == "" {
+		if dtc.paasToken == "" && dtc.tokenSource == nil {
 			return nil, errors.Errorf("not able to set token since paas token is empty for request: %s", url)
 		}
 
-		authHeader = APITokenHeader + dtc.paasToken
+		apiToken = dtc.paasToken
 	case installerURLToken:
-		return dtc.httpClient.Do(req)
+		return dtc.do(req)
 	default:
 		return nil, errors.Errorf("unknown token type (%d), unable to determine token to set in headers", tokenType)
 	}
 
-	req.Header.Add("Authorization", authHeader)
+	if err := dtc.setAuthorization(req, apiToken); err != nil {
+		return nil, err
+	}
+
+	return dtc.do(req)
+}
+
+// do sends the request to the Dynatrace API, answering it from the cache if possible.
+// The response body must be closed by the caller when no longer used.
+func (dtc *dynatraceClient) do(req *http.Request) (*http.Response, error) {
+	if dtc.cache != nil {
+		return dtc.cache.do(req, dtc.doWithRetry)
+	}
+
+	return dtc.doWithRetry(req)
+}
 
-	return dtc.httpClient.Do(req)
+// withTokenType labels requests authorized with the PaaS token for the request metrics, as the Authorization header looks the same for both API and PaaS tokens.
+func (dtc *dynatraceClient) withTokenType(ctx context.Context, tokenType tokenType) context.Context {
+	if tokenType == dynatracePaaSToken && dtc.tokenSource == nil {
+		return utils.WithTokenType(ctx, utils.TokenTypePaaS)
+	}
+
+	return ctx
 }
 
-func createBaseRequest(ctx context.Context, url, method, apiToken string, body io.Reader) (*http.Request, error) {
+func (dtc *dynatraceClient) createBaseRequest(ctx context.Context, url, method string, body io.Reader) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error initializing http request")
 	}
 
 	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Authorization", APITokenHeader+apiToken)
+
+	if err := dtc.setAuthorization(req, dtc.apiToken); err != nil {
+		return nil, err
+	}
 
 	if method == http.MethodPost {
 		req.Header.Add("Content-Type", "application/json")
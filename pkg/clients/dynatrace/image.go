@@ -58,7 +58,7 @@ func (dtc *dynatraceClient) processLatestImageRequest(ctx context.Context, url s
 		return nil, errors.WithStack(err)
 	}
 
-	response, err := dtc.httpClient.Do(request)
+	response, err := dtc.do(request)
 	if err != nil {
 		log.Info("failed to retrieve latest image")
 
@@ -106,11 +106,10 @@ func (dtc *dynatraceClient) createLatestImageRequest(ctx context.Context, url st
 		return nil, err
 	}
 
-	request, err := createBaseRequest(
+	request, err := dtc.createBaseRequest(
 		ctx,
 		url,
 		http.MethodGet,
-		dtc.apiToken,
 		bytes.NewReader(bodyData),
 	)
 
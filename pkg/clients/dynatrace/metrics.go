@@ -0,0 +1,34 @@
+package dynatrace
+
+import "github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+
+const instrumentationClient = "dynatrace"
+
+// endpointResolver maps requests to the endpoints of endpoints.go, which are used to label the request metrics.
+var endpointResolver = utils.NewEndpointResolver(
+	OneAgentConnectionInfoEndpoint,
+	ProcessModuleConfigEndpoint,
+	"/v1/deployment/installer/agent/versions/{os}/{installerType}",
+	"/v1/deployment/installer/agent/{os}/{installerType}/latest/metainfo",
+	"/v1/deployment/installer/agent/{os}/{installerType}/latest",
+	"/v1/deployment/installer/agent/{os}/{installerType}/version/{version}",
+	"/v1/deployment/installer/gateway/connectioninfo",
+	"/v1/deployment/installer/gateway/{os}/latest/metainfo",
+	LatestOneAgentImageEndpoint,
+	LatestCodeModulesImageEndpoint,
+	LatestActiveGateImageEndpoint,
+	"/v2/entities",
+	"/v2/settings/objects",
+	EffectiveSettingsEndpoint,
+	"/v2/apiTokens/lookup",
+	"/v2/activeGateTokens",
+)
+
+// Instrument creates an Option that records the count and duration of all requests sent by the client, labeled with the given DynaKube namespace and name.
+// The metrics are served on the metrics endpoint of the operator.
+func Instrument(dynakubeNamespace, dynakubeName string) Option {
+	return func(c *dynatraceClient) {
+		c.instrumentationNamespace = dynakubeNamespace
+		c.instrumentationName = dynakubeName
+	}
+}
@@ -0,0 +1,67 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestEndpointResolver(t *testing.T) {
+	dtc := &dynatraceClient{url: "https://tenant.example.com/e/abc/api"}
+
+	urls := map[string]string{
+		dtc.getAgentURL(OsUnix, InstallerTypePaaS, "default", "x86", "1.2.3", nil, false): "/v1/deployment/installer/agent/{os}/{installerType}/version/{version}",
+		dtc.getLatestAgentURL(OsUnix, InstallerTypePaaS, "default", "x86", nil, false):    "/v1/deployment/installer/agent/{os}/{installerType}/latest",
+		dtc.getLatestAgentVersionURL(OsUnix, InstallerTypePaaS, "default", "x86"):         "/v1/deployment/installer/agent/{os}/{installerType}/latest/metainfo",
+		dtc.getAgentVersionsURL(OsUnix, InstallerTypePaaS, "default", "x86"):              "/v1/deployment/installer/agent/versions/{os}/{installerType}",
+		dtc.getLatestActiveGateVersionURL(OsUnix):                                         "/v1/deployment/installer/gateway/{os}/latest/metainfo",
+		dtc.getOneAgentConnectionInfoURL():                                                OneAgentConnectionInfoEndpoint,
+		dtc.getActiveGateConnectionInfoURL():                                              "/v1/deployment/installer/gateway/connectioninfo",
+		dtc.getProcessModuleConfigURL():                                                   ProcessModuleConfigEndpoint,
+		dtc.getEntitiesURL():                                                              "/v2/entities",
+		dtc.getSettingsURL(false):                                                         "/v2/settings/objects",
+		dtc.getEffectiveSettingsURL(true):                                                 EffectiveSettingsEndpoint,
+		dtc.getTokensLookupURL():                                                          "/v2/apiTokens/lookup",
+		dtc.getActiveGateAuthTokenURL():                                                   "/v2/activeGateTokens",
+		dtc.getLatestOneAgentImageURL():                                                   LatestOneAgentImageEndpoint,
+		dtc.getLatestCodeModulesImageURL():                                                LatestCodeModulesImageEndpoint,
+		dtc.getLatestActiveGateImageURL():                                                 LatestActiveGateImageEndpoint,
+		"https://installer.example.com/download/agent.zip":                                utils.OtherEndpoint,
+	}
+
+	for rawURL, endpoint := range urls {
+		parsedURL, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		assert.Equal(t, endpoint, endpointResolver(parsedURL.Path), rawURL)
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	t.Run("wraps transport after other options", func(t *testing.T) {
+		dtc, err := NewClient("https://tenant.example.com/api", "api", "paas", Instrument("dynatrace", "dynakube"), SkipCertificateValidation(true))
+		require.NoError(t, err)
+
+		transport := dtc.(*dynatraceClient).httpClient.Transport
+		assert.IsType(t, &utils.InstrumentedTransport{}, transport)
+	})
+	t.Run("not instrumented without name", func(t *testing.T) {
+		dtc, err := NewClient("https://tenant.example.com/api", "api", "paas")
+		require.NoError(t, err)
+
+		assert.IsType(t, &http.Transport{}, dtc.(*dynatraceClient).httpClient.Transport)
+	})
+	t.Run("paas token requests are labeled", func(t *testing.T) {
+		dtc := &dynatraceClient{}
+		ctx := dtc.withTokenType(context.Background(), dynatracePaaSToken)
+		assert.Equal(t, utils.WithTokenType(context.Background(), utils.TokenTypePaaS), ctx)
+
+		dtc.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+		assert.Equal(t, context.Background(), dtc.withTokenType(context.Background(), dynatracePaaSToken))
+	})
+}
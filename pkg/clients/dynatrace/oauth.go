@@ -0,0 +1,180 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Keys of the OAuth client credentials in the tokens secret, used instead of the apiToken/paasToken.
+const (
+	OAuthClientID      = "oauthClientId"
+	OAuthClientSecret  = "oauthClientSecret"
+	OAuthTokenEndpoint = "oauthTokenEndpoint"
+	OAuthScopes        = "oauthScopes"
+	OAuthResource      = "oauthResource"
+
+	DefaultOAuthTokenEndpoint = "https://sso.dynatrace.com/sso/oauth2/token"
+
+	bearerTokenHeader = "Bearer "
+	oauthScopeField   = "scope"
+	oauthResourceKey  = "resource"
+)
+
+var (
+	// oauthTokenSources are shared by all clients of the process, so a token is only fetched again shortly before it expires,
+	// instead of once for every client built during the reconciles.
+	oauthTokenSources     = map[string]oauth2.TokenSource{}
+	oauthTokenSourcesLock sync.Mutex
+)
+
+// oauthScopesByTokenScope maps the scopes of classic API tokens to the OAuth scopes granting the same permissions.
+var oauthScopesByTokenScope = map[string]string{
+	TokenScopeInstallerDownload:     "environment-api:deployment:download",
+	TokenScopeMetricsIngest:         "environment-api:metrics:write",
+	TokenScopeEntitiesRead:          "environment-api:entities:read",
+	TokenScopeSettingsRead:          "settings:objects:read",
+	TokenScopeSettingsWrite:         "settings:objects:write",
+	TokenScopeActiveGateTokenCreate: "environment-api:activegate-tokens:create",
+}
+
+// OAuthScopesFor returns the OAuth scopes needed in place of the given classic token scopes.
+func OAuthScopesFor(tokenScopes []string) []string {
+	scopes := make([]string, 0, len(tokenScopes))
+
+	for _, tokenScope := range tokenScopes {
+		if scope, ok := oauthScopesByTokenScope[tokenScope]; ok {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+// OAuthCredentials holds the client credentials used to authenticate against the Dynatrace API instead of API tokens.
+type OAuthCredentials struct {
+	ClientID      string
+	ClientSecret  string
+	TokenEndpoint string
+	Resource      string
+	Scopes        []string
+}
+
+// OAuth creates an Option that authenticates every request with a bearer token fetched using the given client credentials.
+// The token is cached for all clients using the same credentials and refreshed shortly before it expires.
+// The token endpoint is called using the http client of the first client using the credentials, so Proxy and Certs apply to it as well.
+func OAuth(credentials OAuthCredentials) Option {
+	return func(c *dynatraceClient) {
+		tokenEndpoint := credentials.TokenEndpoint
+		if tokenEndpoint == "" {
+			tokenEndpoint = DefaultOAuthTokenEndpoint
+		}
+
+		config := clientcredentials.Config{
+			ClientID:     credentials.ClientID,
+			ClientSecret: credentials.ClientSecret,
+			TokenURL:     tokenEndpoint,
+			Scopes:       credentials.Scopes,
+		}
+
+		if credentials.Resource != "" {
+			config.EndpointParams = url.Values{oauthResourceKey: []string{credentials.Resource}}
+		}
+
+		c.tokenSource = sharedTokenSource(config, c.httpClient)
+		c.oauthScopes = credentials.Scopes
+	}
+}
+
+// sharedTokenSource returns the token source of the process for the given config, and creates it if it doesn't exist yet.
+func sharedTokenSource(config clientcredentials.Config, httpClient *http.Client) oauth2.TokenSource {
+	key := tokenSourceKey(config)
+
+	oauthTokenSourcesLock.Lock()
+	defer oauthTokenSourcesLock.Unlock()
+
+	if tokenSource, ok := oauthTokenSources[key]; ok {
+		return tokenSource
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	tokenSource := oauth2.ReuseTokenSource(nil, config.TokenSource(ctx))
+	oauthTokenSources[key] = tokenSource
+
+	return tokenSource
+}
+
+// tokenSourceKey identifies the credentials of a config, the client secret is only part of it as a hash.
+func tokenSourceKey(config clientcredentials.Config) string {
+	secretHash := sha256.Sum256([]byte(config.ClientSecret))
+
+	scopes := slices.Clone(config.Scopes)
+	slices.Sort(scopes)
+
+	return strings.Join([]string{
+		config.ClientID,
+		hex.EncodeToString(secretHash[:]),
+		config.TokenURL,
+		strings.Join(scopes, " "),
+		config.EndpointParams.Get(oauthResourceKey),
+	}, "\n")
+}
+
+// setAuthorization sets the Authorization header of the request, using the bearer token if OAuth is configured or the given API token otherwise.
+func (dtc *dynatraceClient) setAuthorization(req *http.Request, apiToken string) error {
+	if dtc.tokenSource == nil {
+		req.Header.Set("Authorization", APITokenHeader+apiToken)
+
+		return nil
+	}
+
+	token, err := dtc.tokenSource.Token()
+	if err != nil {
+		return errors.WithMessage(err, "failed to retrieve oauth token")
+	}
+
+	req.Header.Set("Authorization", bearerTokenHeader+token.AccessToken)
+
+	return nil
+}
+
+// GetOAuthScopes returns the scopes granted to the OAuth client, translated to the names of the corresponding token scopes.
+func (dtc *dynatraceClient) GetOAuthScopes(_ context.Context) (TokenScopes, error) {
+	if dtc.tokenSource == nil {
+		return nil, errors.New("client is not configured to use oauth")
+	}
+
+	token, err := dtc.tokenSource.Token()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve oauth token")
+	}
+
+	// the token endpoint may omit the granted scopes if they are identical to the requested ones
+	grantedScopes := dtc.oauthScopes
+	if granted, _ := token.Extra(oauthScopeField).(string); granted != "" {
+		grantedScopes = strings.Fields(granted)
+	}
+
+	scopes := make(TokenScopes, 0, len(grantedScopes))
+
+	for tokenScope, oauthScope := range oauthScopesByTokenScope {
+		for _, grantedScope := range grantedScopes {
+			if grantedScope == oauthScope {
+				scopes = append(scopes, tokenScope)
+			}
+		}
+	}
+
+	slices.Sort(scopes)
+
+	return scopes, nil
+}
@@ -0,0 +1,145 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	testClientID     = "client-id"
+	testClientSecret = "client-secret"
+	testAccessToken  = "access-token"
+)
+
+func oauthServerHandler(t *testing.T, grantedScopes string, tokenRequests *atomic.Int32) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/token":
+			tokenRequests.Add(1)
+
+			clientID, clientSecret, _ := request.BasicAuth()
+			assert.Equal(t, testClientID, clientID)
+			assert.Equal(t, testClientSecret, clientSecret)
+
+			writer.Header().Set("Content-Type", "application/json")
+			_, _ = writer.Write([]byte(`{"access_token": "` + testAccessToken + `", "token_type": "Bearer", "expires_in": 300, "scope": "` + grantedScopes + `"}`))
+		case "/v1/deployment/image/gateway/latest":
+			if request.Header.Get("Authorization") != bearerTokenHeader+testAccessToken {
+				writeError(writer, http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{"source": "some.registry.com", "tag": "1.2.3"}`))
+		default:
+			writeError(writer, http.StatusNotFound)
+		}
+	}
+}
+
+func TestOAuth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requests use cached bearer token", func(t *testing.T) {
+		tokenRequests := atomic.Int32{}
+		server := httptest.NewServer(oauthServerHandler(t, "", &tokenRequests))
+		defer server.Close()
+
+		dtc, err := NewClient(server.URL, "", "", OAuth(OAuthCredentials{
+			ClientID:      testClientID,
+			ClientSecret:  testClientSecret,
+			TokenEndpoint: server.URL + "/token",
+		}))
+		require.NoError(t, err)
+
+		for range 2 {
+			imageInfo, err := dtc.GetLatestActiveGateImage(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "1.2.3", imageInfo.Tag)
+		}
+
+		assert.Equal(t, int32(1), tokenRequests.Load())
+	})
+	t.Run("clients with the same credentials share the bearer token", func(t *testing.T) {
+		tokenRequests := atomic.Int32{}
+		server := httptest.NewServer(oauthServerHandler(t, "", &tokenRequests))
+		defer server.Close()
+
+		credentials := OAuthCredentials{
+			ClientID:      testClientID,
+			ClientSecret:  testClientSecret,
+			TokenEndpoint: server.URL + "/token",
+		}
+
+		for range 2 {
+			dtc, err := NewClient(server.URL, "", "", OAuth(credentials))
+			require.NoError(t, err)
+
+			_, err = dtc.GetLatestActiveGateImage(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), tokenRequests.Load())
+	})
+	t.Run("different credentials don't share the bearer token", func(t *testing.T) {
+		config := clientcredentials.Config{ClientID: testClientID, ClientSecret: testClientSecret, TokenURL: "https://example.com/token"}
+
+		otherSecret := config
+		otherSecret.ClientSecret = "other-secret"
+
+		otherResource := config
+		otherResource.EndpointParams = url.Values{oauthResourceKey: []string{"urn:dtenvironment:test"}}
+
+		assert.NotEqual(t, tokenSourceKey(config), tokenSourceKey(otherSecret))
+		assert.NotEqual(t, tokenSourceKey(config), tokenSourceKey(otherResource))
+		assert.NotContains(t, tokenSourceKey(config), testClientSecret)
+	})
+	t.Run("granted scopes are translated to token scopes", func(t *testing.T) {
+		tokenRequests := atomic.Int32{}
+		server := httptest.NewServer(oauthServerHandler(t, "settings:objects:write unknown settings:objects:read", &tokenRequests))
+		defer server.Close()
+
+		dtc, err := NewClient(server.URL, "", "", OAuth(OAuthCredentials{
+			ClientID:      testClientID,
+			ClientSecret:  testClientSecret,
+			TokenEndpoint: server.URL + "/token",
+		}))
+		require.NoError(t, err)
+
+		scopes, err := dtc.GetOAuthScopes(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, TokenScopes{TokenScopeSettingsRead, TokenScopeSettingsWrite}, scopes)
+	})
+	t.Run("requested scopes are used if server omits granted scopes", func(t *testing.T) {
+		tokenRequests := atomic.Int32{}
+		server := httptest.NewServer(oauthServerHandler(t, "", &tokenRequests))
+		defer server.Close()
+
+		dtc, err := NewClient(server.URL, "", "", OAuth(OAuthCredentials{
+			ClientID:      testClientID,
+			ClientSecret:  testClientSecret,
+			TokenEndpoint: server.URL + "/token",
+			Scopes:        OAuthScopesFor([]string{TokenScopeInstallerDownload}),
+		}))
+		require.NoError(t, err)
+
+		scopes, err := dtc.GetOAuthScopes(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, TokenScopes{TokenScopeInstallerDownload}, scopes)
+	})
+	t.Run("oauth scopes are not available for api tokens", func(t *testing.T) {
+		dtc, err := NewClient("https://example.com", apiToken, paasToken)
+		require.NoError(t, err)
+
+		_, err = dtc.GetOAuthScopes(ctx)
+		require.Error(t, err)
+	})
+}
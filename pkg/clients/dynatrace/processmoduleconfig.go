@@ -58,18 +58,21 @@ func (pmc *ProcessModuleConfig) Add(newProperty ProcessModuleProperty) *ProcessM
 // Older operator versions handled the cache wrong and multiplied properties on an update
 // instead of updating it.
 // The fixed algorithm in Add cannot handle this broken cache without this function
-// It adds every property first to a map using the property's key, to make them distinct
-// Then collects the now distinct properties and updates the cache
+// It keeps the first position of every property's key, to make them distinct, where the last value wins
+// The order of the properties is kept, so the resulting secret doesn't change between reconciles
 func (pmc *ProcessModuleConfig) fixBrokenCache() {
 	properties := make([]ProcessModuleProperty, 0, len(pmc.Properties))
-	propertyMap := make(map[string]ProcessModuleProperty)
+	propertyIndexes := make(map[string]int)
 
 	for _, property := range pmc.Properties {
-		propertyMap[property.Key] = property
-	}
+		if index, ok := propertyIndexes[property.Key]; ok {
+			properties[index] = property
+
+			continue
+		}
 
-	for _, value := range propertyMap {
-		properties = append(properties, value)
+		propertyIndexes[property.Key] = len(properties)
+		properties = append(properties, property)
 	}
 
 	pmc.Properties = properties
@@ -162,7 +165,7 @@ func (dtc *dynatraceClient) GetProcessModuleConfig(ctx context.Context, prevRevi
 		return nil, err
 	}
 
-	resp, err := dtc.httpClient.Do(req)
+	resp, err := dtc.do(req)
 
 	if dtc.checkProcessModuleConfigRequestStatus(resp) {
 		return &ProcessModuleConfig{}, nil
@@ -183,7 +186,7 @@ func (dtc *dynatraceClient) GetProcessModuleConfig(ctx context.Context, prevRevi
 }
 
 func (dtc *dynatraceClient) createProcessModuleConfigRequest(ctx context.Context, prevRevision uint) (*http.Request, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dtc.getProcessModuleConfigURL(), nil)
+	req, err := http.NewRequestWithContext(dtc.withTokenType(ctx, dynatracePaaSToken), http.MethodGet, dtc.getProcessModuleConfigURL(), nil)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error initializing http request")
 	}
@@ -197,7 +200,10 @@ func (dtc *dynatraceClient) createProcessModuleConfigRequest(ctx context.Context
 
 	req.URL.RawQuery = query.Encode()
 	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", APITokenHeader+dtc.paasToken)
+
+	if err := dtc.setAuthorization(req, dtc.paasToken); err != nil {
+		return nil, err
+	}
 
 	return req, nil
 }
@@ -307,6 +307,25 @@ func TestAdd(t *testing.T) {
 			Value:   testValue,
 		})
 	})
+	t.Run("keeps order when fixing cache", func(t *testing.T) {
+		processModuleConfig := &ProcessModuleConfig{
+			Properties: []ProcessModuleProperty{
+				{Section: testSection, Key: "b", Value: "1"},
+				{Section: testSection, Key: "a", Value: "1"},
+				{Section: testSection, Key: "b", Value: "2"},
+				{Section: testSection, Key: "c", Value: "1"},
+			},
+		}
+
+		processModuleConfig.Add(ProcessModuleProperty{Section: testSection, Key: "d", Value: "1"})
+
+		assert.Equal(t, []ProcessModuleProperty{
+			{Section: testSection, Key: "b", Value: "2"},
+			{Section: testSection, Key: "a", Value: "1"},
+			{Section: testSection, Key: "c", Value: "1"},
+			{Section: testSection, Key: "d", Value: "1"},
+		}, processModuleConfig.Properties)
+	})
 }
 
 func TestProcessModuleConfig_AddProxy(t *testing.T) {
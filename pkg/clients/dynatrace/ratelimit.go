@@ -0,0 +1,30 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestLimiter is consulted before every request sent to the Dynatrace API.
+// Wait blocks until the request to the given host may be sent, or returns an error if the request was rejected.
+type RequestLimiter interface {
+	Wait(ctx context.Context, host string) error
+}
+
+// RateLimit creates an Option that makes every request of the client, including retries, go through the given limiter.
+// The limiter may be shared between clients.
+func RateLimit(limiter RequestLimiter) Option {
+	return func(c *dynatraceClient) {
+		c.limiter = limiter
+	}
+}
+
+func (dtc *dynatraceClient) send(req *http.Request) (*http.Response, error) {
+	if dtc.limiter != nil {
+		if err := dtc.limiter.Wait(req.Context(), req.URL.Host); err != nil {
+			return nil, err
+		}
+	}
+
+	return dtc.httpClient.Do(req)
+}
@@ -0,0 +1,64 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingLimiter struct {
+	err   error
+	hosts []string
+}
+
+func (l *countingLimiter) Wait(_ context.Context, host string) error {
+	l.hosts = append(l.hosts, host)
+
+	return l.err
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("every attempt goes through limiter", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(1, http.StatusTooManyRequests, &calls, nil))
+		defer server.Close()
+
+		limiter := &countingLimiter{}
+		dtc := &dynatraceClient{httpClient: server.Client(), retryPolicy: testRetryPolicy()}
+		RateLimit(limiter)(dtc)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, []string{req.URL.Host, req.URL.Host}, limiter.hosts)
+	})
+	t.Run("rejected requests are not sent", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(0, http.StatusOK, &calls, nil))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client()}
+		RateLimit(&countingLimiter{err: errors.New("rejected")})(dtc)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req) //nolint:bodyclose
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, int32(0), calls.Load())
+	})
+}
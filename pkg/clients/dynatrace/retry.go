@@ -0,0 +1,30 @@
+package dynatrace
+
+import (
+	"net/http"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+)
+
+// RetryPolicy configures how requests to the Dynatrace API are retried when the server is throttling or temporarily unavailable.
+// The zero value disables retries.
+type RetryPolicy = utils.RetryPolicy
+
+// DefaultRetryPolicy returns the RetryPolicy used by the operator when talking to the Dynatrace API.
+func DefaultRetryPolicy() RetryPolicy {
+	return utils.DefaultRetryPolicy()
+}
+
+// Retry creates an Option that makes the client retry throttled (429) and failed (5xx) requests according to the given policy.
+// Non-idempotent requests (e.g. POST requests creating settings objects) are only retried if the server rejected them with 429.
+// Retries never outlive the context of the request.
+func Retry(policy RetryPolicy) Option {
+	return func(c *dynatraceClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// doWithRetry sends the request using the http client of dtc, respecting the configured RequestLimiter, and retries it according to the configured RetryPolicy.
+func (dtc *dynatraceClient) doWithRetry(req *http.Request) (*http.Response, error) {
+	return utils.DoWithRetry(log, dtc.retryPolicy, req, dtc.send)
+}
@@ -0,0 +1,196 @@
+package dynatrace
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+}
+
+func failingHandler(failures int, status int, calls *atomic.Int32, bodies *[]string) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if bodies != nil && request.Body != nil {
+			body, _ := io.ReadAll(request.Body)
+			*bodies = append(*bodies, string(body))
+		}
+
+		if int(calls.Add(1)) <= failures {
+			writer.WriteHeader(status)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"scopes": ["a"]}`))
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries throttled GET requests", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(2, http.StatusTooManyRequests, &calls, nil))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client(), retryPolicy: testRetryPolicy()}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+	t.Run("gives up after max retries", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(10, http.StatusServiceUnavailable, &calls, nil))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client(), retryPolicy: testRetryPolicy()}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+	t.Run("does not retry without policy", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(1, http.StatusTooManyRequests, &calls, nil))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client()}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("does not retry POST on server error", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(1, http.StatusInternalServerError, &calls, nil))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client(), retryPolicy: testRetryPolicy()}
+
+		req, err := dtc.createBaseRequest(ctx, server.URL, http.MethodPost, strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("retries throttled POST with the same body", func(t *testing.T) {
+		calls := atomic.Int32{}
+		bodies := []string{}
+		server := httptest.NewServer(failingHandler(1, http.StatusTooManyRequests, &calls, &bodies))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client(), retryPolicy: testRetryPolicy()}
+
+		req, err := dtc.createBaseRequest(ctx, server.URL, http.MethodPost, strings.NewReader(`{"a":"b"}`))
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{`{"a":"b"}`, `{"a":"b"}`}, bodies)
+	})
+	t.Run("retries idempotent POST on server error", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(1, http.StatusBadGateway, &calls, nil))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client(), url: server.URL, retryPolicy: testRetryPolicy()}
+
+		scopes, err := dtc.GetTokenScopes(ctx, apiToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, TokenScopes{"a"}, scopes)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+	t.Run("stops if Retry-After exceeds max backoff", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			writer.Header().Set(utils.RetryAfterHeader, "120")
+			writer.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		dtc := &dynatraceClient{httpClient: server.Client(), retryPolicy: testRetryPolicy()}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("stops if backoff exceeds context deadline", func(t *testing.T) {
+		calls := atomic.Int32{}
+		server := httptest.NewServer(failingHandler(10, http.StatusTooManyRequests, &calls, nil))
+		defer server.Close()
+
+		policy := testRetryPolicy()
+		policy.InitialBackoff = time.Minute
+		policy.MaxBackoff = time.Hour
+		dtc := &dynatraceClient{httpClient: server.Client(), retryPolicy: policy}
+
+		deadlineCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(deadlineCtx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := dtc.do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
@@ -58,7 +58,7 @@ func (dtc *dynatraceClient) GetMonitoredEntitiesForKubeSystemUUID(ctx context.Co
 		return nil, errors.New("no kube-system namespace UUID given")
 	}
 
-	req, err := createBaseRequest(ctx, dtc.getEntitiesURL(), http.MethodGet, dtc.apiToken, nil)
+	req, err := dtc.createBaseRequest(ctx, dtc.getEntitiesURL(), http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +70,7 @@ func (dtc *dynatraceClient) GetMonitoredEntitiesForKubeSystemUUID(ctx context.Co
 	q.Add(fieldsQueryParam, entitiesNeededFields)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := dtc.httpClient.Do(req)
+	res, err := dtc.do(req)
 	defer utils.CloseBodyAfterRequest(res)
 
 	if err != nil {
@@ -94,7 +94,7 @@ func (dtc *dynatraceClient) GetSettingsForMonitoredEntity(ctx context.Context, m
 		return GetSettingsResponse{TotalCount: 0}, nil
 	}
 
-	req, err := createBaseRequest(ctx, dtc.getSettingsURL(true), http.MethodGet, dtc.apiToken, nil)
+	req, err := dtc.createBaseRequest(ctx, dtc.getSettingsURL(true), http.MethodGet, nil)
 	if err != nil {
 		return GetSettingsResponse{}, err
 	}
@@ -104,7 +104,7 @@ func (dtc *dynatraceClient) GetSettingsForMonitoredEntity(ctx context.Context, m
 	q.Add(scopesQueryParam, monitoredEntity.EntityID)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := dtc.httpClient.Do(req)
+	res, err := dtc.do(req)
 	defer utils.CloseBodyAfterRequest(res)
 
 	if err != nil {
@@ -128,7 +128,7 @@ func (dtc *dynatraceClient) GetSettingsForLogModule(ctx context.Context, monitor
 		return GetLogMonSettingsResponse{TotalCount: 0}, nil
 	}
 
-	req, err := createBaseRequest(ctx, dtc.getSettingsURL(true), http.MethodGet, dtc.apiToken, nil)
+	req, err := dtc.createBaseRequest(ctx, dtc.getSettingsURL(true), http.MethodGet, nil)
 	if err != nil {
 		return GetLogMonSettingsResponse{}, err
 	}
@@ -138,7 +138,7 @@ func (dtc *dynatraceClient) GetSettingsForLogModule(ctx context.Context, monitor
 	q.Add(scopesQueryParam, monitoredEntity)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := dtc.httpClient.Do(req)
+	res, err := dtc.do(req)
 	defer utils.CloseBodyAfterRequest(res)
 
 	if err != nil {
@@ -36,7 +36,8 @@ func (dtc *dynatraceClient) GetTokenScopes(ctx context.Context, token string) (T
 		return nil, errors.WithStack(err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dtc.getTokensLookupURL(), bytes.NewBuffer(jsonStr))
+	// the lookup does not change anything on the server, so it is safe to retry
+	req, err := http.NewRequestWithContext(utils.WithIdempotentRequest(ctx), http.MethodPost, dtc.getTokensLookupURL(), bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return nil, errors.WithMessage(err, "error initializing http request")
 	}
@@ -44,7 +45,7 @@ func (dtc *dynatraceClient) GetTokenScopes(ctx context.Context, token string) (T
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", APITokenHeader+token)
 
-	resp, err := dtc.httpClient.Do(req)
+	resp, err := dtc.do(req)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error making post request to dynatrace api")
 	}
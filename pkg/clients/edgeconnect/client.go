@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
 	"github.com/pkg/errors"
@@ -16,13 +17,23 @@ import (
 
 const (
 	contentTypeJSON = "application/json"
+
+	// DefaultTimeout limits a single attempt of a request, retries get a timeout of their own.
+	DefaultTimeout = 30 * time.Second
 )
 
 type client struct {
 	clientcredentials.Config
-	ctx        context.Context
-	httpClient *http.Client
-	baseURL    string
+	ctx         context.Context
+	httpClient  *http.Client
+	baseURL     string
+	timeout     time.Duration
+	retryPolicy utils.RetryPolicy
+
+	// instrumentationName is the EdgeConnect name used to label the request metrics, the metrics are only recorded if set.
+	instrumentationName string
+	// instrumentationNamespace is the EdgeConnect namespace used to label the request metrics.
+	instrumentationNamespace string
 }
 
 // Option can be passed to NewClient and customizes the created client instance.
@@ -36,6 +47,8 @@ func NewClient(clientID, clientSecret string, options ...Option) (Client, error)
 			ClientID:     clientID,
 			ClientSecret: clientSecret,
 		},
+		ctx:     context.Background(),
+		timeout: DefaultTimeout,
 	}
 
 	for _, opt := range options {
@@ -47,6 +60,12 @@ func NewClient(clientID, clientSecret string, options ...Option) (Client, error)
 		return nil, errors.New("can't create http client for edge connect")
 	}
 
+	httpClient.Timeout = c.timeout
+
+	if c.instrumentationName != "" {
+		httpClient.Transport = utils.NewInstrumentedTransport(httpClient.Transport, instrumentationClient, c.instrumentationNamespace, c.instrumentationName, utils.TokenTypeOAuth, endpointResolver)
+	}
+
 	c.httpClient = httpClient
 
 	return c, nil
@@ -70,7 +89,7 @@ func WithOauthScopes(scopes []string) func(*client) {
 	}
 }
 
-// WithContext can set context for client
+// WithContext can set context for client, it is used to fetch OAuth tokens, requests use the context passed to each method.
 // NB: via context you can override default http client to add Proxy or CA certificates
 func WithContext(ctx context.Context) func(*client) {
 	return func(c *client) {
@@ -78,6 +97,29 @@ func WithContext(ctx context.Context) func(*client) {
 	}
 }
 
+// WithTimeout limits the duration of a single request attempt, 0 disables the timeout.
+func WithTimeout(timeout time.Duration) func(*client) {
+	return func(c *client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetryPolicy makes the client retry throttled (429) and failed (5xx) requests like the Dynatrace API client.
+func WithRetryPolicy(policy utils.RetryPolicy) func(*client) {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithInstrumentation records the count and duration of all requests sent by the client, labeled with the given EdgeConnect namespace and name.
+// The metrics are served on the metrics endpoint of the operator.
+func WithInstrumentation(edgeConnectNamespace, edgeConnectName string) func(*client) {
+	return func(c *client) {
+		c.instrumentationNamespace = edgeConnectNamespace
+		c.instrumentationName = edgeConnectName
+	}
+}
+
 // ServerError represents an error returned from the server (e.g. authentication failure).
 type ServerError struct {
 	Message string       `json:"message,omitempty"`
@@ -153,6 +195,11 @@ func (c *client) handleErrorResponseFromSettingsAPI(response []byte, statusCode
 	return se.Error
 }
 
+// do sends the request and retries it according to the configured RetryPolicy.
+func (c *client) do(req *http.Request) (*http.Response, error) {
+	return utils.DoWithRetry(log, c.retryPolicy, req, c.httpClient.Do)
+}
+
 func (c *client) getServerResponseData(response *http.Response) ([]byte, error) {
 	responseData, err := io.ReadAll(response.Body)
 	if err != nil {
@@ -183,15 +230,15 @@ func (c *client) getSettingsAPIResponseData(response *http.Response) ([]byte, er
 }
 
 // GetEdgeConnect returns edge connect if it exists
-func (c *client) GetEdgeConnect(edgeConnectID string) (GetResponse, error) {
+func (c *client) GetEdgeConnect(ctx context.Context, edgeConnectID string) (GetResponse, error) {
 	edgeConnectURL := c.getEdgeConnectURL(edgeConnectID)
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, edgeConnectURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, edgeConnectURL, nil)
 	if err != nil {
 		return GetResponse{}, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
@@ -214,7 +261,7 @@ func (c *client) GetEdgeConnect(edgeConnectID string) (GetResponse, error) {
 }
 
 // UpdateEdgeConnect updates existing edge connect hostPatterns and oauthClientId
-func (c *client) UpdateEdgeConnect(edgeConnectID string, request *Request) error {
+func (c *client) UpdateEdgeConnect(ctx context.Context, edgeConnectID string, request *Request) error {
 	edgeConnectURL := c.getEdgeConnectURL(edgeConnectID)
 
 	payloadBuf := new(bytes.Buffer)
@@ -224,14 +271,14 @@ func (c *client) UpdateEdgeConnect(edgeConnectID string, request *Request) error
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodPut, edgeConnectURL, payloadBuf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, edgeConnectURL, payloadBuf)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", contentTypeJSON)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
@@ -253,15 +300,15 @@ func (c *client) UpdateEdgeConnect(edgeConnectID string, request *Request) error
 }
 
 // DeleteEdgeConnect deletes edge connect using DELETE method for give edgeConnectId
-func (c *client) DeleteEdgeConnect(edgeConnectID string) error {
+func (c *client) DeleteEdgeConnect(ctx context.Context, edgeConnectID string) error {
 	edgeConnectURL := c.getEdgeConnectURL(edgeConnectID)
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodDelete, edgeConnectURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, edgeConnectURL, nil)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
@@ -283,7 +330,7 @@ func (c *client) DeleteEdgeConnect(edgeConnectID string) error {
 }
 
 // CreateEdgeConnect creates new edge connect
-func (c *client) CreateEdgeConnect(request *Request) (CreateResponse, error) {
+func (c *client) CreateEdgeConnect(ctx context.Context, request *Request) (CreateResponse, error) {
 	edgeConnectsURL := c.getEdgeConnectsURL()
 
 	payloadBuf := new(bytes.Buffer)
@@ -293,14 +340,14 @@ func (c *client) CreateEdgeConnect(request *Request) (CreateResponse, error) {
 		return CreateResponse{}, err
 	}
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodPost, edgeConnectsURL, payloadBuf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, edgeConnectsURL, payloadBuf)
 	if err != nil {
 		return CreateResponse{}, err
 	}
 
 	req.Header.Set("Content-Type", contentTypeJSON)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 
 	defer utils.CloseBodyAfterRequest(resp)
 
@@ -324,10 +371,10 @@ func (c *client) CreateEdgeConnect(request *Request) (CreateResponse, error) {
 }
 
 // GetEdgeConnects returns list of edge connects
-func (c *client) GetEdgeConnects(name string) (ListResponse, error) {
+func (c *client) GetEdgeConnects(ctx context.Context, name string) (ListResponse, error) {
 	edgeConnectsURL := c.getEdgeConnectsURL()
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, edgeConnectsURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, edgeConnectsURL, nil)
 	if err != nil {
 		return ListResponse{}, err
 	}
@@ -337,7 +384,7 @@ func (c *client) GetEdgeConnects(name string) (ListResponse, error) {
 		"filter":     {fmt.Sprintf("name='%s'", name)},
 	}.Encode()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -37,7 +39,7 @@ func TestCreateEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectCreateServerHandler(false))
 		defer edgeConnectServer.Close()
 
-		resp, err := edgeConnectClient.CreateEdgeConnect(NewRequest("InternalServices", []string{"*.internal.org"}, []edgeconnect.HostMapping{}, "dt0s02.AIOUP56P"))
+		resp, err := edgeConnectClient.CreateEdgeConnect(context.Background(), NewRequest("InternalServices", []string{"*.internal.org"}, []edgeconnect.HostMapping{}, "dt0s02.AIOUP56P"))
 		require.NoError(t, err)
 		assert.Equal(t, "InternalServices", resp.Name)
 	})
@@ -45,7 +47,7 @@ func TestCreateEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectCreateServerHandler(true))
 		defer edgeConnectServer.Close()
 
-		_, err := edgeConnectClient.CreateEdgeConnect(NewRequest("", []string{"*.internal.org"}, []edgeconnect.HostMapping{}, "dt0s02.AIOUP56P"))
+		_, err := edgeConnectClient.CreateEdgeConnect(context.Background(), NewRequest("", []string{"*.internal.org"}, []edgeconnect.HostMapping{}, "dt0s02.AIOUP56P"))
 		require.Error(t, err, "edgeconnect server error 400: Constraints violated.")
 	})
 	t.Run("create edge connect with hostMappings", func(t *testing.T) {
@@ -59,7 +61,7 @@ func TestCreateEdgeConnect(t *testing.T) {
 			},
 		}
 
-		_, err := edgeConnectClient.CreateEdgeConnect(NewRequest("InternalServices", []string{"*.internal.org"}, hostMappings, "dt0s02.AIOUP56P"))
+		_, err := edgeConnectClient.CreateEdgeConnect(context.Background(), NewRequest("InternalServices", []string{"*.internal.org"}, hostMappings, "dt0s02.AIOUP56P"))
 		require.NoError(t, err)
 	})
 }
@@ -69,7 +71,7 @@ func TestGetEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectGetServerHandler())
 		defer edgeConnectServer.Close()
 
-		resp, err := edgeConnectClient.GetEdgeConnect("348b4cd9-ba31-4670-9c45-9125a7d87439")
+		resp, err := edgeConnectClient.GetEdgeConnect(context.Background(), "348b4cd9-ba31-4670-9c45-9125a7d87439")
 		require.NoError(t, err)
 		assert.Equal(t, "InternalServices", resp.Name)
 	})
@@ -78,7 +80,7 @@ func TestGetEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectGetServerHandler())
 		defer edgeConnectServer.Close()
 
-		_, err := edgeConnectClient.GetEdgeConnect("not-found")
+		_, err := edgeConnectClient.GetEdgeConnect(context.Background(), "not-found")
 		require.Error(t, err, http.StatusBadRequest)
 	})
 }
@@ -88,7 +90,7 @@ func TestDeleteEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectDeleteServerHandler())
 		defer edgeConnectServer.Close()
 
-		err := edgeConnectClient.DeleteEdgeConnect("348b4cd9-ba31-4670-9c45-9125a7d87439")
+		err := edgeConnectClient.DeleteEdgeConnect(context.Background(), "348b4cd9-ba31-4670-9c45-9125a7d87439")
 		require.NoError(t, err)
 	})
 
@@ -96,7 +98,7 @@ func TestDeleteEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectDeleteServerHandler())
 		defer edgeConnectServer.Close()
 
-		err := edgeConnectClient.DeleteEdgeConnect("not-found")
+		err := edgeConnectClient.DeleteEdgeConnect(context.Background(), "not-found")
 		require.Error(t, err, http.StatusBadRequest)
 	})
 }
@@ -106,7 +108,7 @@ func TestUpdateEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectUpdateServerHandler())
 		defer edgeConnectServer.Close()
 
-		err := edgeConnectClient.UpdateEdgeConnect(EdgeConnectID, NewRequest("test_name", []string{""}, []edgeconnect.HostMapping{}, ""))
+		err := edgeConnectClient.UpdateEdgeConnect(context.Background(), EdgeConnectID, NewRequest("test_name", []string{""}, []edgeconnect.HostMapping{}, ""))
 		require.NoError(t, err)
 	})
 
@@ -114,23 +116,88 @@ func TestUpdateEdgeConnect(t *testing.T) {
 		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectUpdateServerHandler())
 		defer edgeConnectServer.Close()
 
-		err := edgeConnectClient.UpdateEdgeConnect("", NewRequest("test_name", []string{""}, []edgeconnect.HostMapping{}, ""))
+		err := edgeConnectClient.UpdateEdgeConnect(context.Background(), "", NewRequest("test_name", []string{""}, []edgeconnect.HostMapping{}, ""))
 		require.Error(t, err, http.StatusBadRequest)
 	})
 }
 
-func createTestEdgeConnectServer(t *testing.T, handler http.Handler) (*httptest.Server, Client) {
+func TestRetryAndTimeout(t *testing.T) {
+	throttlingHandler := func(throttled int, delay time.Duration) http.HandlerFunc {
+		calls := 0
+
+		return func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("Content-Type", "application/json")
+
+			if request.URL.Path == "/sso/oauth2/token" {
+				writeOauthTokenResponse(writer)
+
+				return
+			}
+
+			time.Sleep(delay)
+
+			calls++
+			if calls <= throttled {
+				writeError(writer, http.StatusTooManyRequests)
+
+				return
+			}
+
+			edgeConnectGetServerHandler()(writer, request)
+		}
+	}
+	testPolicy := utils.RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond}
+
+	t.Run("throttled requests are retried", func(t *testing.T) {
+		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, throttlingHandler(2, 0), WithRetryPolicy(testPolicy))
+		defer edgeConnectServer.Close()
+
+		resp, err := edgeConnectClient.GetEdgeConnect(context.Background(), EdgeConnectID)
+		require.NoError(t, err)
+		assert.Equal(t, "InternalServices", resp.Name)
+	})
+	t.Run("throttled requests fail without retry policy", func(t *testing.T) {
+		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, throttlingHandler(1, 0))
+		defer edgeConnectServer.Close()
+
+		_, err := edgeConnectClient.GetEdgeConnect(context.Background(), EdgeConnectID)
+		require.Error(t, err)
+	})
+	t.Run("slow requests time out", func(t *testing.T) {
+		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, throttlingHandler(0, 100*time.Millisecond), WithTimeout(10*time.Millisecond))
+		defer edgeConnectServer.Close()
+
+		_, err := edgeConnectClient.GetEdgeConnect(context.Background(), EdgeConnectID)
+		require.Error(t, err)
+	})
+	t.Run("canceled context aborts request", func(t *testing.T) {
+		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, throttlingHandler(0, 100*time.Millisecond))
+		defer edgeConnectServer.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := edgeConnectClient.GetEdgeConnect(ctx, EdgeConnectID)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func createTestEdgeConnectServer(t *testing.T, handler http.Handler, opts ...Option) (*httptest.Server, Client) {
 	edgeConnectServer := httptest.NewServer(handler)
 
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, edgeConnectServer.Client())
 
-	edgeConnectClient, err := NewClient(
-		EdgeConnectOAuthClientID,
-		EdgeConnectOAuthClientSecret,
+	options := []Option{
 		WithOauthScopes([]string{"test_scopes"}),
 		WithBaseURL(edgeConnectServer.URL),
-		WithTokenURL(edgeConnectServer.URL+"/sso/oauth2/token"),
+		WithTokenURL(edgeConnectServer.URL + "/sso/oauth2/token"),
 		WithContext(ctx),
+	}
+
+	edgeConnectClient, err := NewClient(
+		EdgeConnectOAuthClientID,
+		EdgeConnectOAuthClientSecret,
+		append(options, opts...)...,
 	)
 
 	require.NoError(t, err)
@@ -0,0 +1,9 @@
+package edgeconnect
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+var (
+	log = logd.Get().WithName("edgeconnect-client")
+)
@@ -1,5 +1,17 @@
 package edgeconnect
 
+import "github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+
+const instrumentationClient = "edgeconnect"
+
+// endpointResolver maps requests to the endpoints below, which are used to label the request metrics.
+var endpointResolver = utils.NewEndpointResolver(
+	"/platform/app-engine/edge-connect/v1/edge-connects",
+	"/platform/app-engine/edge-connect/v1/edge-connects/{id}",
+	"/platform/classic/environment-api/v2/settings/objects",
+	"/platform/classic/environment-api/v2/settings/objects/{objectId}",
+)
+
 // EdgeConnect API
 
 func (c *client) getEdgeConnectAPIURL() string {
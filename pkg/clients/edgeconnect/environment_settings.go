@@ -2,6 +2,7 @@ package edgeconnect
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -34,10 +35,10 @@ type EnvironmentSettingsResponse struct {
 	PageSize   int                  `json:"pageSize"`
 }
 
-func (c *client) GetConnectionSettings() ([]EnvironmentSetting, error) {
+func (c *client) GetConnectionSettings(ctx context.Context) ([]EnvironmentSetting, error) {
 	settingsObjectsURL := c.getSettingsObjectsURL()
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, settingsObjectsURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, settingsObjectsURL, nil)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error initializing http request")
 	}
@@ -48,7 +49,7 @@ func (c *client) GetConnectionSettings() ([]EnvironmentSetting, error) {
 
 	req.URL.RawQuery = q.Encode()
 
-	response, err := c.httpClient.Do(req)
+	response, err := c.do(req)
 	defer utils.CloseBodyAfterRequest(response)
 
 	if err != nil {
@@ -70,20 +71,20 @@ func (c *client) GetConnectionSettings() ([]EnvironmentSetting, error) {
 	return resDataJSON.Items, nil
 }
 
-func (c *client) CreateConnectionSetting(es EnvironmentSetting) error {
+func (c *client) CreateConnectionSetting(ctx context.Context, es EnvironmentSetting) error {
 	jsonStr, err := json.Marshal([]EnvironmentSetting{es})
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodPost, c.getSettingsObjectsURL(), bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getSettingsObjectsURL(), bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return errors.WithMessage(err, "error initializing http request")
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 
-	response, err := c.httpClient.Do(req)
+	response, err := c.do(req)
 
 	defer utils.CloseBodyAfterRequest(response)
 
@@ -100,20 +101,20 @@ func (c *client) CreateConnectionSetting(es EnvironmentSetting) error {
 	return nil
 }
 
-func (c *client) UpdateConnectionSetting(es EnvironmentSetting) error {
+func (c *client) UpdateConnectionSetting(ctx context.Context, es EnvironmentSetting) error {
 	jsonStr, err := json.Marshal(es)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodPut, c.getSettingsObjectsIDURL(*es.ObjectID), bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.getSettingsObjectsIDURL(*es.ObjectID), bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return errors.WithMessage(err, "error initializing http request")
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 
-	response, err := c.httpClient.Do(req)
+	response, err := c.do(req)
 	if err != nil {
 		return errors.WithMessage(err, "error making post request to dynatrace api")
 	}
@@ -129,13 +130,13 @@ func (c *client) UpdateConnectionSetting(es EnvironmentSetting) error {
 	return nil
 }
 
-func (c *client) DeleteConnectionSetting(objectID string) error {
-	req, err := http.NewRequestWithContext(c.ctx, http.MethodDelete, c.getSettingsObjectsIDURL(objectID), nil)
+func (c *client) DeleteConnectionSetting(ctx context.Context, objectID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getSettingsObjectsIDURL(objectID), nil)
 	if err != nil {
 		return errors.WithMessage(err, "error initializing http request")
 	}
 
-	response, err := c.httpClient.Do(req)
+	response, err := c.do(req)
 
 	defer utils.CloseBodyAfterRequest(response)
 
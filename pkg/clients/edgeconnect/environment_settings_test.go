@@ -28,19 +28,19 @@ var testEnvironmentSetting = EnvironmentSetting{
 func TestGetConnectionSetting(t *testing.T) {
 	t.Run("Server response OK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusOK))
-		got, err := client.GetConnectionSettings()
+		got, err := client.GetConnectionSettings(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, got)
 	})
 	t.Run("Server response NOK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusBadRequest))
-		got, err := client.GetConnectionSettings()
+		got, err := client.GetConnectionSettings(context.Background())
 		require.Error(t, err)
 		require.Nil(t, got)
 	})
 	t.Run("Server response unexpected", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockUnexpectedServerHandler())
-		got, err := client.GetConnectionSettings()
+		got, err := client.GetConnectionSettings(context.Background())
 		require.Error(t, err)
 		require.Nil(t, got)
 	})
@@ -49,17 +49,17 @@ func TestGetConnectionSetting(t *testing.T) {
 func TestCreateConnectionSetting(t *testing.T) {
 	t.Run("Server response OK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusOK))
-		err := client.CreateConnectionSetting(testEnvironmentSetting)
+		err := client.CreateConnectionSetting(context.Background(), testEnvironmentSetting)
 		require.NoError(t, err)
 	})
 	t.Run("Server response NOK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusBadRequest))
-		err := client.CreateConnectionSetting(testEnvironmentSetting)
+		err := client.CreateConnectionSetting(context.Background(), testEnvironmentSetting)
 		require.Error(t, err)
 	})
 	t.Run("Server response unexpected", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockUnexpectedServerHandler())
-		err := client.CreateConnectionSetting(testEnvironmentSetting)
+		err := client.CreateConnectionSetting(context.Background(), testEnvironmentSetting)
 		require.Error(t, err)
 	})
 }
@@ -67,17 +67,17 @@ func TestCreateConnectionSetting(t *testing.T) {
 func TestUpdateConnectionSetting(t *testing.T) {
 	t.Run("Server response OK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusOK))
-		err := client.UpdateConnectionSetting(testEnvironmentSetting)
+		err := client.UpdateConnectionSetting(context.Background(), testEnvironmentSetting)
 		require.NoError(t, err)
 	})
 	t.Run("Server response NOK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusBadRequest))
-		err := client.UpdateConnectionSetting(testEnvironmentSetting)
+		err := client.UpdateConnectionSetting(context.Background(), testEnvironmentSetting)
 		require.Error(t, err)
 	})
 	t.Run("Server response unexpected", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockUnexpectedServerHandler())
-		err := client.UpdateConnectionSetting(testEnvironmentSetting)
+		err := client.UpdateConnectionSetting(context.Background(), testEnvironmentSetting)
 		require.Error(t, err)
 	})
 }
@@ -85,17 +85,17 @@ func TestUpdateConnectionSetting(t *testing.T) {
 func TestDeleteConnectionSetting(t *testing.T) {
 	t.Run("Server response OK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusOK))
-		err := client.DeleteConnectionSetting(testObjectID)
+		err := client.DeleteConnectionSetting(context.Background(), testObjectID)
 		require.NoError(t, err)
 	})
 	t.Run("Server response NOK", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockServerHandler(http.StatusBadRequest))
-		err := client.DeleteConnectionSetting(testObjectID)
+		err := client.DeleteConnectionSetting(context.Background(), testObjectID)
 		require.Error(t, err)
 	})
 	t.Run("Server response unexpected", func(t *testing.T) {
 		client := mockEdgeConnectClient(mockUnexpectedServerHandler())
-		err := client.DeleteConnectionSetting(testObjectID)
+		err := client.DeleteConnectionSetting(context.Background(), testObjectID)
 		require.Error(t, err)
 	})
 }
@@ -1,31 +1,34 @@
 package edgeconnect
 
+import "context"
+
 // Client is the interface for the Dynatrace EdgeConnect REST API client.
+// Every request is bound to the given context, so canceling it aborts the request including pending retries.
 type Client interface {
 	// GetEdgeConnect return details of single edge connect
-	GetEdgeConnect(edgeConnectID string) (GetResponse, error)
+	GetEdgeConnect(ctx context.Context, edgeConnectID string) (GetResponse, error)
 
 	// CreateEdgeConnect creates edge connect
-	CreateEdgeConnect(request *Request) (CreateResponse, error)
+	CreateEdgeConnect(ctx context.Context, request *Request) (CreateResponse, error)
 
 	// UpdateEdgeConnect updates edge connect
-	UpdateEdgeConnect(edgeConnectID string, request *Request) error
+	UpdateEdgeConnect(ctx context.Context, edgeConnectID string, request *Request) error
 
 	// DeleteEdgeConnect deletes edge connect
-	DeleteEdgeConnect(edgeConnectID string) error
+	DeleteEdgeConnect(ctx context.Context, edgeConnectID string) error
 
 	// GetEdgeConnects returns list of edge connects
-	GetEdgeConnects(name string) (ListResponse, error)
+	GetEdgeConnects(ctx context.Context, name string) (ListResponse, error)
 
 	// GetConnectionSettings returns all connection setting objects
-	GetConnectionSettings() ([]EnvironmentSetting, error)
+	GetConnectionSettings(ctx context.Context) ([]EnvironmentSetting, error)
 
 	// CreateConnectionSetting creates a connection setting object
-	CreateConnectionSetting(es EnvironmentSetting) error
+	CreateConnectionSetting(ctx context.Context, es EnvironmentSetting) error
 
 	// UpdateConnectionSetting updates a connection setting object
-	UpdateConnectionSetting(es EnvironmentSetting) error
+	UpdateConnectionSetting(ctx context.Context, es EnvironmentSetting) error
 
 	// DeleteConnectionSetting deletes a connection setting object
-	DeleteConnectionSetting(objectID string) error
+	DeleteConnectionSetting(ctx context.Context, objectID string) error
 }
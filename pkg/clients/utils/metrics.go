@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	TokenTypeAPI   = "api-token"
+	TokenTypePaaS  = "paas-token"
+	TokenTypeOAuth = "oauth"
+	TokenTypeNone  = "none"
+
+	// OtherEndpoint is the endpoint label of requests that match none of the known endpoints, e.g. agent downloads from installer URLs.
+	OtherEndpoint = "other"
+
+	statusClassError = "error"
+)
+
+var (
+	apiRequestLabels = []string{"client", "endpoint", "method", "status_class", "namespace", "name", "token_type"}
+
+	apiRequestsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api",
+		Name:      "requests_total",
+		Help:      "Number of requests sent to Dynatrace APIs, including retries",
+	}, apiRequestLabels)
+	apiRequestDurationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of requests sent to Dynatrace APIs until the response headers were received",
+		Buckets:   prometheus.DefBuckets,
+	}, apiRequestLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequestsMetric, apiRequestDurationMetric)
+}
+
+// EndpointResolver maps the URL path of a request to the logical endpoint it belongs to.
+// It keeps IDs and versions that are part of the path out of the metric labels.
+type EndpointResolver func(path string) string
+
+// NewEndpointResolver creates an EndpointResolver for the given path templates, segments in braces (e.g. "{id}") match any value.
+// Templates are matched against the end of the path, so the base path of the API (e.g. "/e/<tenant>/api") does not matter.
+// The first matching template is returned, paths matching none of them resolve to OtherEndpoint.
+func NewEndpointResolver(templates ...string) EndpointResolver {
+	splitTemplates := make([][]string, len(templates))
+	for i, template := range templates {
+		splitTemplates[i] = splitPath(template)
+	}
+
+	return func(path string) string {
+		segments := splitPath(path)
+
+		for i, template := range splitTemplates {
+			if matchesSuffix(segments, template) {
+				return templates[i]
+			}
+		}
+
+		return OtherEndpoint
+	}
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchesSuffix(segments, template []string) bool {
+	if len(segments) < len(template) {
+		return false
+	}
+
+	segments = segments[len(segments)-len(template):]
+
+	for i, part := range template {
+		isPlaceholder := strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}")
+		if !isPlaceholder && part != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type tokenTypeKey struct{}
+
+// WithTokenType sets the token type label of the request metrics for requests created with the returned context.
+// Without it, the token type is derived from the Authorization header of the request.
+func WithTokenType(ctx context.Context, tokenType string) context.Context {
+	return context.WithValue(ctx, tokenTypeKey{}, tokenType)
+}
+
+// InstrumentedTransport is an http.RoundTripper recording the count and duration of every request sent to a Dynatrace API.
+// Metrics are served on the metrics endpoint of the operator, every request is traced as a child span of the span in its context.
+type InstrumentedTransport struct {
+	next    http.RoundTripper
+	resolve EndpointResolver
+
+	client           string
+	namespace        string
+	name             string
+	defaultTokenType string
+}
+
+// NewInstrumentedTransport wraps next, labeling the recorded metrics with the given client (e.g. "dynatrace") and
+// the namespace and name of the DynaKube or EdgeConnect the requests are sent for.
+// defaultTokenType is used for requests that set neither an Authorization header nor a token type via WithTokenType.
+func NewInstrumentedTransport(next http.RoundTripper, client, namespace, name, defaultTokenType string, resolve EndpointResolver) *InstrumentedTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if resolve == nil {
+		resolve = func(string) string { return OtherEndpoint }
+	}
+
+	return &InstrumentedTransport{
+		next:             next,
+		resolve:          resolve,
+		client:           client,
+		namespace:        namespace,
+		name:             name,
+		defaultTokenType: defaultTokenType,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (transport *InstrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := transport.resolve(req.URL.Path)
+
+	_, span := tracing.Start(req.Context(), "HTTP "+req.Method+" "+endpoint,
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLTemplate(endpoint),
+		semconv.ServerAddress(req.URL.Hostname()),
+	)
+
+	start := time.Now()
+	resp, err := transport.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+
+	tracing.End(span, err)
+
+	labels := prometheus.Labels{
+		"client":       transport.client,
+		"endpoint":     endpoint,
+		"method":       req.Method,
+		"status_class": statusClass(resp, err),
+		"namespace":    transport.namespace,
+		"name":         transport.name,
+		"token_type":   transport.tokenType(req),
+	}
+
+	apiRequestsMetric.With(labels).Inc()
+	apiRequestDurationMetric.With(labels).Observe(duration.Seconds())
+
+	return resp, err
+}
+
+func (transport *InstrumentedTransport) tokenType(req *http.Request) string {
+	if tokenType, ok := req.Context().Value(tokenTypeKey{}).(string); ok && tokenType != "" {
+		return tokenType
+	}
+
+	scheme, _, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+
+	switch {
+	case strings.EqualFold(scheme, "Api-Token"):
+		return TokenTypeAPI
+	case strings.EqualFold(scheme, "Bearer"):
+		return TokenTypeOAuth
+	case transport.defaultTokenType != "":
+		return transport.defaultTokenType
+	}
+
+	return TokenTypeNone
+}
+
+// statusClass returns the class of the response status (e.g. "2xx"), or "error" if no response was received.
+func statusClass(resp *http.Response, err error) string {
+	if err != nil || resp == nil {
+		return statusClassError
+	}
+
+	return strconv.Itoa(resp.StatusCode/100) + "xx"
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointResolver(t *testing.T) {
+	resolve := NewEndpointResolver(
+		"/v1/deployment/installer/agent/connectioninfo",
+		"/v1/deployment/installer/agent/{os}/{installerType}/latest",
+		"/v2/settings/objects/{objectId}",
+	)
+
+	assert.Equal(t, "/v1/deployment/installer/agent/connectioninfo", resolve("/api/v1/deployment/installer/agent/connectioninfo"))
+	assert.Equal(t, "/v1/deployment/installer/agent/connectioninfo", resolve("/e/tenant/api/v1/deployment/installer/agent/connectioninfo"))
+	assert.Equal(t, "/v1/deployment/installer/agent/{os}/{installerType}/latest", resolve("/api/v1/deployment/installer/agent/unix/default/latest"))
+	assert.Equal(t, "/v2/settings/objects/{objectId}", resolve("/api/v2/settings/objects/abc-123"))
+	assert.Equal(t, OtherEndpoint, resolve("/api/v2/settings/objects"))
+	assert.Equal(t, OtherEndpoint, resolve("/installer/download"))
+	assert.Equal(t, OtherEndpoint, resolve(""))
+}
+
+func TestInstrumentedTransport(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolve := NewEndpointResolver("/v2/entities", "/v2/missing")
+
+	labels := func(endpoint, statusClass, name, tokenType string) prometheus.Labels {
+		return prometheus.Labels{
+			"client":       "test",
+			"endpoint":     endpoint,
+			"method":       http.MethodGet,
+			"status_class": statusClass,
+			"namespace":    "dynatrace",
+			"name":         name,
+			"token_type":   tokenType,
+		}
+	}
+
+	requestCount := func(endpoint, statusClass, name, tokenType string) float64 {
+		return testutil.ToFloat64(apiRequestsMetric.With(labels(endpoint, statusClass, name, tokenType)))
+	}
+
+	send := func(t *testing.T, transport http.RoundTripper, ctx context.Context, path, authorization string) {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		resp, err := (&http.Client{Transport: transport}).Do(req)
+		if err == nil {
+			DiscardBody(resp)
+		}
+	}
+
+	t.Run("labels requests by endpoint, status class and token type", func(t *testing.T) {
+		transport := NewInstrumentedTransport(server.Client().Transport, "test", "dynatrace", "dynakube-a", "", resolve)
+
+		send(t, transport, ctx, "/api/v2/entities?entitySelector=abc", "Api-Token secret")
+		send(t, transport, ctx, "/api/v2/entities", "Bearer secret")
+		send(t, transport, WithTokenType(ctx, TokenTypePaaS), "/api/v2/missing", "Api-Token secret")
+		send(t, transport, ctx, "/download/agent.zip", "")
+
+		assert.InDelta(t, 1, requestCount("/v2/entities", "2xx", "dynakube-a", TokenTypeAPI), 0)
+		assert.InDelta(t, 1, requestCount("/v2/entities", "2xx", "dynakube-a", TokenTypeOAuth), 0)
+		assert.InDelta(t, 1, requestCount("/v2/missing", "4xx", "dynakube-a", TokenTypePaaS), 0)
+		assert.InDelta(t, 1, requestCount(OtherEndpoint, "2xx", "dynakube-a", TokenTypeNone), 0)
+
+		histogram := &dto.Metric{}
+		require.NoError(t, apiRequestDurationMetric.With(labels("/v2/entities", "2xx", "dynakube-a", TokenTypeAPI)).(prometheus.Metric).Write(histogram))
+		assert.Equal(t, uint64(1), histogram.GetHistogram().GetSampleCount())
+	})
+	t.Run("uses default token type and counts transport errors", func(t *testing.T) {
+		transport := NewInstrumentedTransport(server.Client().Transport, "test", "dynatrace", "dynakube-b", TokenTypeOAuth, resolve)
+
+		send(t, transport, ctx, "/api/v2/entities", "")
+
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		send(t, transport, canceledCtx, "/api/v2/entities", "")
+
+		assert.InDelta(t, 1, requestCount("/v2/entities", "2xx", "dynakube-b", TokenTypeOAuth), 0)
+		assert.InDelta(t, 1, requestCount("/v2/entities", statusClassError, "dynakube-b", TokenTypeOAuth), 0)
+	})
+}
@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+	defaultJitter         = 0.2
+
+	RetryAfterHeader = "Retry-After"
+)
+
+// RetryPolicy configures how requests to Dynatrace APIs are retried when the server is throttling or temporarily unavailable.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts after the first request failed.
+	MaxRetries int
+
+	// InitialBackoff is the wait time before the first retry, it is doubled for every further retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait time between two attempts.
+	// A Retry-After header asking for a longer wait stops retrying and returns the response as is.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (between 0 and 1) by which each backoff is randomly shortened.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by the operator when talking to Dynatrace APIs.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     defaultMaxRetries,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		Jitter:         defaultJitter,
+	}
+}
+
+type idempotentRequestKey struct{}
+
+// WithIdempotentRequest marks requests created with the returned context as safe to retry, regardless of their method.
+// Meant for read-only POST endpoints, like the token lookup.
+func WithIdempotentRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentRequestKey{}, true)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	idempotent, _ := req.Context().Value(idempotentRequestKey{}).(bool)
+
+	return idempotent
+}
+
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// the request might have reached the server before the connection broke
+		return req.Context().Err() == nil && isIdempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// throttled requests were not processed by the server
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+
+	return false
+}
+
+// DoWithRetry sends the request using send and retries throttled (429) and failed (5xx) requests according to the policy.
+// Non-idempotent requests (e.g. POST requests creating objects) are only retried if the server rejected them with 429.
+// Retries never outlive the context of the request.
+func DoWithRetry(log logd.Logger, policy RetryPolicy, req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	resp, err := send(req)
+
+	for attempt := 0; attempt < policy.MaxRetries && isRetryable(req, resp, err); attempt++ {
+		wait, ok := policy.backoff(attempt, resp)
+		if !ok || !fitsIntoDeadline(req.Context(), wait) {
+			break
+		}
+
+		next, rewindErr := rewind(req)
+		if rewindErr != nil {
+			break
+		}
+
+		log.Info("retrying request", "url", req.URL.Redacted(), "attempt", attempt+1, "backoff", wait.String(), "cause", retryCause(resp, err))
+
+		DiscardBody(resp)
+
+		if waitErr := sleep(req.Context(), wait); waitErr != nil {
+			return nil, errors.WithMessage(waitErr, "aborted retrying request")
+		}
+
+		req = next
+		resp, err = send(req)
+	}
+
+	return resp, err
+}
+
+// backoff returns the time to wait before the given retry attempt (starting at 0).
+// Returns false if the server asked to wait longer than MaxBackoff.
+func (policy RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if retryAfter, ok := parseRetryAfter(resp); ok {
+		return retryAfter, policy.MaxBackoff <= 0 || retryAfter <= policy.MaxBackoff
+	}
+
+	wait := float64(policy.InitialBackoff) * math.Pow(2, float64(attempt))
+	if policy.MaxBackoff > 0 {
+		wait = math.Min(wait, float64(policy.MaxBackoff))
+	}
+
+	jitter := math.Min(math.Max(policy.Jitter, 0), 1)
+	wait -= wait * jitter * rand.Float64() //nolint:gosec
+
+	return time.Duration(wait), true
+}
+
+// parseRetryAfter reads the Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get(RetryAfterHeader)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func fitsIntoDeadline(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+
+	return !ok || time.Until(deadline) > wait
+}
+
+// rewind creates a copy of the request that can be sent again, as the body of the original one was already consumed.
+func rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errors.New("request body can not be rewound")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	next.Body = body
+
+	return next, nil
+}
+
+// DiscardBody reads the remaining body of the response and closes it, so the connection can be reused.
+func DiscardBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+	}
+
+	CloseBodyAfterRequest(resp)
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func retryCause(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	return resp.Status
+}
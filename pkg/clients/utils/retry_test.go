@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoWithRetry(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond}
+
+	setup := func(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+		calls := &atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			writer.WriteHeader(status)
+		}))
+		t.Cleanup(server.Close)
+
+		return server, calls
+	}
+
+	t.Run("retries idempotent requests until max retries", func(t *testing.T) {
+		server, calls := setup(t, http.StatusServiceUnavailable)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := DoWithRetry(logd.Get(), policy, req, server.Client().Do)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+	t.Run("does not retry POST on server error", func(t *testing.T) {
+		server, calls := setup(t, http.StatusBadGateway)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		resp, err := DoWithRetry(logd.Get(), policy, req, server.Client().Do)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("retries POST marked as idempotent", func(t *testing.T) {
+		server, calls := setup(t, http.StatusBadGateway)
+
+		req, err := http.NewRequestWithContext(WithIdempotentRequest(ctx), http.MethodPost, server.URL, strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		resp, err := DoWithRetry(logd.Get(), policy, req, server.Client().Do)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, int32(3), calls.Load())
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	}
+
+	t.Run("doubles until max backoff", func(t *testing.T) {
+		expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+		for attempt, wait := range expected {
+			actual, ok := policy.backoff(attempt, nil)
+			require.True(t, ok)
+			assert.Equal(t, wait, actual)
+		}
+	})
+	t.Run("jitter shortens backoff", func(t *testing.T) {
+		jittered := policy
+		jittered.Jitter = 0.5
+
+		actual, ok := jittered.backoff(0, nil)
+		require.True(t, ok)
+		assert.LessOrEqual(t, actual, time.Second)
+		assert.GreaterOrEqual(t, actual, 500*time.Millisecond)
+	})
+	t.Run("honours Retry-After in seconds", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{RetryAfterHeader: []string{"3"}}}
+
+		actual, ok := policy.backoff(0, resp)
+		require.True(t, ok)
+		assert.Equal(t, 3*time.Second, actual)
+	})
+	t.Run("honours Retry-After as date", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{RetryAfterHeader: []string{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}}}
+
+		actual, ok := policy.backoff(0, resp)
+		require.True(t, ok)
+		assert.Equal(t, time.Duration(0), actual)
+	})
+}
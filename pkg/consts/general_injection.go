@@ -11,4 +11,6 @@ const (
 	K8sBasePodNameEnv = "K8S_BASEPODNAME"
 	K8sNamespaceEnv   = "K8S_NAMESPACE"
 	K8sClusterIDEnv   = "K8S_CLUSTER_ID"
+
+	InjectionNetworkPolicyName = "dynatrace-injection-egress"
 )
@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"os"
+	"strconv"
+)
+
+const defaultMaxConcurrentReconciles = 1
+
+// MaxConcurrentReconciles returns how many requests a controller may reconcile in parallel, read from the given env.
+// Falls back to a single worker if the env is not set or not a positive number.
+func MaxConcurrentReconciles(envName string) int {
+	value := os.Getenv(envName)
+	if value == "" {
+		return defaultMaxConcurrentReconciles
+	}
+
+	maxConcurrentReconciles, err := strconv.Atoi(value)
+	if err != nil || maxConcurrentReconciles < 1 {
+		log.Info("invalid number of concurrent reconciles in env, using default", "env", envName, "value", value, "default", defaultMaxConcurrentReconciles)
+
+		return defaultMaxConcurrentReconciles
+	}
+
+	return maxConcurrentReconciles
+}
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxConcurrentReconciles(t *testing.T) {
+	const testEnv = "DT_TEST_MAX_CONCURRENT_RECONCILES"
+
+	tests := map[string]int{
+		"":    defaultMaxConcurrentReconciles,
+		"4":   4,
+		"0":   defaultMaxConcurrentReconciles,
+		"-2":  defaultMaxConcurrentReconciles,
+		"abc": defaultMaxConcurrentReconciles,
+	}
+
+	for value, expected := range tests {
+		t.Run("env="+value, func(t *testing.T) {
+			t.Setenv(testEnv, value)
+
+			assert.Equal(t, expected, MaxConcurrentReconciles(testEnv))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package controllers
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+var (
+	log = logd.Get().WithName("controllers")
+)
@@ -3,6 +3,7 @@ package cleanup
 import (
 	"context"
 	"os"
+	"sync"
 
 	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
@@ -11,6 +12,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// runMutex serializes the cleanups, as the provisioner may reconcile several DynaKubes in parallel and the ticker is shared.
+var runMutex sync.Mutex
+
 type Cleaner struct {
 	fs        afero.Afero
 	apiReader client.Reader
@@ -39,6 +43,9 @@ func New(fs afero.Afero, apiReader client.Reader, path metadata.PathResolver, mo
 
 // Run will only execute the cleanup logic if enough time has passed from the previous run, to not overload the IO of the node
 func (c *Cleaner) Run(ctx context.Context) error {
+	runMutex.Lock()
+	defer runMutex.Unlock()
+
 	tickerResetFunc := checkTicker()
 	if tickerResetFunc == nil {
 		return nil
@@ -50,6 +57,9 @@ func (c *Cleaner) Run(ctx context.Context) error {
 
 // InstantRun will always execute the cleanup logic ignoring the time passed from previous run
 func (c *Cleaner) InstantRun(ctx context.Context) error {
+	runMutex.Lock()
+	defer runMutex.Unlock()
+
 	defer resetTickerAfterDelete()
 
 	return c.run(ctx)
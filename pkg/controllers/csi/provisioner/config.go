@@ -4,6 +4,11 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 )
 
+const (
+	// MaxConcurrentReconcilesEnv sets how many DynaKubes the provisioner of a node handles in parallel, defaults to 1.
+	MaxConcurrentReconcilesEnv = "DT_CSI_PROVISIONER_MAX_CONCURRENT_RECONCILES"
+)
+
 var (
 	log = logd.Get().WithName("csi-provisioner")
 )
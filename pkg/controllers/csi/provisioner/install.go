@@ -33,6 +33,9 @@ func (provisioner *OneAgentProvisioner) installAgent(ctx context.Context, dk dyn
 
 	targetDir := provisioner.getTargetDir(dk)
 
+	unlock := installLocks.lock(targetDir)
+	defer unlock()
+
 	ready, err := agentInstaller.InstallAgent(ctx, targetDir)
 	if err != nil {
 		return err
@@ -0,0 +1,21 @@
+package csiprovisioner
+
+import "sync"
+
+// installLocks serializes the installations into the same directory, as DynaKubes using the same CodeModules share it.
+var installLocks dirLocks
+
+// dirLocks holds a mutex per directory, the zero value is ready to use.
+type dirLocks struct {
+	locks sync.Map
+}
+
+// lock blocks until no other installation into dir is running, the returned func releases the lock.
+func (dl *dirLocks) lock(dir string) func() {
+	value, _ := dl.locks.LoadOrStore(dir, &sync.Mutex{})
+	mutex := value.(*sync.Mutex) //nolint:forcetypeassert
+
+	mutex.Lock()
+
+	return mutex.Unlock
+}
@@ -2,11 +2,13 @@ package capability
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	"k8s.io/utils/net"
 	"k8s.io/utils/ptr"
 )
@@ -25,11 +27,15 @@ type Capability interface {
 	Enabled() bool
 	ArgName() string
 	Properties() *activegate.CapabilityProperties
+	GroupName() string
+	HasCapability(displayName activegate.CapabilityDisplayName) bool
 }
 
 type capabilityBase struct {
-	properties *activegate.CapabilityProperties
-	argName    string
+	properties   *activegate.CapabilityProperties
+	argName      string
+	groupName    string
+	capabilities []activegate.CapabilityDisplayName
 }
 
 func (capability *capabilityBase) Enabled() bool {
@@ -44,10 +50,50 @@ func (capability *capabilityBase) ArgName() string {
 	return capability.argName
 }
 
+// GroupName returns the name of the ActiveGate group, which is empty for the default ActiveGate.
+func (capability *capabilityBase) GroupName() string {
+	return capability.groupName
+}
+
+func (capability *capabilityBase) HasCapability(displayName activegate.CapabilityDisplayName) bool {
+	return slices.Contains(capability.capabilities, displayName)
+}
+
 func CalculateStatefulSetName(dynakubeName string) string {
 	return dynakubeName + "-" + consts.MultiActiveGateName
 }
 
+// BuildComponentName returns the value of the component label for the pods of the given ActiveGate group, the default group has an empty name.
+func BuildComponentName(groupName string) string {
+	if groupName == "" {
+		return consts.MultiActiveGateName
+	}
+
+	return consts.MultiActiveGateName + "-" + groupName
+}
+
+// CalculateGroupStatefulSetName returns the name of the StatefulSet of the given ActiveGate group, the default group has an empty name.
+func CalculateGroupStatefulSetName(dynakubeName, groupName string) string {
+	if groupName == "" {
+		return CalculateStatefulSetName(dynakubeName)
+	}
+
+	return CalculateStatefulSetName(dynakubeName) + "-" + groupName
+}
+
+// BuildSelectorLabels returns the labels selecting the pods of the given ActiveGate group, the default group has an empty name.
+// The component is only included if ActiveGate groups are defined, so the pods of the groups aren't selected for each other.
+func BuildSelectorLabels(dk *dynakube.DynaKube, groupName string) map[string]string {
+	appLabels := labels.NewAppLabels(labels.ActiveGateComponentLabel, dk.Name, "", "")
+	selectorLabels := appLabels.BuildMatchLabels()
+
+	if len(dk.Spec.ActiveGate.Groups) > 0 {
+		selectorLabels[labels.AppComponentLabel] = BuildComponentName(groupName)
+	}
+
+	return selectorLabels
+}
+
 type MultiCapability struct {
 	capabilityBase
 }
@@ -56,26 +102,18 @@ func NewMultiCapability(dk *dynakube.DynaKube) Capability {
 	mc := MultiCapability{
 		capabilityBase{},
 	}
-	if dk == nil || !dk.ActiveGate().IsEnabled() {
+	if dk == nil || !dk.ActiveGate().IsDefaultEnabled() {
 		return &mc
 	}
 
 	mc.properties = &dk.Spec.ActiveGate.CapabilityProperties
+	mc.capabilities = dk.Spec.ActiveGate.Capabilities
 
 	if len(dk.Spec.ActiveGate.Capabilities) == 0 && dk.IsExtensionsEnabled() {
 		mc.properties.Replicas = ptr.To(int32(1))
 	}
 
-	capabilityArgs := []string{}
-
-	for _, capName := range dk.Spec.ActiveGate.Capabilities {
-		argName, ok := activeGateCapabilities[capName]
-		if !ok {
-			continue
-		}
-
-		capabilityArgs = append(capabilityArgs, argName)
-	}
+	capabilityArgs := buildCapabilityArgs(dk.Spec.ActiveGate.Capabilities)
 
 	if dk.IsExtensionsEnabled() {
 		capabilityArgs = append(capabilityArgs, "extension_controller")
@@ -90,16 +128,87 @@ func NewMultiCapability(dk *dynakube.DynaKube) Capability {
 	return &mc
 }
 
+type GroupCapability struct {
+	capabilityBase
+}
+
+// NewGroupCapability creates the capability of an additional ActiveGate group.
+// The properties are taken from the group, except the custom properties, which are shared with the default ActiveGate.
+func NewGroupCapability(dk *dynakube.DynaKube, group activegate.GroupSpec) Capability {
+	gc := GroupCapability{
+		capabilityBase{
+			groupName:    group.Name,
+			capabilities: group.Capabilities,
+		},
+	}
+
+	gc.properties = &activegate.CapabilityProperties{
+		CustomProperties:          dk.Spec.ActiveGate.CustomProperties,
+		NodeSelector:              group.NodeSelector,
+		Labels:                    group.Labels,
+		Replicas:                  group.Replicas,
+		Image:                     dk.Spec.ActiveGate.Image,
+		Group:                     group.Group,
+		Resources:                 group.Resources,
+		Tolerations:               group.Tolerations,
+		Env:                       group.Env,
+		TopologySpreadConstraints: group.TopologySpreadConstraints,
+	}
+	gc.argName = strings.Join(buildCapabilityArgs(group.Capabilities), ",")
+
+	return &gc
+}
+
+// NewGroupCapabilities creates the capabilities of all additional ActiveGate groups of the DynaKube.
+func NewGroupCapabilities(dk *dynakube.DynaKube) []Capability {
+	groupCapabilities := make([]Capability, 0, len(dk.Spec.ActiveGate.Groups))
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		groupCapabilities = append(groupCapabilities, NewGroupCapability(dk, group))
+	}
+
+	return groupCapabilities
+}
+
+func buildCapabilityArgs(capabilities []activegate.CapabilityDisplayName) []string {
+	capabilityArgs := []string{}
+
+	for _, capName := range capabilities {
+		argName, ok := activeGateCapabilities[capName]
+		if !ok {
+			continue
+		}
+
+		capabilityArgs = append(capabilityArgs, argName)
+	}
+
+	return capabilityArgs
+}
+
 func BuildServiceName(dynakubeName string) string {
 	return dynakubeName + "-" + consts.MultiActiveGateName
 }
 
-// BuilDNSEntryPoint will create a string listing of the full DNS entry points for the Service of the ActiveGate in the provided DynaKube
+// BuildGroupServiceName returns the name of the Service of the given ActiveGate group, the default group has an empty name.
+func BuildGroupServiceName(dynakubeName, groupName string) string {
+	if groupName == "" {
+		return BuildServiceName(dynakubeName)
+	}
+
+	return BuildServiceName(dynakubeName) + "-" + groupName
+}
+
+// BuildCapabilityServiceName returns the name of the Service of the ActiveGate group, which has the given capability enabled.
+func BuildCapabilityServiceName(dk dynakube.DynaKube, displayName activegate.CapabilityDisplayName) string {
+	return BuildGroupServiceName(dk.Name, dk.ActiveGate().GetCapabilityGroupName(displayName))
+}
+
+// BuilDNSEntryPoint will create a string listing of the full DNS entry points for the Service of the given ActiveGate group in the provided DynaKube
 // example: https://34.118.233.238:443,https://dynakube-activegate.dynatrace:443
-func BuildDNSEntryPoint(dk dynakube.DynaKube) string {
+func BuildDNSEntryPoint(dk dynakube.DynaKube, groupName string) string {
 	entries := []string{}
 
-	for _, ip := range dk.Status.ActiveGate.ServiceIPs {
+	for _, ip := range dk.Status.ActiveGate.GetServiceIPs(groupName) {
 		if net.IsIPv6String(ip) {
 			ip = "[" + ip + "]"
 		}
@@ -108,8 +217,8 @@ func BuildDNSEntryPoint(dk dynakube.DynaKube) string {
 		entries = append(entries, serviceHostEntry)
 	}
 
-	if dk.ActiveGate().IsRoutingEnabled() {
-		serviceDomain := buildServiceDomainName(dk.Name, dk.Namespace)
+	if isRoutingGroup(dk, groupName) {
+		serviceDomain := buildServiceDomainName(BuildGroupServiceName(dk.Name, groupName), dk.Namespace)
 		serviceDomainEntry := buildDNSEntry(serviceDomain)
 		entries = append(entries, serviceDomainEntry)
 	}
@@ -117,13 +226,18 @@ func BuildDNSEntryPoint(dk dynakube.DynaKube) string {
 	return strings.Join(entries, ",")
 }
 
-// BuildHostEntries will create a string listing the host entries for the Service of the ActiveGate in the provided DynaKube
+// BuildHostEntries will create a string listing the host entries for the Services of the ActiveGates in the provided DynaKube
 // Meant to be used as a NO_PROXY value for components needing to directly communicate with the ActiveGate.
 // example: 34.118.233.238,dynakube-activegate.dynatrace
 func BuildHostEntries(dk dynakube.DynaKube) string {
 	entries := []string{}
 
-	for _, ip := range dk.Status.ActiveGate.ServiceIPs {
+	serviceIPs := slices.Clone(dk.Status.ActiveGate.ServiceIPs)
+	for _, group := range dk.Status.ActiveGate.Groups {
+		serviceIPs = append(serviceIPs, group.ServiceIPs...)
+	}
+
+	for _, ip := range serviceIPs {
 		if net.IsIPv6String(ip) {
 			ip = "[" + ip + "]"
 		}
@@ -132,18 +246,22 @@ func BuildHostEntries(dk dynakube.DynaKube) string {
 	}
 
 	if dk.ActiveGate().IsRoutingEnabled() {
-		entries = append(entries, fmt.Sprintf("%s.%s", BuildServiceName(dk.Name), dk.Namespace))
+		entries = append(entries, fmt.Sprintf("%s.%s", BuildCapabilityServiceName(dk, activegate.RoutingCapability.DisplayName), dk.Namespace))
 	}
 
 	return strings.Join(entries, ",")
 }
 
+func isRoutingGroup(dk dynakube.DynaKube, groupName string) bool {
+	return dk.ActiveGate().IsRoutingEnabled() && dk.ActiveGate().GetCapabilityGroupName(activegate.RoutingCapability.DisplayName) == groupName
+}
+
 func buildServiceHostName(host string) string {
 	return fmt.Sprintf("%s:%d", host, consts.HTTPSServicePort)
 }
 
-func buildServiceDomainName(dynakubeName string, namespaceName string) string {
-	return fmt.Sprintf("%s.%s:%d", BuildServiceName(dynakubeName), namespaceName, consts.HTTPSServicePort)
+func buildServiceDomainName(serviceName string, namespaceName string) string {
+	return fmt.Sprintf("%s.%s:%d", serviceName, namespaceName, consts.HTTPSServicePort)
 }
 
 func buildDNSEntry(host string) string {
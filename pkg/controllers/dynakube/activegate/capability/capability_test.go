@@ -141,7 +141,7 @@ func TestNewMultiCapabilityWithDebugging(t *testing.T) {
 }
 
 func TestBuildServiceDomainNameForDNSEntryPoint(t *testing.T) {
-	actual := buildServiceDomainName("test-name", "test-namespace")
+	actual := buildServiceDomainName(BuildServiceName("test-name"), "test-namespace")
 	assert.NotEmpty(t, actual)
 
 	expected := "test-name-activegate.test-namespace:443"
@@ -150,7 +150,7 @@ func TestBuildServiceDomainNameForDNSEntryPoint(t *testing.T) {
 	testStringName := "this---dynakube_string"
 	testNamespace := "this_is---namespace_string"
 	expected = "this---dynakube_string-activegate.this_is---namespace_string:443"
-	actual = buildServiceDomainName(testStringName, testNamespace)
+	actual = buildServiceDomainName(BuildServiceName(testStringName), testNamespace)
 	assert.Equal(t, expected, actual)
 }
 
@@ -322,8 +322,93 @@ func TestBuildDNSEntryPoint(t *testing.T) {
 	}
 	for _, test := range testCases {
 		t.Run(test.title, func(t *testing.T) {
-			dnsEntryPoint := BuildDNSEntryPoint(*test.dk)
+			dnsEntryPoint := BuildDNSEntryPoint(*test.dk, "")
 			assert.Equal(t, test.expectedDNS, dnsEntryPoint)
 		})
 	}
 }
+
+func TestNewGroupCapability(t *testing.T) {
+	t.Run(`creates capability of ActiveGate group`, func(t *testing.T) {
+		dk := buildDynakube(capabilities[:1], true, true)
+		group := activegate.GroupSpec{
+			Name:         "kubemon",
+			Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName, activegate.DynatraceAPICapability.DisplayName},
+			Group:        "kubemon-group",
+		}
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{group}
+
+		groupCapabilities := NewGroupCapabilities(dk)
+		require.Len(t, groupCapabilities, 1)
+
+		gc := groupCapabilities[0]
+		assert.True(t, gc.Enabled())
+		assert.Equal(t, "kubemon", gc.GroupName())
+		assert.Equal(t, "kubernetes_monitoring,restInterface", gc.ArgName())
+		assert.Equal(t, "kubemon-group", gc.Properties().Group)
+		assert.True(t, gc.HasCapability(activegate.KubeMonCapability.DisplayName))
+		assert.False(t, gc.HasCapability(activegate.RoutingCapability.DisplayName))
+
+		mc := NewMultiCapability(dk)
+		assert.Empty(t, mc.GroupName())
+		assert.True(t, mc.HasCapability(activegate.RoutingCapability.DisplayName))
+		assert.False(t, mc.HasCapability(activegate.KubeMonCapability.DisplayName))
+	})
+	t.Run(`only groups => default ActiveGate disabled`, func(t *testing.T) {
+		dk := buildDynakube(nil, false, true)
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+		}
+
+		assert.False(t, NewMultiCapability(dk).Enabled())
+		assert.True(t, NewGroupCapabilities(dk)[0].Enabled())
+	})
+}
+
+func TestGroupNames(t *testing.T) {
+	assert.Equal(t, "dynakube-activegate", CalculateGroupStatefulSetName("dynakube", ""))
+	assert.Equal(t, "dynakube-activegate-routing", CalculateGroupStatefulSetName("dynakube", "routing"))
+	assert.Equal(t, "dynakube-activegate", BuildGroupServiceName("dynakube", ""))
+	assert.Equal(t, "dynakube-activegate-routing", BuildGroupServiceName("dynakube", "routing"))
+	assert.Equal(t, "activegate", BuildComponentName(""))
+	assert.Equal(t, "activegate-routing", BuildComponentName("routing"))
+}
+
+func TestGroupEntryPoints(t *testing.T) {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dynakube",
+			Namespace: "dynatrace",
+		},
+		Spec: dynakube.DynaKubeSpec{
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+				Groups: []activegate.GroupSpec{
+					{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+				},
+			},
+		},
+		Status: dynakube.DynaKubeStatus{
+			ActiveGate: activegate.Status{
+				ServiceIPs: []string{"1.2.3.4"},
+				Groups: []activegate.GroupStatus{
+					{Name: "routing", ServiceIPs: []string{"5.6.7.8"}},
+				},
+			},
+		},
+	}
+
+	t.Run(`DNSEntryPoint of default ActiveGate doesn't contain routing group`, func(t *testing.T) {
+		assert.Equal(t, "https://1.2.3.4:443/communication", BuildDNSEntryPoint(*dk, ""))
+	})
+	t.Run(`DNSEntryPoint of routing group`, func(t *testing.T) {
+		assert.Equal(t, "https://5.6.7.8:443/communication,https://dynakube-activegate-routing.dynatrace:443/communication", BuildDNSEntryPoint(*dk, "routing"))
+	})
+	t.Run(`host entries contain all groups`, func(t *testing.T) {
+		assert.Equal(t, "1.2.3.4,5.6.7.8,dynakube-activegate-routing.dynatrace", BuildHostEntries(*dk))
+	})
+	t.Run(`service of capability`, func(t *testing.T) {
+		assert.Equal(t, "dynakube-activegate-routing", BuildCapabilityServiceName(*dk, activegate.RoutingCapability.DisplayName))
+		assert.Equal(t, "dynakube-activegate", BuildCapabilityServiceName(*dk, activegate.KubeMonCapability.DisplayName))
+	})
+}
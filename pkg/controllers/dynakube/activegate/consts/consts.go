@@ -19,6 +19,14 @@ const (
 	AuthTokenSecretVolumeName = "ag-authtoken-secret"
 	AuthTokenMountPoint       = connectioninfo.TokenBasePath + "/auth-token"
 
+	TrustStoreVolumeName        = "truststore-volume"
+	CertLoaderWorkDirVolumeName = "cert-tmp"
+	KSPMTokenVolumeName         = "kspm-token"
+	EECTokenVolumeName          = "eec-token"
+	CustomPropertiesVolumeName  = "custom-properties"
+	TLSCertsVolumeName          = "server-certs"
+	TrustedCAsVolumeName        = "trustedcas"
+
 	EnvDtCapabilities    = "DT_CAPABILITIES"
 	EnvDtIDSeedNamespace = "DT_ID_SEED_NAMESPACE"
 	EnvDtIDSeedClusterID = "DT_ID_SEED_K8S_CLUSTER_ID"
@@ -8,6 +8,15 @@ import (
 
 const ActiveGateAuthTokenSecretConditionType string = "ActiveGateAuthTokenSecret"
 
+// GetConditionType returns the condition type of the auth token secret of the given ActiveGate group, the default group has an empty name.
+func GetConditionType(groupName string) string {
+	if groupName == "" {
+		return ActiveGateAuthTokenSecretConditionType
+	}
+
+	return ActiveGateAuthTokenSecretConditionType + "-" + groupName
+}
+
 func setAuthSecretCreated(conditions *[]metav1.Condition, conditionType string, msg string) {
 	condition := metav1.Condition{
 		Type:    conditionType,
@@ -10,6 +10,7 @@ import (
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	k8slabels "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	k8ssecret "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -21,6 +22,8 @@ import (
 const (
 	ActiveGateAuthTokenName = "auth-token"
 
+	groupAuthTokenSecretSuffix = "-authtoken-secret"
+
 	// Buffer to avoid warnings in the UI
 	AuthTokenBuffer           = time.Hour * 24
 	AuthTokenRotationInterval = time.Hour*24*30 - AuthTokenBuffer
@@ -46,70 +49,138 @@ func NewReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.Dyna
 
 func (r *Reconciler) Reconcile(ctx context.Context) error {
 	if !r.dk.ActiveGate().IsEnabled() {
-		if meta.FindStatusCondition(*r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType) == nil {
+		r.deleteDefaultSecret(ctx)
+
+		if !r.hasGroupConditions() {
 			return nil
 		}
 
-		defer meta.RemoveStatusCondition(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType)
+		return r.deleteStaleGroupSecrets(ctx)
+	}
+
+	if r.dk.ActiveGate().IsDefaultEnabled() {
+		err := r.reconcileAuthTokenSecret(ctx, "")
+		if err != nil {
+			return errors.WithMessage(err, "failed to create activeGateAuthToken secret")
+		}
+	} else {
+		r.deleteDefaultSecret(ctx)
+	}
+
+	for _, group := range r.dk.Spec.ActiveGate.Groups {
+		err := r.reconcileAuthTokenSecret(ctx, group.Name)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to create activeGateAuthToken secret for group '%s'", group.Name)
+		}
+	}
+
+	return r.deleteStaleGroupSecrets(ctx)
+}
+
+func (r *Reconciler) deleteDefaultSecret(ctx context.Context) {
+	if meta.FindStatusCondition(*r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType) == nil {
+		return
+	}
 
-		secret, _ := k8ssecret.Build(r.dk, r.dk.ActiveGate().GetAuthTokenSecretName(), nil)
-		_ = r.deleteSecret(ctx, secret)
+	defer meta.RemoveStatusCondition(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType)
+
+	secret, _ := k8ssecret.Build(r.dk, r.dk.ActiveGate().GetAuthTokenSecretName(), nil)
+	_ = r.deleteSecret(ctx, "", secret)
+}
 
-		return nil
+func (r *Reconciler) hasGroupConditions() bool {
+	for _, condition := range *r.dk.Conditions() {
+		if strings.HasPrefix(condition.Type, ActiveGateAuthTokenSecretConditionType+"-") {
+			return true
+		}
 	}
 
-	err := r.reconcileAuthTokenSecret(ctx)
+	return false
+}
+
+// deleteStaleGroupSecrets deletes the secrets of the ActiveGate groups, which were removed from the DynaKube.
+func (r *Reconciler) deleteStaleGroupSecrets(ctx context.Context) error {
+	var secrets corev1.SecretList
+
+	err := r.apiReader.List(ctx, &secrets, client.InNamespace(r.dk.Namespace), client.MatchingLabels(buildSecretLabels(r.dk.Name)))
 	if err != nil {
-		return errors.WithMessage(err, "failed to create activeGateAuthToken secret")
+		return errors.WithStack(err)
+	}
+
+	desiredSecrets := map[string]bool{}
+	for _, group := range r.dk.Spec.ActiveGate.Groups {
+		desiredSecrets[r.dk.ActiveGate().GetGroupAuthTokenSecretName(group.Name)] = true
+	}
+
+	desiredSecrets[r.dk.ActiveGate().GetAuthTokenSecretName()] = true
+	groupSecretPrefix := r.dk.Name + "-activegate-"
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if desiredSecrets[secret.Name] || !strings.HasPrefix(secret.Name, groupSecretPrefix) || !strings.HasSuffix(secret.Name, groupAuthTokenSecretSuffix) {
+			continue
+		}
+
+		groupName := strings.TrimSuffix(strings.TrimPrefix(secret.Name, groupSecretPrefix), groupAuthTokenSecretSuffix)
+
+		log.Info("deleting activeGateAuthToken secret of removed group", "group", groupName)
+
+		if err := r.deleteSecret(ctx, groupName, secret); err != nil {
+			return errors.WithStack(err)
+		}
+
+		meta.RemoveStatusCondition(r.dk.Conditions(), GetConditionType(groupName))
 	}
 
 	return nil
 }
 
-func (r *Reconciler) reconcileAuthTokenSecret(ctx context.Context) error {
-	secret, err := r.secretQuery().Get(ctx, client.ObjectKey{Name: r.dk.ActiveGate().GetAuthTokenSecretName(), Namespace: r.dk.Namespace})
+func (r *Reconciler) reconcileAuthTokenSecret(ctx context.Context, groupName string) error {
+	conditionType := GetConditionType(groupName)
+
+	secret, err := r.secretQuery().Get(ctx, client.ObjectKey{Name: r.dk.ActiveGate().GetGroupAuthTokenSecretName(groupName), Namespace: r.dk.Namespace})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			log.Info("creating activeGateAuthToken secret")
+			log.Info("creating activeGateAuthToken secret", "group", groupName)
 
-			return r.ensureAuthTokenSecret(ctx)
+			return r.ensureAuthTokenSecret(ctx, groupName)
 		}
 
-		conditions.SetKubeAPIError(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType, err)
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
 
 		return errors.WithStack(err)
 	}
 
 	if isSecretOutdated(secret) {
-		log.Info("activeGateAuthToken is outdated, creating new one")
+		log.Info("activeGateAuthToken is outdated, creating new one", "group", groupName)
 
-		conditions.SetSecretOutdated(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType, "secret is outdated, update in progress")
+		conditions.SetSecretOutdated(r.dk.Conditions(), conditionType, "secret is outdated, update in progress")
 
-		if err := r.deleteSecret(ctx, secret); err != nil {
+		if err := r.deleteSecret(ctx, groupName, secret); err != nil {
 			return errors.WithStack(err)
 		}
 
-		return r.ensureAuthTokenSecret(ctx)
+		return r.ensureAuthTokenSecret(ctx, groupName)
 	}
 
-	r.conditionSetSecretCreated(secret) // update message once a day
+	r.conditionSetSecretCreated(groupName, secret) // update message once a day
 
 	return nil
 }
 
-func (r *Reconciler) ensureAuthTokenSecret(ctx context.Context) error {
-	agSecretData, err := r.getActiveGateAuthToken(ctx)
+func (r *Reconciler) ensureAuthTokenSecret(ctx context.Context, groupName string) error {
+	agSecretData, err := r.getActiveGateAuthToken(ctx, groupName)
 	if err != nil {
-		return errors.WithMessagef(err, "failed to create secret '%s'", r.dk.ActiveGate().GetAuthTokenSecretName())
+		return errors.WithMessagef(err, "failed to create secret '%s'", r.dk.ActiveGate().GetGroupAuthTokenSecretName(groupName))
 	}
 
-	return r.createSecret(ctx, agSecretData)
+	return r.createSecret(ctx, groupName, agSecretData)
 }
 
-func (r *Reconciler) getActiveGateAuthToken(ctx context.Context) (map[string][]byte, error) {
-	authTokenInfo, err := r.dtc.GetActiveGateAuthToken(ctx, r.dk.Name)
+func (r *Reconciler) getActiveGateAuthToken(ctx context.Context, groupName string) (map[string][]byte, error) {
+	authTokenInfo, err := r.dtc.GetActiveGateAuthToken(ctx, buildAuthTokenName(r.dk.Name, groupName))
 	if err != nil {
-		conditions.SetDynatraceAPIError(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType, err)
+		conditions.SetDynatraceAPIError(r.dk.Conditions(), GetConditionType(groupName), err)
 
 		return nil, errors.WithStack(err)
 	}
@@ -119,33 +190,35 @@ func (r *Reconciler) getActiveGateAuthToken(ctx context.Context) (map[string][]b
 	}, nil
 }
 
-func (r *Reconciler) createSecret(ctx context.Context, secretData map[string][]byte) error {
-	secretName := r.dk.ActiveGate().GetAuthTokenSecretName()
+func (r *Reconciler) createSecret(ctx context.Context, groupName string, secretData map[string][]byte) error {
+	conditionType := GetConditionType(groupName)
+	secretName := r.dk.ActiveGate().GetGroupAuthTokenSecretName(groupName)
 
 	secret, err := k8ssecret.Build(r.dk,
 		secretName,
-		secretData)
+		secretData,
+		k8ssecret.SetLabels(buildSecretLabels(r.dk.Name)))
 	if err != nil {
-		conditions.SetKubeAPIError(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType, err)
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
 
 		return errors.WithStack(err)
 	}
 
 	err = r.secretQuery().WithOwner(r.dk).Create(ctx, secret)
 	if err != nil {
-		conditions.SetKubeAPIError(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType, err)
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
 
 		return errors.Errorf("failed to create secret '%s': %v", secretName, err)
 	}
 
-	r.conditionSetSecretCreated(secret)
+	r.conditionSetSecretCreated(groupName, secret)
 
 	return nil
 }
 
-func (r *Reconciler) deleteSecret(ctx context.Context, secret *corev1.Secret) error {
+func (r *Reconciler) deleteSecret(ctx context.Context, groupName string, secret *corev1.Secret) error {
 	if err := r.secretQuery().Delete(ctx, secret); err != nil {
-		conditions.SetKubeAPIError(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType, err)
+		conditions.SetKubeAPIError(r.dk.Conditions(), GetConditionType(groupName), err)
 
 		return err
 	}
@@ -153,17 +226,30 @@ func (r *Reconciler) deleteSecret(ctx context.Context, secret *corev1.Secret) er
 	return nil
 }
 
+// buildAuthTokenName names the tokens of the ActiveGate groups after the group, so they can be told apart in the Dynatrace environment.
+func buildAuthTokenName(dynakubeName, groupName string) string {
+	if groupName == "" {
+		return dynakubeName
+	}
+
+	return dynakubeName + "-" + groupName
+}
+
+func buildSecretLabels(dynakubeName string) map[string]string {
+	return k8slabels.NewCoreLabels(dynakubeName, k8slabels.ActiveGateComponentLabel).BuildMatchLabels()
+}
+
 func isSecretOutdated(secret *corev1.Secret) bool {
 	return secret.CreationTimestamp.Add(AuthTokenRotationInterval).Before(time.Now())
 }
 
-func (r *Reconciler) conditionSetSecretCreated(secret *corev1.Secret) {
+func (r *Reconciler) conditionSetSecretCreated(groupName string, secret *corev1.Secret) {
 	lifespan := time.Since(secret.CreationTimestamp.Time)
 	days := strconv.Itoa(int(lifespan.Hours() / 24))
 	tokenAllParts := strings.Split(string(secret.Data[ActiveGateAuthTokenName]), ".")
 	tokenPublicPart := strings.Join(tokenAllParts[:2], ".")
 
-	setAuthSecretCreated(r.dk.Conditions(), ActiveGateAuthTokenSecretConditionType, "secret created "+days+" day(s) ago, token:"+tokenPublicPart)
+	setAuthSecretCreated(r.dk.Conditions(), GetConditionType(groupName), "secret created "+days+" day(s) ago, token:"+tokenPublicPart)
 }
 
 func (r *Reconciler) secretQuery() k8ssecret.QueryObject {
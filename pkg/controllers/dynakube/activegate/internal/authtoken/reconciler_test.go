@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -197,4 +198,53 @@ func TestReconcile(t *testing.T) {
 		assert.Equal(t, firstCreationTimestamp, secondCreationTimestamp)
 		assert.Equal(t, secondTransition, firstTransition)
 	})
+	t.Run(`reconcile auth tokens of ActiveGate groups`, func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+		}
+
+		clt := fake.NewClientBuilder().Build()
+
+		r := newTestReconciler(t, clt, dk)
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		var authToken corev1.Secret
+		err = r.client.Get(context.Background(), client.ObjectKey{Name: secretName, Namespace: testNamespace}, &authToken)
+		require.NoError(t, err)
+
+		err = r.client.Get(context.Background(), client.ObjectKey{Name: testDynakubeName + "-activegate-routing-authtoken-secret", Namespace: testNamespace}, &authToken)
+		require.NoError(t, err)
+		assert.NotEmpty(t, authToken.Data[ActiveGateAuthTokenName])
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), GetConditionType("routing"))
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.SecretCreatedReason, condition.Reason)
+	})
+	t.Run(`remove ActiveGate group => its auth token deleted`, func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+		}
+
+		clt := fake.NewClientBuilder().Build()
+
+		r := newTestReconciler(t, clt, dk)
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		dk.Spec.ActiveGate.Groups = nil
+
+		err = r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		var authToken corev1.Secret
+		err = r.client.Get(context.Background(), client.ObjectKey{Name: testDynakubeName + "-activegate-routing-authtoken-secret", Namespace: testNamespace}, &authToken)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		err = r.client.Get(context.Background(), client.ObjectKey{Name: secretName, Namespace: testNamespace}, &authToken)
+		require.NoError(t, err)
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), GetConditionType("routing")))
+	})
 }
@@ -65,7 +65,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 }
 
 func (r *Reconciler) setAGServiceIPs(ctx context.Context) error {
-	template := CreateService(r.dk)
+	template := CreateService(r.dk, r.capability.GroupName())
 	present := &corev1.Service{}
 
 	err := r.client.Get(ctx, client.ObjectKeyFromObject(template), present)
@@ -73,18 +73,18 @@ func (r *Reconciler) setAGServiceIPs(ctx context.Context) error {
 		return errors.WithStack(err)
 	}
 
-	r.dk.Status.ActiveGate.ServiceIPs = present.Spec.ClusterIPs
+	r.dk.Status.ActiveGate.SetServiceIPs(r.capability.GroupName(), present.Spec.ClusterIPs)
 
 	return nil
 }
 
 func (r *Reconciler) createOrUpdateService(ctx context.Context) error {
-	desired := CreateService(r.dk)
+	desired := CreateService(r.dk, r.capability.GroupName())
 	installed := &corev1.Service{}
 
 	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), installed)
 	if k8serrors.IsNotFound(err) {
-		log.Info("creating AG service", "dk", r.dk.Name)
+		log.Info("creating AG service", "dk", r.dk.Name, "name", desired.Name)
 
 		err = controllerutil.SetControllerReference(r.dk, desired, r.client.Scheme())
 		if err != nil {
@@ -282,7 +282,7 @@ func TestPortsAreOutdated(t *testing.T) {
 	r := NewReconciler(clt, capability.NewMultiCapability(dk), dk, mockStatefulSetReconciler, mockCustompropertiesReconciler, mockTLSSecretReconciler).(*Reconciler)
 	verifyReconciler(t, r)
 
-	desiredService := CreateService(r.dk)
+	desiredService := CreateService(r.dk, "")
 
 	err := r.Reconcile(context.Background())
 	require.NoError(t, err)
@@ -311,7 +311,7 @@ func TestLabelsAreOutdated(t *testing.T) {
 	r := NewReconciler(clt, capability.NewMultiCapability(dk), dk, mockStatefulSetReconciler, mockCustompropertiesReconciler, mockTLSSecretReconciler).(*Reconciler)
 	verifyReconciler(t, r)
 
-	desiredService := CreateService(r.dk)
+	desiredService := CreateService(r.dk, "")
 
 	err := r.Reconcile(context.Background())
 	require.NoError(t, err)
@@ -10,7 +10,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-func CreateService(dk *dynakube.DynaKube) *corev1.Service {
+// CreateService creates the Service of the given ActiveGate group, the default group has an empty name.
+func CreateService(dk *dynakube.DynaKube, groupName string) *corev1.Service {
 	var ports []corev1.ServicePort
 
 	ports = append(ports,
@@ -30,20 +31,14 @@ func CreateService(dk *dynakube.DynaKube) *corev1.Service {
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      capability.BuildServiceName(dk.Name),
+			Name:      capability.BuildGroupServiceName(dk.Name, groupName),
 			Namespace: dk.Namespace,
 			Labels:    coreLabels.BuildLabels(),
 		},
 		Spec: corev1.ServiceSpec{
 			Type:     corev1.ServiceTypeClusterIP,
-			Selector: buildSelectorLabels(dk.Name),
+			Selector: capability.BuildSelectorLabels(dk, groupName),
 			Ports:    ports,
 		},
 	}
 }
-
-func buildSelectorLabels(dynakubeName string) map[string]string {
-	appLabels := labels.NewAppLabels(labels.ActiveGateComponentLabel, dynakubeName, "", "")
-
-	return appLabels.BuildMatchLabels()
-}
@@ -49,7 +49,7 @@ func TestCreateService(t *testing.T) {
 
 	t.Run("check service name, labels and selector", func(t *testing.T) {
 		dk := createTestDynaKube()
-		service := CreateService(dk)
+		service := CreateService(dk, "")
 
 		assert.NotNil(t, service)
 		assert.Equal(t, dk.Name+"-"+consts.MultiActiveGateName, service.Name)
@@ -77,7 +77,7 @@ func TestCreateService(t *testing.T) {
 		dk := createTestDynaKube()
 		agutil.SwitchCapability(dk, activegate.RoutingCapability, true)
 
-		service := CreateService(dk)
+		service := CreateService(dk, "")
 		ports := service.Spec.Ports
 
 		assert.Contains(t, ports, agHTTPSPort)
@@ -87,7 +87,7 @@ func TestCreateService(t *testing.T) {
 		dk := createTestDynaKube()
 		agutil.SwitchCapability(dk, activegate.MetricsIngestCapability, true)
 
-		service := CreateService(dk)
+		service := CreateService(dk, "")
 		ports := service.Spec.Ports
 
 		assert.Contains(t, ports, agHTTPSPort)
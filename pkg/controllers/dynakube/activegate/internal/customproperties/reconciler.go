@@ -8,6 +8,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	corev1 "k8s.io/api/core/v1"
@@ -21,7 +22,7 @@ const (
 	Suffix     = "custom-properties"
 	DataKey    = "customProperties"
 	DataPath   = "custom.properties"
-	VolumeName = "custom-properties"
+	VolumeName = consts.CustomPropertiesVolumeName
 	MountPath  = "/var/lib/dynatrace/gateway/config_template/custom.properties"
 
 	clientInternalSection = "[http.client.internal]"
@@ -0,0 +1,5 @@
+package hpa
+
+const (
+	conditionType = "ActiveGateHorizontalPodAutoscaler"
+)
@@ -0,0 +1,7 @@
+package hpa
+
+import "github.com/Dynatrace/dynatrace-operator/pkg/logd"
+
+var (
+	log = logd.Get().WithName("dynakube-activegate-hpa")
+)
@@ -0,0 +1,133 @@
+package hpa
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	k8shpa "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/hpa"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ controllers.Reconciler = &Reconciler{}
+
+type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
+	dk        *dynakube.DynaKube
+}
+
+func NewReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube) *Reconciler {
+	return &Reconciler{
+		client:    clt,
+		apiReader: apiReader,
+		dk:        dk,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	if r.dk.ActiveGate().IsDefaultEnabled() && r.dk.ActiveGate().IsAutoscalingEnabled() {
+		return r.reconcileHPA(ctx)
+	}
+
+	r.dk.Status.ActiveGate.Autoscaling = nil
+
+	if meta.FindStatusCondition(*r.dk.Conditions(), conditionType) == nil {
+		return nil
+	}
+	defer meta.RemoveStatusCondition(r.dk.Conditions(), conditionType)
+
+	return k8shpa.Query(r.client, r.apiReader, log).Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capability.CalculateStatefulSetName(r.dk.Name),
+			Namespace: r.dk.Namespace,
+		},
+	})
+}
+
+func (r *Reconciler) reconcileHPA(ctx context.Context) error {
+	query := k8shpa.Query(r.client, r.apiReader, log).WithOwner(r.dk)
+	desired := r.buildHPA()
+
+	updated, err := query.CreateOrUpdate(ctx, desired)
+	if err != nil {
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
+
+		return err
+	} else if updated {
+		conditions.SetHorizontalPodAutoscalerCreated(r.dk.Conditions(), conditionType, desired.Name)
+	}
+
+	current, err := query.Get(ctx, client.ObjectKeyFromObject(desired))
+	if err != nil {
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	r.dk.Status.ActiveGate.Autoscaling = &activegate.AutoscalingStatus{
+		LastScaleTime:   current.Status.LastScaleTime,
+		CurrentReplicas: current.Status.CurrentReplicas,
+		DesiredReplicas: current.Status.DesiredReplicas,
+	}
+
+	return nil
+}
+
+func (r *Reconciler) buildHPA() *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := r.dk.Spec.ActiveGate.Autoscaling
+	stsName := capability.CalculateStatefulSetName(r.dk.Name)
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      stsName,
+			Namespace: r.dk.Namespace,
+			Labels:    labels.NewCoreLabels(r.dk.Name, labels.ActiveGateComponentLabel).BuildLabels(),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "StatefulSet",
+				Name:       stsName,
+			},
+			MinReplicas: autoscaling.MinReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     buildMetrics(autoscaling),
+		},
+	}
+}
+
+func buildMetrics(autoscaling *activegate.AutoscalingSpec) []autoscalingv2.MetricSpec {
+	var metrics []autoscalingv2.MetricSpec
+
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, buildResourceMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, buildResourceMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+
+	return append(metrics, autoscaling.Metrics...)
+}
+
+func buildResourceMetric(resource corev1.ResourceName, targetUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &targetUtilization,
+			},
+		},
+	}
+}
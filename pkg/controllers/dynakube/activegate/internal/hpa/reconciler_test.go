@@ -0,0 +1,163 @@
+package hpa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testNamespace    = "test-namespace"
+	testDynakubeName = "test-dynakube"
+	testHPAName      = testDynakubeName + "-activegate"
+)
+
+func newDynaKube(autoscaling *activegate.AutoscalingSpec) *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testDynakubeName,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{
+					activegate.RoutingCapability.DisplayName,
+				},
+				Autoscaling: autoscaling,
+			},
+		},
+	}
+}
+
+func getHPA(t *testing.T, clt client.Client) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	t.Helper()
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := clt.Get(context.Background(), client.ObjectKey{Name: testHPAName, Namespace: testNamespace}, hpa)
+
+	return hpa, err
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("autoscaling disabled => no HorizontalPodAutoscaler", func(t *testing.T) {
+		dk := newDynaKube(nil)
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		_, err = getHPA(t, fakeClient)
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, dk.Status.ActiveGate.Autoscaling)
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditionType))
+	})
+	t.Run("autoscaling enabled => HorizontalPodAutoscaler targets the StatefulSet", func(t *testing.T) {
+		customMetric := autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "queue-length"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+			},
+		}
+		dk := newDynaKube(&activegate.AutoscalingSpec{
+			MinReplicas:                       ptr.To(int32(2)),
+			MaxReplicas:                       5,
+			TargetCPUUtilizationPercentage:    ptr.To(int32(70)),
+			TargetMemoryUtilizationPercentage: ptr.To(int32(80)),
+			Metrics:                           []autoscalingv2.MetricSpec{customMetric},
+		})
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		hpa, err := getHPA(t, fakeClient)
+		require.NoError(t, err)
+
+		assert.Equal(t, "StatefulSet", hpa.Spec.ScaleTargetRef.Kind)
+		assert.Equal(t, testHPAName, hpa.Spec.ScaleTargetRef.Name)
+		assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 3)
+		assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+		assert.Equal(t, int32(70), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name)
+		assert.Equal(t, int32(80), *hpa.Spec.Metrics[1].Resource.Target.AverageUtilization)
+		assert.Equal(t, customMetric, hpa.Spec.Metrics[2])
+		require.Len(t, hpa.OwnerReferences, 1)
+		assert.Equal(t, testDynakubeName, hpa.OwnerReferences[0].Name)
+
+		condition := meta.FindStatusCondition(dk.Status.Conditions, conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.HorizontalPodAutoscalerCreatedReason, condition.Reason)
+	})
+	t.Run("HorizontalPodAutoscaler status => surfaced in ActiveGate status", func(t *testing.T) {
+		dk := newDynaKube(&activegate.AutoscalingSpec{MaxReplicas: 5})
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		hpa, err := getHPA(t, fakeClient)
+		require.NoError(t, err)
+
+		hpa.Status.CurrentReplicas = 2
+		hpa.Status.DesiredReplicas = 4
+		require.NoError(t, fakeClient.Update(ctx, hpa))
+
+		err = NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		require.NotNil(t, dk.Status.ActiveGate.Autoscaling)
+		assert.Equal(t, int32(2), dk.Status.ActiveGate.Autoscaling.CurrentReplicas)
+		assert.Equal(t, int32(4), dk.Status.ActiveGate.Autoscaling.DesiredReplicas)
+	})
+	t.Run("autoscaling changed => HorizontalPodAutoscaler updated", func(t *testing.T) {
+		dk := newDynaKube(&activegate.AutoscalingSpec{MaxReplicas: 5})
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.ActiveGate.Autoscaling.MaxReplicas = 10
+
+		err = NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		hpa, err := getHPA(t, fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	})
+	t.Run("autoscaling disabled afterwards => HorizontalPodAutoscaler deleted", func(t *testing.T) {
+		dk := newDynaKube(&activegate.AutoscalingSpec{MaxReplicas: 5})
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.ActiveGate.Autoscaling = nil
+
+		err = NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		_, err = getHPA(t, fakeClient)
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, dk.Status.ActiveGate.Autoscaling)
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditionType))
+	})
+}
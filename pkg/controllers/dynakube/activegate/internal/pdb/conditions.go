@@ -0,0 +1,5 @@
+package pdb
+
+const (
+	conditionType = "ActiveGatePodDisruptionBudget"
+)
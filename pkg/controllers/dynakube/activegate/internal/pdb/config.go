@@ -0,0 +1,7 @@
+package pdb
+
+import "github.com/Dynatrace/dynatrace-operator/pkg/logd"
+
+var (
+	log = logd.Get().WithName("dynakube-activegate-pdb")
+)
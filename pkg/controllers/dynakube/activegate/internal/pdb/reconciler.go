@@ -0,0 +1,126 @@
+package pdb
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	k8spdb "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pdb"
+	"github.com/pkg/errors"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ controllers.Reconciler = &Reconciler{}
+
+type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
+	dk        *dynakube.DynaKube
+}
+
+func NewReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube) *Reconciler {
+	return &Reconciler{
+		client:    clt,
+		apiReader: apiReader,
+		dk:        dk,
+	}
+}
+
+// Reconcile creates a PodDisruptionBudget for the default ActiveGate and one for each ActiveGate group,
+// and deletes the ones that aren't needed anymore.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	var desired []*policyv1.PodDisruptionBudget
+
+	var groupNames []string
+
+	budgets := map[string]*pdb.Spec{}
+
+	if r.dk.ActiveGate().IsDefaultEnabled() {
+		groupNames = append(groupNames, "")
+		budgets[""] = pdb.GetBudget(r.dk.Spec.ActiveGate.PodDisruptionBudget, r.getMaxReplicas())
+	}
+
+	for _, group := range r.dk.Spec.ActiveGate.Groups {
+		groupNames = append(groupNames, group.Name)
+		budgets[group.Name] = pdb.GetBudget(r.dk.Spec.ActiveGate.PodDisruptionBudget, ptr.Deref(group.Replicas, 1))
+	}
+
+	for _, groupName := range groupNames {
+		budget := budgets[groupName]
+		if budget == nil {
+			continue
+		}
+
+		podDisruptionBudget, err := k8spdb.Build(r.dk, capability.CalculateGroupStatefulSetName(r.dk.Name, groupName), capability.BuildSelectorLabels(r.dk, groupName), *budget,
+			k8spdb.SetLabels(labels.NewCoreLabels(r.dk.Name, labels.ActiveGateComponentLabel).BuildLabels()),
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		desired = append(desired, podDisruptionBudget)
+	}
+
+	if len(desired) == 0 {
+		if meta.FindStatusCondition(*r.dk.Conditions(), conditionType) == nil {
+			return nil
+		}
+		defer meta.RemoveStatusCondition(r.dk.Conditions(), conditionType)
+
+		return r.deleteStalePDBs(ctx, nil)
+	}
+
+	pdbReconciler := k8spdb.NewReconciler(r.client, r.apiReader, log, r.dk, conditionType)
+
+	for _, podDisruptionBudget := range desired {
+		if err := pdbReconciler.CreateOrUpdate(ctx, podDisruptionBudget); err != nil {
+			return err
+		}
+	}
+
+	return r.deleteStalePDBs(ctx, desired)
+}
+
+// getMaxReplicas considers the upper limit of the HorizontalPodAutoscaler, as the replicas change over time when autoscaling is enabled.
+func (r *Reconciler) getMaxReplicas() int32 {
+	if r.dk.ActiveGate().IsAutoscalingEnabled() {
+		return r.dk.Spec.ActiveGate.Autoscaling.MaxReplicas
+	}
+
+	return r.dk.ActiveGate().GetReplicas()
+}
+
+// deleteStalePDBs deletes the PodDisruptionBudgets of the ActiveGate, which aren't desired anymore, like the ones of removed groups.
+func (r *Reconciler) deleteStalePDBs(ctx context.Context, desired []*policyv1.PodDisruptionBudget) error {
+	var podDisruptionBudgets policyv1.PodDisruptionBudgetList
+
+	err := r.apiReader.List(ctx, &podDisruptionBudgets, client.InNamespace(r.dk.Namespace), client.MatchingLabels(labels.NewCoreLabels(r.dk.Name, labels.ActiveGateComponentLabel).BuildMatchLabels()))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	query := k8spdb.Query(r.client, r.apiReader, log)
+
+	for i := range podDisruptionBudgets.Items {
+		stale := &podDisruptionBudgets.Items[i]
+
+		if slices.ContainsFunc(desired, func(podDisruptionBudget *policyv1.PodDisruptionBudget) bool {
+			return podDisruptionBudget.Name == stale.Name
+		}) {
+			continue
+		}
+
+		if err := query.Delete(ctx, stale); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package pdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testNamespace    = "test-namespace"
+	testDynakubeName = "test-dynakube"
+	testPDBName      = testDynakubeName + "-activegate"
+)
+
+func newDynaKube(replicas int32, budget *pdb.Spec) *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testDynakubeName,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{
+					activegate.RoutingCapability.DisplayName,
+				},
+				CapabilityProperties: activegate.CapabilityProperties{
+					Replicas: ptr.To(replicas),
+				},
+				PodDisruptionBudget: budget,
+			},
+		},
+	}
+}
+
+func getPDB(t *testing.T, clt client.Client) (*policyv1.PodDisruptionBudget, error) {
+	t.Helper()
+
+	podDisruptionBudget := &policyv1.PodDisruptionBudget{}
+	err := clt.Get(context.Background(), client.ObjectKey{Name: testPDBName, Namespace: testNamespace}, podDisruptionBudget)
+
+	return podDisruptionBudget, err
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("single replica without budget => no PodDisruptionBudget", func(t *testing.T) {
+		dk := newDynaKube(1, nil)
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		_, err = getPDB(t, fakeClient)
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditionType))
+	})
+	t.Run("multiple replicas without budget => default PodDisruptionBudget", func(t *testing.T) {
+		dk := newDynaKube(3, nil)
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		podDisruptionBudget, err := getPDB(t, fakeClient)
+		require.NoError(t, err)
+
+		assert.Equal(t, intstr.FromInt32(1), *podDisruptionBudget.Spec.MaxUnavailable)
+		assert.Nil(t, podDisruptionBudget.Spec.MinAvailable)
+		assert.Equal(t, testDynakubeName, podDisruptionBudget.Spec.Selector.MatchLabels[labels.AppCreatedByLabel])
+		require.Len(t, podDisruptionBudget.OwnerReferences, 1)
+		assert.Equal(t, testDynakubeName, podDisruptionBudget.OwnerReferences[0].Name)
+
+		condition := meta.FindStatusCondition(dk.Status.Conditions, conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.PodDisruptionBudgetCreatedReason, condition.Reason)
+	})
+	t.Run("configured budget => applied to PodDisruptionBudget", func(t *testing.T) {
+		minAvailable := intstr.FromString("50%")
+		dk := newDynaKube(1, &pdb.Spec{MinAvailable: &minAvailable})
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		podDisruptionBudget, err := getPDB(t, fakeClient)
+		require.NoError(t, err)
+
+		assert.Equal(t, minAvailable, *podDisruptionBudget.Spec.MinAvailable)
+		assert.Nil(t, podDisruptionBudget.Spec.MaxUnavailable)
+	})
+	t.Run("autoscaling => maximum replicas considered", func(t *testing.T) {
+		dk := newDynaKube(1, nil)
+		dk.Spec.ActiveGate.Autoscaling = &activegate.AutoscalingSpec{MaxReplicas: 3}
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		_, err = getPDB(t, fakeClient)
+		require.NoError(t, err)
+	})
+	t.Run("groups => PodDisruptionBudget per group", func(t *testing.T) {
+		dk := newDynaKube(3, nil)
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "metrics", Capabilities: []activegate.CapabilityDisplayName{activegate.MetricsIngestCapability.DisplayName}, Replicas: ptr.To(int32(2))},
+			{Name: "single", Capabilities: []activegate.CapabilityDisplayName{activegate.DynatraceAPICapability.DisplayName}},
+		}
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		defaultPDB, err := getPDB(t, fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, "activegate", defaultPDB.Spec.Selector.MatchLabels[labels.AppComponentLabel])
+
+		groupPDB := &policyv1.PodDisruptionBudget{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: testPDBName + "-metrics", Namespace: testNamespace}, groupPDB))
+		assert.Equal(t, "activegate-metrics", groupPDB.Spec.Selector.MatchLabels[labels.AppComponentLabel])
+		assert.Equal(t, testDynakubeName, groupPDB.Spec.Selector.MatchLabels[labels.AppCreatedByLabel])
+
+		err = fakeClient.Get(ctx, client.ObjectKey{Name: testPDBName + "-single", Namespace: testNamespace}, &policyv1.PodDisruptionBudget{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		// the group is removed
+		dk.Spec.ActiveGate.Groups = nil
+
+		err = NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		err = fakeClient.Get(ctx, client.ObjectKey{Name: testPDBName + "-metrics", Namespace: testNamespace}, &policyv1.PodDisruptionBudget{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		_, err = getPDB(t, fakeClient)
+		require.NoError(t, err)
+	})
+	t.Run("scaled down to single replica => PodDisruptionBudget deleted", func(t *testing.T) {
+		dk := newDynaKube(3, nil)
+		fakeClient := fake.NewClient()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.ActiveGate.Replicas = ptr.To(int32(1))
+
+		err = NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		_, err = getPDB(t, fakeClient)
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditionType))
+	})
+}
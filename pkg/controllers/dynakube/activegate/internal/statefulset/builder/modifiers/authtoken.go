@@ -2,6 +2,7 @@ package modifiers
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/authtoken"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
@@ -14,14 +15,16 @@ var _ volumeModifier = AuthTokenModifier{}
 var _ volumeMountModifier = AuthTokenModifier{}
 var _ builder.Modifier = AuthTokenModifier{}
 
-func NewAuthTokenModifier(dk dynakube.DynaKube) AuthTokenModifier {
+func NewAuthTokenModifier(dk dynakube.DynaKube, capability capability.Capability) AuthTokenModifier {
 	return AuthTokenModifier{
-		dk: dk,
+		dk:         dk,
+		capability: capability,
 	}
 }
 
 type AuthTokenModifier struct {
-	dk dynakube.DynaKube
+	capability capability.Capability
+	dk         dynakube.DynaKube
 }
 
 func (mod AuthTokenModifier) Enabled() bool {
@@ -42,7 +45,7 @@ func (mod AuthTokenModifier) getVolumes() []corev1.Volume {
 			Name: consts.AuthTokenSecretVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: mod.dk.ActiveGate().GetAuthTokenSecretName(),
+					SecretName: mod.dk.ActiveGate().GetGroupAuthTokenSecretName(mod.capability.GroupName()),
 				},
 			},
 		},
@@ -3,6 +3,7 @@ package modifiers
 import (
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/stretchr/testify/require"
 )
 
@@ -10,7 +11,7 @@ func TestAuthTokenModify(t *testing.T) {
 	t.Run("successfully modified", func(t *testing.T) {
 		dk := getBaseDynakube()
 		enableKubeMonCapability(&dk)
-		mod := NewAuthTokenModifier(dk)
+		mod := NewAuthTokenModifier(dk, capability.NewMultiCapability(&dk))
 		builder := createBuilderForTesting()
 
 		sts, _ := builder.AddModifier(mod).Build()
@@ -16,7 +16,6 @@ var _ volumeMountModifier = CertificatesModifier{}
 var _ builder.Modifier = CertificatesModifier{}
 
 const (
-	jettyCerts     = "server-certs"
 	secretsRootDir = "/var/lib/dynatrace/secrets/"
 )
 
@@ -45,7 +44,7 @@ func (mod CertificatesModifier) Modify(sts *appsv1.StatefulSet) error {
 func (mod CertificatesModifier) getVolumes() []corev1.Volume {
 	return []corev1.Volume{
 		{
-			Name: jettyCerts,
+			Name: consts.TLSCertsVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					SecretName: mod.dk.ActiveGate().GetTLSSecretName(),
@@ -59,7 +58,7 @@ func (mod CertificatesModifier) getVolumeMounts() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
 		{
 			ReadOnly:  true,
-			Name:      jettyCerts,
+			Name:      consts.TLSCertsVolumeName,
 			MountPath: filepath.Join(secretsRootDir, "tls"),
 		},
 	}
@@ -28,7 +28,7 @@ type initContainerModifier interface {
 
 func GenerateAllModifiers(dk dynakube.DynaKube, capability capability.Capability, agBaseContainerEnvMap *prioritymap.Map) []builder.Modifier {
 	return []builder.Modifier{
-		NewAuthTokenModifier(dk),
+		NewAuthTokenModifier(dk, capability),
 		NewSSLVolumeModifier(dk),
 		NewCertificatesModifier(dk),
 		NewTrustedCAsVolumeModifier(dk),
@@ -38,7 +38,7 @@ func GenerateAllModifiers(dk dynakube.DynaKube, capability capability.Capability
 		NewReadOnlyModifier(dk),
 		NewServicePortModifier(dk, capability, agBaseContainerEnvMap),
 		NewKubernetesMonitoringModifier(dk, capability),
-		NewEecVolumeModifier(dk),
-		NewKspmModifier(dk),
+		NewEecVolumeModifier(dk, capability),
+		NewKspmModifier(dk, capability),
 	}
 }
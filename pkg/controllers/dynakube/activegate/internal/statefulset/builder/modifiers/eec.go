@@ -2,6 +2,7 @@ package modifiers
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
 	eecconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/consts"
@@ -15,23 +16,25 @@ var _ volumeMountModifier = EecModifier{}
 var _ builder.Modifier = EecModifier{}
 
 const (
-	eecVolumeName = "eec-token"
-	eecMountPath  = "/var/lib/dynatrace/secrets/eec/token"
-	eecFile       = "eec.token"
+	eecMountPath = "/var/lib/dynatrace/secrets/eec/token"
+	eecFile      = "eec.token"
 )
 
-func NewEecVolumeModifier(dk dynakube.DynaKube) EecModifier {
+func NewEecVolumeModifier(dk dynakube.DynaKube, capability capability.Capability) EecModifier {
 	return EecModifier{
-		dk: dk,
+		dk:         dk,
+		capability: capability,
 	}
 }
 
 type EecModifier struct {
-	dk dynakube.DynaKube
+	capability capability.Capability
+	dk         dynakube.DynaKube
 }
 
+// Enabled only for the default ActiveGate, as the extension controller isn't part of the ActiveGate groups.
 func (mod EecModifier) Enabled() bool {
-	return mod.dk.IsExtensionsEnabled()
+	return mod.dk.IsExtensionsEnabled() && mod.capability.GroupName() == ""
 }
 
 func (mod EecModifier) Modify(sts *appsv1.StatefulSet) error {
@@ -47,7 +50,7 @@ func (mod EecModifier) getVolumes() []corev1.Volume {
 
 	return []corev1.Volume{
 		{
-			Name: eecVolumeName,
+			Name: consts.EECTokenVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					SecretName:  mod.dk.ExtensionsTokenSecretName(),
@@ -69,7 +72,7 @@ func (mod EecModifier) getVolumeMounts() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
 		{
 			ReadOnly:  true,
-			Name:      eecVolumeName,
+			Name:      consts.EECTokenVolumeName,
 			MountPath: eecMountPath,
 		},
 	}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,7 +15,7 @@ func TestEecEnabled(t *testing.T) {
 		dk := getBaseDynakube()
 		dk.Spec.Extensions = &dynakube.ExtensionsSpec{}
 
-		mod := NewEecVolumeModifier(dk)
+		mod := NewEecVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -22,7 +24,7 @@ func TestEecEnabled(t *testing.T) {
 		dk := getBaseDynakube()
 		dk.Spec.Extensions = nil
 
-		mod := NewEecVolumeModifier(dk)
+		mod := NewEecVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.False(t, mod.Enabled())
 	})
@@ -33,7 +35,7 @@ func TestEecModify(t *testing.T) {
 		dk := getBaseDynakube()
 		dk.Spec.Extensions = &dynakube.ExtensionsSpec{}
 
-		mod := NewEecVolumeModifier(dk)
+		mod := NewEecVolumeModifier(dk, capability.NewMultiCapability(&dk))
 		builder := createBuilderForTesting()
 
 		sts, _ := builder.AddModifier(mod).Build()
@@ -41,7 +43,7 @@ func TestEecModify(t *testing.T) {
 		require.NotEmpty(t, sts)
 		isSubset(t, mod.getVolumes(), sts.Spec.Template.Spec.Volumes)
 		isSubset(t, mod.getVolumeMounts(), sts.Spec.Template.Spec.Containers[0].VolumeMounts)
-		require.Equal(t, eecVolumeName, sts.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name)
+		require.Equal(t, consts.EECTokenVolumeName, sts.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name)
 		require.Equal(t, eecMountPath, sts.Spec.Template.Spec.Containers[0].VolumeMounts[0].MountPath)
 		require.True(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts[0].ReadOnly)
 	})
@@ -3,7 +3,9 @@ package modifiers
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/container"
@@ -16,23 +18,24 @@ var _ volumeMountModifier = KspmModifier{}
 var _ builder.Modifier = KspmModifier{}
 
 const (
-	kspmTokenVolumeName           = "kspm-token"
 	kspmTokenMountPath            = "/var/lib/dynatrace/secrets/tokens/kspm/node-configuration-collector"
 	kspmTokenSecretHashAnnotation = api.InternalFlagPrefix + "kspm-token-secret-hash"
 )
 
-func NewKspmModifier(dk dynakube.DynaKube) KspmModifier {
+func NewKspmModifier(dk dynakube.DynaKube, capability capability.Capability) KspmModifier {
 	return KspmModifier{
-		dk: dk,
+		dk:         dk,
+		capability: capability,
 	}
 }
 
 type KspmModifier struct {
-	dk dynakube.DynaKube
+	capability capability.Capability
+	dk         dynakube.DynaKube
 }
 
 func (mod KspmModifier) Enabled() bool {
-	return mod.dk.KSPM().IsEnabled() && mod.dk.ActiveGate().IsKubernetesMonitoringEnabled()
+	return mod.dk.KSPM().IsEnabled() && mod.capability.HasCapability(activegate.KubeMonCapability.DisplayName)
 }
 
 func (mod KspmModifier) Modify(sts *appsv1.StatefulSet) error {
@@ -51,7 +54,7 @@ func (mod KspmModifier) Modify(sts *appsv1.StatefulSet) error {
 func (mod KspmModifier) getVolumes() []corev1.Volume {
 	return []corev1.Volume{
 		{
-			Name: kspmTokenVolumeName,
+			Name: consts.KSPMTokenVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					SecretName: mod.dk.KSPM().GetTokenSecretName(),
@@ -65,7 +68,7 @@ func (mod KspmModifier) getVolumeMounts() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
 		{
 			ReadOnly:  true,
-			Name:      kspmTokenVolumeName,
+			Name:      consts.KSPMTokenVolumeName,
 			MountPath: kspmTokenMountPath,
 			SubPath:   kspm.TokenSecretKey,
 		},
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,7 +24,7 @@ func TestKspmEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		setKSPMUsage(&dk, true)
 
-		mod := NewKspmModifier(dk)
+		mod := NewKspmModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -33,7 +34,7 @@ func TestKspmEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		setKSPMUsage(&dk, false)
 
-		mod := NewKspmModifier(dk)
+		mod := NewKspmModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.False(t, mod.Enabled())
 	})
@@ -42,7 +43,7 @@ func TestKspmEnabled(t *testing.T) {
 		dk := getBaseDynakube()
 		setKSPMUsage(&dk, true)
 
-		mod := NewKspmModifier(dk)
+		mod := NewKspmModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.False(t, mod.Enabled())
 	})
@@ -54,7 +55,7 @@ func TestKspmModify(t *testing.T) {
 		dk.KSPM().TokenSecretHash = "some-hash"
 		enableKubeMonCapability(&dk)
 		setKSPMUsage(&dk, true)
-		mod := NewKspmModifier(dk)
+		mod := NewKspmModifier(dk, capability.NewMultiCapability(&dk))
 		builder := createBuilderForTesting()
 
 		sts, _ := builder.AddModifier(mod).Build()
@@ -2,6 +2,7 @@ package modifiers
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
@@ -17,15 +18,12 @@ var _ initContainerModifier = KubernetesMonitoringModifier{}
 var _ builder.Modifier = KubernetesMonitoringModifier{}
 
 const (
-	trustStoreVolume          = "truststore-volume"
 	activeGateCacertsPath     = "/opt/dynatrace/gateway/jre/lib/security/cacerts"
 	k8sCertificateFile        = "k8s-local.jks"
 	k8scrt2jksPath            = "/opt/dynatrace/gateway/k8scrt2jks.sh"
 	activeGateSslPath         = "/var/lib/dynatrace/gateway/ssl"
 	k8scrt2jksWorkingDir      = "/var/lib/dynatrace/gateway"
 	initContainerTemplateName = "certificate-loader"
-
-	certLoaderWorkDirVolume = "cert-tmp"
 )
 
 func NewKubernetesMonitoringModifier(dk dynakube.DynaKube, capability capability.Capability) KubernetesMonitoringModifier {
@@ -41,7 +39,7 @@ type KubernetesMonitoringModifier struct {
 }
 
 func (mod KubernetesMonitoringModifier) Enabled() bool {
-	return mod.dk.ActiveGate().IsKubernetesMonitoringEnabled()
+	return mod.capability.HasCapability(activegate.KubeMonCapability.DisplayName)
 }
 
 func (mod KubernetesMonitoringModifier) Modify(sts *appsv1.StatefulSet) error {
@@ -57,7 +55,7 @@ func (mod KubernetesMonitoringModifier) getInitContainers() []corev1.Container {
 	volumeMounts := []corev1.VolumeMount{
 		{
 			ReadOnly:  false,
-			Name:      trustStoreVolume,
+			Name:      consts.TrustStoreVolumeName,
 			MountPath: activeGateSslPath,
 		},
 	}
@@ -81,7 +79,7 @@ func (mod KubernetesMonitoringModifier) getInitContainers() []corev1.Container {
 func (mod KubernetesMonitoringModifier) getVolumes() []corev1.Volume {
 	volumes := []corev1.Volume{
 		{
-			Name: trustStoreVolume,
+			Name: consts.TrustStoreVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
@@ -94,7 +92,7 @@ func (mod KubernetesMonitoringModifier) getVolumes() []corev1.Volume {
 func (mod KubernetesMonitoringModifier) getReadOnlyInitVolumes() []corev1.Volume {
 	return []corev1.Volume{
 		{
-			Name:         certLoaderWorkDirVolume,
+			Name:         consts.CertLoaderWorkDirVolumeName,
 			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 		},
 	}
@@ -104,7 +102,7 @@ func (mod KubernetesMonitoringModifier) getVolumeMounts() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
 		{
 			ReadOnly:  true,
-			Name:      trustStoreVolume,
+			Name:      consts.TrustStoreVolumeName,
 			MountPath: activeGateCacertsPath,
 			SubPath:   k8sCertificateFile,
 		},
@@ -115,7 +113,7 @@ func (mod KubernetesMonitoringModifier) getReadOnlyInitVolumeMounts() []corev1.V
 	return []corev1.VolumeMount{
 		{
 			ReadOnly:  false,
-			Name:      certLoaderWorkDirVolume,
+			Name:      consts.CertLoaderWorkDirVolumeName,
 			MountPath: k8scrt2jksWorkingDir,
 		},
 	}
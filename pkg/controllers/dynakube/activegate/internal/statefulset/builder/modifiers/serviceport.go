@@ -62,7 +62,7 @@ func (mod ServicePortModifier) getEnvs() []corev1.EnvVar {
 		[]corev1.EnvVar{
 			{
 				Name:  consts.EnvDtDNSEntryPoint,
-				Value: capability.BuildDNSEntryPoint(mod.dk),
+				Value: capability.BuildDNSEntryPoint(mod.dk, mod.capability.GroupName()),
 			},
 		},
 		prioritymap.WithPriority(modifierEnvPriority))
@@ -14,7 +14,6 @@ var _ volumeMountModifier = TrustedCAsModifier{}
 var _ builder.Modifier = TrustedCAsModifier{}
 
 const (
-	volumeName     = "trustedcas"
 	trustedCAsDir  = "/var/lib/dynatrace/secrets/rootca"
 	trustedCAsFile = "rootca.pem"
 )
@@ -44,7 +43,7 @@ func (mod TrustedCAsModifier) Modify(sts *appsv1.StatefulSet) error {
 func (mod TrustedCAsModifier) getVolumes() []corev1.Volume {
 	return []corev1.Volume{
 		{
-			Name: volumeName,
+			Name: consts.TrustedCAsVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				ConfigMap: &corev1.ConfigMapVolumeSource{
 					LocalObjectReference: corev1.LocalObjectReference{
@@ -66,7 +65,7 @@ func (mod TrustedCAsModifier) getVolumeMounts() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
 		{
 			ReadOnly:  true,
-			Name:      volumeName,
+			Name:      consts.TrustedCAsVolumeName,
 			MountPath: trustedCAsDir,
 		},
 	}
@@ -1,3 +1,12 @@
 package statefulset
 
 const ActiveGateStatefulSetConditionType string = "ActiveGateStatefulSet"
+
+// GetConditionType returns the condition type of the StatefulSet of the given ActiveGate group, the default group has an empty name.
+func GetConditionType(groupName string) string {
+	if groupName == "" {
+		return ActiveGateStatefulSetConditionType
+	}
+
+	return ActiveGateStatefulSetConditionType + "-" + groupName
+}
@@ -0,0 +1,41 @@
+package statefulset
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// applyPodTemplateOverride applies the pod template override of the DynaKube as strategic merge patch.
+// It runs after all modifiers, so the override takes precedence over the defaults of the operator.
+// The fields managed by the operator are protected by the validation webhook.
+func (statefulSetBuilder Builder) applyPodTemplateOverride(sts *appsv1.StatefulSet) error {
+	activeGate := statefulSetBuilder.dynakube.ActiveGate()
+	if !activeGate.HasPodTemplateOverride() {
+		return nil
+	}
+
+	original, err := json.Marshal(sts.Spec.Template)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(original, activeGate.PodTemplateOverride.Raw, corev1.PodTemplateSpec{})
+	if err != nil {
+		return errors.WithMessage(err, "failed to apply the pod template override of the ActiveGate")
+	}
+
+	var template corev1.PodTemplateSpec
+
+	err = json.Unmarshal(patched, &template)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	sts.Spec.Template = template
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package statefulset
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestApplyPodTemplateOverride(t *testing.T) {
+	t.Run("no override => pod template unchanged", func(t *testing.T) {
+		dk := getTestDynakube()
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, capability.NewMultiCapability(&dk))
+
+		sts, err := builder.CreateStatefulSet(nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, sts.Spec.Template.Spec.SecurityContext.SeccompProfile.Type)
+		assert.Len(t, sts.Spec.Template.Spec.Containers, 1)
+	})
+	t.Run("override => merged into pod template", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.ActiveGate.PodTemplateOverride = &runtime.RawExtension{Raw: []byte(`{
+			"metadata": {"labels": {"team": "platform"}},
+			"spec": {
+				"serviceAccountName": "custom-sa",
+				"runtimeClassName": "gvisor",
+				"hostAliases": [{"ip": "10.0.0.1", "hostnames": ["tenant.internal"]}],
+				"securityContext": {"runAsNonRoot": true},
+				"affinity": {"podAntiAffinity": {"preferredDuringSchedulingIgnoredDuringExecution": [{"weight": 100, "podAffinityTerm": {"topologyKey": "kubernetes.io/hostname"}}]}},
+				"volumes": [{"name": "extra", "emptyDir": {}}],
+				"containers": [
+					{"name": "activegate", "volumeMounts": [{"name": "extra", "mountPath": "/extra"}]},
+					{"name": "sidecar", "image": "sidecar:latest"}
+				]
+			}
+		}`)}
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, capability.NewMultiCapability(&dk))
+
+		sts, err := builder.CreateStatefulSet(nil)
+		require.NoError(t, err)
+
+		podSpec := sts.Spec.Template.Spec
+		assert.Equal(t, "platform", sts.Spec.Template.Labels["team"])
+		assert.Equal(t, "custom-sa", podSpec.ServiceAccountName)
+		assert.Equal(t, "gvisor", *podSpec.RuntimeClassName)
+		assert.Equal(t, "10.0.0.1", podSpec.HostAliases[0].IP)
+
+		// defaults of the operator are kept, if not overridden
+		assert.True(t, *podSpec.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+		assert.NotNil(t, podSpec.Affinity.NodeAffinity)
+		assert.NotNil(t, podSpec.Affinity.PodAntiAffinity)
+
+		require.Len(t, podSpec.Containers, 2)
+		activeGateContainer := podSpec.Containers[0]
+		assert.Equal(t, consts.ActiveGateContainerName, activeGateContainer.Name)
+		assert.Equal(t, dk.ActiveGate().GetImage(), activeGateContainer.Image)
+		assert.NotEmpty(t, activeGateContainer.Env)
+		assert.Contains(t, activeGateContainer.VolumeMounts, corev1.VolumeMount{Name: "extra", MountPath: "/extra"})
+		assert.Contains(t, activeGateContainer.VolumeMounts, corev1.VolumeMount{Name: consts.GatewayTmpVolumeName, MountPath: consts.GatewayTmpMountPoint})
+		assert.Equal(t, "sidecar", podSpec.Containers[1].Name)
+
+		volumeNames := make([]string, 0, len(podSpec.Volumes))
+		for _, volume := range podSpec.Volumes {
+			volumeNames = append(volumeNames, volume.Name)
+		}
+
+		assert.Contains(t, volumeNames, "extra")
+		assert.Contains(t, volumeNames, consts.GatewayTmpVolumeName)
+	})
+	t.Run("invalid override => error", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.ActiveGate.PodTemplateOverride = &runtime.RawExtension{Raw: []byte(`{"spec": {"containers": [{"image": "no-name"}]}}`)}
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, capability.NewMultiCapability(&dk))
+
+		_, err := builder.CreateStatefulSet(nil)
+		require.Error(t, err)
+	})
+}
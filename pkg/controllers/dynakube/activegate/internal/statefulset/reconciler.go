@@ -13,10 +13,12 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/statefulset"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -60,22 +62,53 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 }
 
 func (r *Reconciler) manageStatefulSet(ctx context.Context) error {
+	conditionType := GetConditionType(r.capability.GroupName())
+
 	desiredSts, err := r.buildDesiredStatefulSet(ctx)
 	if err != nil {
-		conditions.SetKubeAPIError(r.dk.Conditions(), ActiveGateStatefulSetConditionType, err)
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
 
 		return err
 	}
 
+	if r.dk.ActiveGate().IsAutoscalingEnabled() && r.capability.GroupName() == "" {
+		err = r.keepAutoscaledReplicas(ctx, desiredSts)
+		if err != nil {
+			conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
+
+			return err
+		}
+	}
+
 	updated, err := statefulset.Query(r.client, r.apiReader, log).WithOwner(r.dk).CreateOrUpdate(ctx, desiredSts)
 	if err != nil {
-		conditions.SetKubeAPIError(r.dk.Conditions(), ActiveGateStatefulSetConditionType, err)
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
 
 		return err
 	} else if updated {
-		conditions.SetStatefulSetCreated(r.dk.Conditions(), ActiveGateStatefulSetConditionType, desiredSts.Name)
+		conditions.SetStatefulSetCreated(r.dk.Conditions(), conditionType, desiredSts.Name)
+	}
+
+	return nil
+}
+
+// keepAutoscaledReplicas takes over the replicas set by the HorizontalPodAutoscaler, so updating the StatefulSet doesn't reset them.
+// The hash annotation is added beforehand, so scaling alone isn't detected as a change of the StatefulSet.
+func (r *Reconciler) keepAutoscaledReplicas(ctx context.Context, desiredSts *appsv1.StatefulSet) error {
+	err := hasher.AddAnnotation(desiredSts)
+	if err != nil {
+		return errors.WithStack(err)
 	}
 
+	currentSts, err := statefulset.Query(r.client, r.apiReader, log).Get(ctx, client.ObjectKeyFromObject(desiredSts))
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	desiredSts.Spec.Replicas = currentSts.Spec.Replicas
+
 	return nil
 }
 
@@ -152,7 +185,7 @@ func (r *Reconciler) getDataFromCustomProperty(ctx context.Context, customProper
 }
 
 func (r *Reconciler) getDataFromAuthTokenSecret(ctx context.Context) (string, error) {
-	return secret.GetDataFromSecretName(ctx, r.apiReader, types.NamespacedName{Namespace: r.dk.Namespace, Name: r.dk.ActiveGate().GetAuthTokenSecretName()}, authtoken.ActiveGateAuthTokenName, log)
+	return secret.GetDataFromSecretName(ctx, r.apiReader, types.NamespacedName{Namespace: r.dk.Namespace, Name: r.dk.ActiveGate().GetGroupAuthTokenSecretName(r.capability.GroupName())}, authtoken.ActiveGateAuthTokenName, log)
 }
 
 func needsCustomPropertyHash(customProperties *value.Source) bool {
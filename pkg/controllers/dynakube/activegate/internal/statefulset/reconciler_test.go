@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -268,6 +269,34 @@ func TestManageStatefulSet(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, testValue, labelValue)
 	})
+	t.Run("keep replicas set by the HorizontalPodAutoscaler", func(t *testing.T) {
+		r := createDefaultReconciler(t)
+		r.dk.Spec.ActiveGate.Autoscaling = &activegate.AutoscalingSpec{MaxReplicas: 5}
+
+		err := r.manageStatefulSet(ctx)
+		require.NoError(t, err)
+
+		desiredStatefulSet, err := r.buildDesiredStatefulSet(ctx)
+		require.NoError(t, err)
+
+		actualStatefulSet, err := statefulset.Query(r.client, r.apiReader, log).Get(ctx, client.ObjectKeyFromObject(desiredStatefulSet))
+		require.NoError(t, err)
+
+		actualStatefulSet.Spec.Replicas = ptr.To(int32(4))
+		err = r.client.Update(ctx, actualStatefulSet)
+		require.NoError(t, err)
+
+		err = r.manageStatefulSet(ctx)
+		require.NoError(t, err)
+
+		r.dk.Spec.Proxy = &value.Source{Value: testValue}
+		err = r.manageStatefulSet(ctx)
+		require.NoError(t, err)
+
+		actualStatefulSet, err = statefulset.Query(r.client, r.apiReader, log).Get(ctx, client.ObjectKeyFromObject(desiredStatefulSet))
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), *actualStatefulSet.Spec.Replicas)
+	})
 }
 
 func TestStatefulSetUpdateWeakness(t *testing.T) {
@@ -56,6 +56,11 @@ func (statefulSetBuilder Builder) CreateStatefulSet(mods []builder.Modifier) (*a
 
 	sts, _ := activeGateBuilder.AddModifier(mods...).Build()
 
+	err := statefulSetBuilder.applyPodTemplateOverride(&sts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &sts, nil
 }
 
@@ -79,7 +84,7 @@ func (statefulSetBuilder Builder) getBase() appsv1.StatefulSet {
 
 func (statefulSetBuilder Builder) getBaseObjectMeta() metav1.ObjectMeta {
 	return metav1.ObjectMeta{
-		Name:        statefulSetBuilder.dynakube.Name + "-" + consts.MultiActiveGateName,
+		Name:        capability.CalculateGroupStatefulSetName(statefulSetBuilder.dynakube.Name, statefulSetBuilder.capability.GroupName()),
 		Namespace:   statefulSetBuilder.dynakube.Namespace,
 		Annotations: map[string]string{},
 	}
@@ -87,7 +92,7 @@ func (statefulSetBuilder Builder) getBaseObjectMeta() metav1.ObjectMeta {
 
 func (statefulSetBuilder Builder) getBaseSpec() appsv1.StatefulSetSpec {
 	return appsv1.StatefulSetSpec{
-		Replicas:            statefulSetBuilder.capability.Properties().Replicas,
+		Replicas:            statefulSetBuilder.getReplicas(),
 		PodManagementPolicy: appsv1.ParallelPodManagement,
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
@@ -100,17 +105,44 @@ func (statefulSetBuilder Builder) getBaseSpec() appsv1.StatefulSetSpec {
 	}
 }
 
+// getReplicas leaves the replicas unset if autoscaling is enabled, as they are managed by the HorizontalPodAutoscaler then.
+// Autoscaling only applies to the default ActiveGate.
+func (statefulSetBuilder Builder) getReplicas() *int32 {
+	if statefulSetBuilder.dynakube.ActiveGate().IsAutoscalingEnabled() && !statefulSetBuilder.isGroup() {
+		return nil
+	}
+
+	return statefulSetBuilder.capability.Properties().Replicas
+}
+
 func (statefulSetBuilder Builder) addLabels(sts *appsv1.StatefulSet) {
 	appLabels := statefulSetBuilder.buildAppLabels()
 	sts.Labels = appLabels.BuildLabels()
-	sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()}
+	sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: statefulSetBuilder.buildMatchLabels()}
 	sts.Spec.Template.Labels = maputils.MergeMap(statefulSetBuilder.capability.Properties().Labels, appLabels.BuildLabels())
 }
 
 func (statefulSetBuilder Builder) buildAppLabels() *labels.AppLabels {
 	version := statefulSetBuilder.dynakube.Status.ActiveGate.Version
 
-	return labels.NewAppLabels(labels.ActiveGateComponentLabel, statefulSetBuilder.dynakube.Name, consts.MultiActiveGateName, version)
+	return labels.NewAppLabels(labels.ActiveGateComponentLabel, statefulSetBuilder.dynakube.Name, capability.BuildComponentName(statefulSetBuilder.capability.GroupName()), version)
+}
+
+// buildMatchLabels includes the component for the ActiveGate groups, so their pods are distinguished from each other.
+// The selector of the default ActiveGate is kept as is, because it can't be changed on existing StatefulSets.
+func (statefulSetBuilder Builder) buildMatchLabels() map[string]string {
+	appLabels := statefulSetBuilder.buildAppLabels()
+	matchLabels := appLabels.BuildMatchLabels()
+
+	if statefulSetBuilder.isGroup() {
+		matchLabels[labels.AppComponentLabel] = appLabels.Component
+	}
+
+	return matchLabels
+}
+
+func (statefulSetBuilder Builder) isGroup() bool {
+	return statefulSetBuilder.capability.GroupName() != ""
 }
 
 func (statefulSetBuilder Builder) addUserAnnotations(sts *appsv1.StatefulSet) {
@@ -148,7 +180,7 @@ func (statefulSetBuilder Builder) buildVolumes() []corev1.Volume {
 	volumes := []corev1.Volume{}
 
 	if statefulSetBuilder.dynakube.Spec.ActiveGate.VolumeClaimTemplate == nil {
-		if !isDefaultPVCNeeded(statefulSetBuilder.dynakube) {
+		if !statefulSetBuilder.isDefaultPVCNeeded() {
 			volumes = append(volumes, corev1.Volume{
 				Name: consts.GatewayTmpVolumeName,
 				VolumeSource: corev1.VolumeSource{
@@ -187,20 +219,20 @@ func (statefulSetBuilder Builder) buildPodSecurityContext() *corev1.PodSecurityC
 }
 
 func (statefulSetBuilder Builder) defaultTopologyConstraints() []corev1.TopologySpreadConstraint {
-	appLabels := statefulSetBuilder.buildAppLabels()
+	matchLabels := statefulSetBuilder.buildMatchLabels()
 
 	return []corev1.TopologySpreadConstraint{
 		{
 			MaxSkew:           1,
 			TopologyKey:       "topology.kubernetes.io/zone",
 			WhenUnsatisfiable: "ScheduleAnyway",
-			LabelSelector:     &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()},
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: matchLabels},
 		},
 		{
 			MaxSkew:           1,
 			TopologyKey:       "kubernetes.io/hostname",
 			WhenUnsatisfiable: "DoNotSchedule",
-			LabelSelector:     &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()},
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: matchLabels},
 		},
 	}
 }
@@ -277,8 +309,9 @@ func (statefulSetBuilder Builder) nodeAffinity() *corev1.Affinity {
 	return &affinity
 }
 
-func isDefaultPVCNeeded(dk dynakube.DynaKube) bool {
-	return dk.TelemetryIngest().IsEnabled() && !dk.Spec.ActiveGate.UseEphemeralVolume
+// isDefaultPVCNeeded is only true for the default ActiveGate, as telemetry ingest isn't part of the ActiveGate groups.
+func (statefulSetBuilder Builder) isDefaultPVCNeeded() bool {
+	return statefulSetBuilder.dynakube.TelemetryIngest().IsEnabled() && !statefulSetBuilder.dynakube.Spec.ActiveGate.UseEphemeralVolume && !statefulSetBuilder.isGroup()
 }
 
 func (statefulSetBuilder Builder) addPersistentVolumeClaim(sts *appsv1.StatefulSet) {
@@ -293,7 +326,7 @@ func (statefulSetBuilder Builder) addPersistentVolumeClaim(sts *appsv1.StatefulS
 			},
 		}
 		sts.Spec.PersistentVolumeClaimRetentionPolicy = defaultPVCRetentionPolicy()
-	} else if isDefaultPVCNeeded(statefulSetBuilder.dynakube) {
+	} else if statefulSetBuilder.isDefaultPVCNeeded() {
 		sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
 			{
 				ObjectMeta: metav1.ObjectMeta{
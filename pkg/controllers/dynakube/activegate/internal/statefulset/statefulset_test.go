@@ -177,6 +177,16 @@ func TestGetBaseSpec(t *testing.T) {
 		assert.Equal(t, testConfigHash, stsSpec.Template.Annotations[consts.AnnotationActiveGateConfigurationHash])
 		assert.Equal(t, testTokenHash, stsSpec.Template.Annotations[consts.AnnotationActiveGateTenantTokenHash])
 	})
+	t.Run("replicas are left to the HorizontalPodAutoscaler if autoscaling is enabled", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.ActiveGate.Autoscaling = &activegate.AutoscalingSpec{MaxReplicas: 5}
+		multiCapability := capability.NewMultiCapability(&dk)
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, multiCapability)
+
+		stsSpec := builder.getBaseSpec()
+
+		assert.Nil(t, stsSpec.Replicas)
+	})
 }
 
 func TestAddLabels(t *testing.T) {
@@ -677,3 +687,49 @@ func TestTerminationGracePeriodSecondsNil(t *testing.T) {
 		assert.Nil(t, dk.ActiveGate().GetTerminationGracePeriodSeconds())
 	})
 }
+
+func TestGroupStatefulSet(t *testing.T) {
+	group := activegate.GroupSpec{
+		Name:         "routing",
+		Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+		Replicas:     ptr.To(int32(3)),
+	}
+
+	t.Run("group => own name and selector", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{group}
+		statefulsetBuilder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, capability.NewGroupCapability(&dk, group))
+
+		sts, err := statefulsetBuilder.CreateStatefulSet(nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, testDynakubeName+"-activegate-routing", sts.Name)
+		assert.Equal(t, "activegate-routing", sts.Spec.Selector.MatchLabels[labels.AppComponentLabel])
+		assert.Equal(t, "activegate-routing", sts.Spec.Template.Labels[labels.AppComponentLabel])
+		assert.Equal(t, int32(3), *sts.Spec.Replicas)
+	})
+	t.Run("group => replicas kept and no default PVC", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{group}
+		dk.Spec.ActiveGate.Autoscaling = &activegate.AutoscalingSpec{MaxReplicas: 5}
+		dk.Spec.TelemetryIngest = &telemetryingest.Spec{}
+		statefulsetBuilder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, capability.NewGroupCapability(&dk, group))
+
+		sts, err := statefulsetBuilder.CreateStatefulSet(nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(3), *sts.Spec.Replicas)
+		assert.Empty(t, sts.Spec.VolumeClaimTemplates)
+	})
+	t.Run("default ActiveGate => selector unchanged", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{group}
+		statefulsetBuilder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, capability.NewMultiCapability(&dk))
+
+		sts, err := statefulsetBuilder.CreateStatefulSet(nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, testDynakubeName+"-activegate", sts.Name)
+		assert.NotContains(t, sts.Spec.Selector.MatchLabels, labels.AppComponentLabel)
+	})
+}
@@ -3,10 +3,13 @@ package tls
 import (
 	"context"
 	"crypto/x509"
+	"encoding/pem"
 	"net"
+	"slices"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/certificates"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	k8slabels "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
@@ -61,7 +64,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 func (r *Reconciler) reconcileSelfSignedTLSSecret(ctx context.Context) error {
 	query := k8ssecret.Query(r.client, r.client, log)
 
-	_, err := query.Get(ctx, types.NamespacedName{
+	secret, err := query.Get(ctx, types.NamespacedName{
 		Name:      r.dk.ActiveGate().GetTLSSecretName(),
 		Namespace: r.dk.Namespace,
 	})
@@ -76,9 +79,88 @@ func (r *Reconciler) reconcileSelfSignedTLSSecret(ctx context.Context) error {
 		return err
 	}
 
+	if r.isCertificateOutdated(secret) {
+		log.Info("self-signed ActiveGate TLS certificate doesn't cover all ActiveGate services, creating new one")
+
+		err = query.Delete(ctx, secret)
+		if err != nil {
+			conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
+		return r.createSelfSignedTLSSecret(ctx)
+	}
+
 	return nil
 }
 
+// isCertificateOutdated checks if the certificate misses names or IPs of the Services of the ActiveGate groups.
+// Only the groups are checked, so certificates that were created before the groups existed are only replaced once groups are configured.
+// Secrets without a parsable certificate are left as they are.
+func (r *Reconciler) isCertificateOutdated(secret *corev1.Secret) bool {
+	block, _ := pem.Decode(secret.Data[consts.TLSCrtDataName])
+	if block == nil {
+		return false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	for _, dnsName := range r.buildGroupAltNames() {
+		if !slices.Contains(cert.DNSNames, dnsName) {
+			return true
+		}
+	}
+
+	ipAddresses, err := getCertificateAltIPs(r.getGroupServiceIPs())
+	if err != nil {
+		return false
+	}
+
+	for _, ip := range ipAddresses {
+		if !slices.ContainsFunc(cert.IPAddresses, ip.Equal) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Reconciler) buildCertificateAltNames() []string {
+	altNames := certificates.AltNames(r.dk.Name, r.dk.Namespace, activeGateSelfSignedTLSCommonNameSuffix)
+
+	return append(altNames, r.buildGroupAltNames()...)
+}
+
+func (r *Reconciler) buildGroupAltNames() []string {
+	var altNames []string
+
+	for _, group := range r.dk.Spec.ActiveGate.Groups {
+		altNames = append(altNames, certificates.AltNames(r.dk.Name, r.dk.Namespace, capability.BuildComponentName(group.Name))...)
+	}
+
+	return altNames
+}
+
+func (r *Reconciler) getServiceIPs() []string {
+	serviceIPs := slices.Clone(r.dk.Status.ActiveGate.ServiceIPs)
+
+	return append(serviceIPs, r.getGroupServiceIPs()...)
+}
+
+func (r *Reconciler) getGroupServiceIPs() []string {
+	var serviceIPs []string
+
+	for _, group := range r.dk.Status.ActiveGate.Groups {
+		serviceIPs = append(serviceIPs, group.ServiceIPs...)
+	}
+
+	return serviceIPs
+}
+
 func (r *Reconciler) deleteSelfSignedTLSSecret(ctx context.Context) error {
 	query := k8ssecret.Query(r.client, r.client, log)
 
@@ -98,12 +180,12 @@ func (r *Reconciler) createSelfSignedTLSSecret(ctx context.Context) error {
 		return err
 	}
 
-	cert.Cert.DNSNames = certificates.AltNames(r.dk.Name, r.dk.Namespace, activeGateSelfSignedTLSCommonNameSuffix)
+	cert.Cert.DNSNames = r.buildCertificateAltNames()
 	cert.Cert.KeyUsage = x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment
 	cert.Cert.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
 	cert.Cert.Subject.CommonName = certificates.CommonName(r.dk.Name, r.dk.Namespace, activeGateSelfSignedTLSCommonNameSuffix)
 
-	ipAddresses, err := getCertificateAltIPs(r.dk.Status.ActiveGate.ServiceIPs)
+	ipAddresses, err := getCertificateAltIPs(r.getServiceIPs())
 	if err != nil {
 		conditions.SetSecretGenFailed(r.dk.Conditions(), conditionType, err)
 
@@ -2,6 +2,8 @@ package tls
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"testing"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -129,4 +132,96 @@ func TestReconciler_Reconcile(t *testing.T) {
 		assert.Equal(t, conditions.SecretCreatedReason, condition.Reason)
 		assert.Equal(t, fmt.Sprintf("%s created", agTLSSecret.Name), condition.Message)
 	})
+
+	t.Run(`ActiveGate groups => certificate covers their services`, func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testDynakubeName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.KubeMonCapability.DisplayName,
+					},
+				},
+			},
+			Status: dynakube.DynaKubeStatus{
+				ActiveGate: activegate.Status{
+					ServiceIPs: []string{"10.0.0.1"},
+				},
+			},
+		}
+		fakeClient := fake.NewClient()
+		r := NewReconciler(fakeClient, fakeClient, dk)
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		cert := getCertificate(t, r)
+		assert.NotContains(t, cert.DNSNames, testDynakubeName+"-activegate-routing."+testNamespace)
+
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+		}
+		dk.Status.ActiveGate.SetServiceIPs("routing", []string{"10.0.0.2"})
+
+		err = r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		cert = getCertificate(t, r)
+		assert.Contains(t, cert.DNSNames, testDynakubeName+"-activegate."+testNamespace)
+		assert.Contains(t, cert.DNSNames, testDynakubeName+"-activegate-routing."+testNamespace)
+		require.Len(t, cert.IPAddresses, 2)
+		assert.Equal(t, "10.0.0.1", cert.IPAddresses[0].String())
+		assert.Equal(t, "10.0.0.2", cert.IPAddresses[1].String())
+	})
+	t.Run(`no ActiveGate groups => certificate is kept when the default service IPs change`, func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testDynakubeName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.KubeMonCapability.DisplayName,
+					},
+				},
+			},
+			Status: dynakube.DynaKubeStatus{
+				ActiveGate: activegate.Status{
+					ServiceIPs: []string{"10.0.0.1"},
+				},
+			},
+		}
+		fakeClient := fake.NewClient()
+		r := NewReconciler(fakeClient, fakeClient, dk)
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		dk.Status.ActiveGate.ServiceIPs = []string{"10.0.0.3"}
+
+		err = r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		cert := getCertificate(t, r)
+		require.Len(t, cert.IPAddresses, 1)
+		assert.Equal(t, "10.0.0.1", cert.IPAddresses[0].String())
+	})
+}
+
+func getCertificate(t *testing.T, r *Reconciler) *x509.Certificate {
+	t.Helper()
+
+	agTLSSecret := corev1.Secret{}
+	err := r.client.Get(context.Background(), client.ObjectKey{Name: r.dk.ActiveGate().GetTLSSecretName(), Namespace: r.dk.Namespace}, &agTLSSecret)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(agTLSSecret.Data[consts.TLSCrtDataName])
+	require.NotNil(t, block)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert
 }
@@ -2,8 +2,11 @@ package activegate
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
@@ -11,6 +14,8 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/authtoken"
 	capabilityInternal "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/hpa"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/pdb"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/tls"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
@@ -20,6 +25,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/version"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/configmap"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -123,16 +129,19 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 
 	agCapability := capability.NewMultiCapability(r.dk)
 	if agCapability.Enabled() {
-		return r.createCapability(ctx, agCapability)
+		err = r.createCapability(ctx, agCapability)
+		if err != nil {
+			return err
+		}
 	} else {
 		if err := r.deleteCapability(ctx); err != nil {
 			return err
 		}
+		// TODO: move cleanup to ActiveGate reconciler
+		meta.RemoveStatusCondition(r.dk.Conditions(), statefulset.ActiveGateStatefulSetConditionType)
 	}
-	// TODO: move cleanup to ActiveGate reconciler
-	meta.RemoveStatusCondition(r.dk.Conditions(), statefulset.ActiveGateStatefulSetConditionType)
 
-	return nil
+	return r.reconcileGroups(ctx)
 }
 
 func (r *Reconciler) createActiveGateTenantConnectionInfoConfigMap(ctx context.Context) error {
@@ -178,6 +187,20 @@ func extractPublicData(dk *dynakube.DynaKube) map[string]string {
 }
 
 func (r *Reconciler) createCapability(ctx context.Context, agCapability capability.Capability) error {
+	err := r.reconcileCapability(ctx, agCapability)
+	if err != nil {
+		return err
+	}
+
+	err = hpa.NewReconciler(r.client, r.apiReader, r.dk).Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+
+	return pdb.NewReconciler(r.client, r.apiReader, r.dk).Reconcile(ctx)
+}
+
+func (r *Reconciler) reconcileCapability(ctx context.Context, agCapability capability.Capability) error {
 	customPropertiesReconciler := r.newCustomPropertiesReconcilerFunc(r.dk.ActiveGate().GetServiceAccountOwner(), agCapability.Properties().CustomProperties) //nolint:typeCheck
 	statefulsetReconciler := r.newStatefulsetReconcilerFunc(r.client, r.apiReader, r.dk, agCapability)                                                        //nolint:typeCheck
 	tlsSecretReconciler := tls.NewReconciler(r.client, r.apiReader, r.dk)
@@ -187,22 +210,126 @@ func (r *Reconciler) createCapability(ctx context.Context, agCapability capabili
 	return capabilityReconciler.Reconcile(ctx)
 }
 
+// reconcileGroups deploys a separate StatefulSet and Service for each ActiveGate group,
+// and deletes the ones of the groups that were removed from the DynaKube.
+func (r *Reconciler) reconcileGroups(ctx context.Context) error {
+	desiredGroups := map[string]bool{}
+	for _, group := range r.dk.Spec.ActiveGate.Groups {
+		desiredGroups[group.Name] = true
+	}
+
+	var staleGroups []string
+
+	r.dk.Status.ActiveGate.Groups = slices.DeleteFunc(r.dk.Status.ActiveGate.Groups, func(groupStatus activegate.GroupStatus) bool {
+		if desiredGroups[groupStatus.Name] {
+			return false
+		}
+
+		staleGroups = append(staleGroups, groupStatus.Name)
+
+		return true
+	})
+
+	orphanedGroups, err := r.findOrphanedGroups(ctx, desiredGroups)
+	if err != nil {
+		return err
+	}
+
+	for _, groupName := range orphanedGroups {
+		if !slices.Contains(staleGroups, groupName) {
+			staleGroups = append(staleGroups, groupName)
+		}
+	}
+
+	for _, groupName := range staleGroups {
+		log.Info("deleting removed ActiveGate group", "group", groupName)
+
+		if err := r.deleteStatefulset(ctx, groupName); err != nil {
+			return err
+		}
+
+		if err := r.deleteService(ctx, groupName); err != nil {
+			return err
+		}
+
+		meta.RemoveStatusCondition(r.dk.Conditions(), statefulset.GetConditionType(groupName))
+	}
+
+	for _, groupCapability := range capability.NewGroupCapabilities(r.dk) {
+		err := r.reconcileCapability(ctx, groupCapability)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to reconcile ActiveGate group '%s'", groupCapability.GroupName())
+		}
+	}
+
+	return nil
+}
+
+// findOrphanedGroups returns the groups that still have a StatefulSet or Service in the cluster but are no longer in the DynaKube,
+// so they are deleted even if the status of the DynaKube doesn't know them anymore.
+func (r *Reconciler) findOrphanedGroups(ctx context.Context, desiredGroups map[string]bool) ([]string, error) {
+	var statefulSets appsv1.StatefulSetList
+
+	err := r.apiReader.List(ctx, &statefulSets, client.InNamespace(r.dk.Namespace), client.MatchingLabels(labels.NewAppLabels(labels.ActiveGateComponentLabel, r.dk.Name, "", "").BuildMatchLabels()))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var services corev1.ServiceList
+
+	err = r.apiReader.List(ctx, &services, client.InNamespace(r.dk.Namespace), client.MatchingLabels(labels.NewCoreLabels(r.dk.Name, labels.ActiveGateComponentLabel).BuildMatchLabels()))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var orphanedGroups []string
+
+	addOrphanedGroup := func(objectName, groupPrefix string) {
+		if !strings.HasPrefix(objectName, groupPrefix) {
+			return
+		}
+
+		groupName := strings.TrimPrefix(objectName, groupPrefix)
+		if !desiredGroups[groupName] && !slices.Contains(orphanedGroups, groupName) {
+			orphanedGroups = append(orphanedGroups, groupName)
+		}
+	}
+
+	for _, sts := range statefulSets.Items {
+		addOrphanedGroup(sts.Name, capability.CalculateStatefulSetName(r.dk.Name)+"-")
+	}
+
+	for _, svc := range services.Items {
+		addOrphanedGroup(svc.Name, capability.BuildServiceName(r.dk.Name)+"-")
+	}
+
+	return orphanedGroups, nil
+}
+
 func (r *Reconciler) deleteCapability(ctx context.Context) error {
-	if err := r.deleteStatefulset(ctx); err != nil {
+	if err := hpa.NewReconciler(r.client, r.apiReader, r.dk).Reconcile(ctx); err != nil {
+		return err
+	}
+
+	if err := pdb.NewReconciler(r.client, r.apiReader, r.dk).Reconcile(ctx); err != nil {
+		return err
+	}
+
+	if err := r.deleteStatefulset(ctx, ""); err != nil {
 		return err
 	}
 
-	if err := r.deleteService(ctx); err != nil {
+	if err := r.deleteService(ctx, ""); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *Reconciler) deleteService(ctx context.Context) error {
+func (r *Reconciler) deleteService(ctx context.Context, groupName string) error {
 	svc := corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      capability.BuildServiceName(r.dk.Name),
+			Name:      capability.BuildGroupServiceName(r.dk.Name, groupName),
 			Namespace: r.dk.Namespace,
 		},
 	}
@@ -210,10 +337,10 @@ func (r *Reconciler) deleteService(ctx context.Context) error {
 	return client.IgnoreNotFound(r.client.Delete(ctx, &svc))
 }
 
-func (r *Reconciler) deleteStatefulset(ctx context.Context) error {
+func (r *Reconciler) deleteStatefulset(ctx context.Context, groupName string) error {
 	sts := appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      capability.CalculateStatefulSetName(r.dk.Name),
+			Name:      capability.CalculateGroupStatefulSetName(r.dk.Name, groupName),
 			Namespace: r.dk.Namespace,
 		},
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dtpullsecret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/version"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	controllermock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers"
 	istiomock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers/dynakube/istio"
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -131,6 +133,134 @@ func TestReconciler_Reconcile(t *testing.T) {
 		err = fakeClient.Get(context.Background(), types.NamespacedName{Name: testServiceName, Namespace: testNamespace}, &service)
 		assert.True(t, k8serrors.IsNotFound(err))
 	})
+	t.Run(`Create AG groups (creation and deletion)`, func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+					Groups: []activegate.GroupSpec{
+						{
+							Name:         "routing",
+							Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+							Replicas:     ptr.To(int32(3)),
+						},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClient(testKubeSystemNamespace)
+		dtc := createMockDtClient(t, true)
+		dtc.On("GetActiveGateAuthToken", mock.AnythingOfType("context.backgroundCtx"), testName+"-routing").Return(&dtclient.ActiveGateAuthTokenInfo{TokenID: "test", Token: "dt.some.valuegoeshere"}, nil)
+
+		r := NewReconciler(fakeClient, fakeClient, dk, dtc, nil, nil).(*Reconciler)
+		r.connectionReconciler = createGenericReconcilerMock(t)
+		r.versionReconciler = createVersionReconcilerMock(t)
+		r.pullSecretReconciler = createGenericReconcilerMock(t)
+
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		var statefulSet appsv1.StatefulSet
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate", Namespace: testNamespace}, &statefulSet)
+		require.NoError(t, err)
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate-routing", Namespace: testNamespace}, &statefulSet)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+		assert.Equal(t, "activegate-routing", statefulSet.Spec.Selector.MatchLabels[labels.AppComponentLabel])
+
+		var service corev1.Service
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testServiceName + "-routing", Namespace: testNamespace}, &service)
+		require.NoError(t, err)
+		assert.Equal(t, "activegate-routing", service.Spec.Selector[labels.AppComponentLabel])
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testServiceName, Namespace: testNamespace}, &service)
+		require.NoError(t, err)
+		assert.Equal(t, "activegate", service.Spec.Selector[labels.AppComponentLabel])
+
+		require.Len(t, dk.Status.ActiveGate.Groups, 1)
+		assert.Equal(t, "routing", dk.Status.ActiveGate.Groups[0].Name)
+		assert.NotNil(t, meta.FindStatusCondition(dk.Status.Conditions, "ActiveGateStatefulSet-routing"))
+
+		// remove group from spec
+		dk.Spec.ActiveGate.Groups = nil
+		r.connectionReconciler = createGenericReconcilerMock(t)
+		r.versionReconciler = createVersionReconcilerMock(t)
+		r.pullSecretReconciler = createGenericReconcilerMock(t)
+		err = r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate-routing", Namespace: testNamespace}, &statefulSet)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testServiceName + "-routing", Namespace: testNamespace}, &service)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate", Namespace: testNamespace}, &statefulSet)
+		require.NoError(t, err)
+		assert.Empty(t, dk.Status.ActiveGate.Groups)
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, "ActiveGateStatefulSet-routing"))
+	})
+	t.Run(`Delete AG groups that are unknown to the status`, func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+				},
+			},
+		}
+		orphanedStatefulSet := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName + "-activegate-old",
+				Namespace: testNamespace,
+				Labels:    labels.NewAppLabels(labels.ActiveGateComponentLabel, testName, "activegate-old", "").BuildLabels(),
+			},
+		}
+		orphanedService := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testServiceName + "-old",
+				Namespace: testNamespace,
+				Labels:    labels.NewCoreLabels(testName, labels.ActiveGateComponentLabel).BuildLabels(),
+			},
+		}
+		unrelatedStatefulSet := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName + "-activegate-unrelated",
+				Namespace: testNamespace,
+			},
+		}
+		fakeClient := fake.NewClient(testKubeSystemNamespace, orphanedStatefulSet, orphanedService, unrelatedStatefulSet)
+
+		r := NewReconciler(fakeClient, fakeClient, dk, createMockDtClient(t, true), nil, nil).(*Reconciler)
+		r.connectionReconciler = createGenericReconcilerMock(t)
+		r.versionReconciler = createVersionReconcilerMock(t)
+		r.pullSecretReconciler = createGenericReconcilerMock(t)
+
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		var statefulSet appsv1.StatefulSet
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate-old", Namespace: testNamespace}, &statefulSet)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		var service corev1.Service
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testServiceName + "-old", Namespace: testNamespace}, &service)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate-unrelated", Namespace: testNamespace}, &statefulSet)
+		require.NoError(t, err)
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate", Namespace: testNamespace}, &statefulSet)
+		require.NoError(t, err)
+	})
 	t.Run("Reconcile DynaKube without Proxy after a DynaKube with proxy must not interfere with the second DKs Proxy Secret", func(t *testing.T) { // TODO: This is not a unit test, it tests the functionality of another package, it should use a mock for that
 		dkWithProxy := &dynakube.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
@@ -44,7 +44,7 @@ func TestReconcileActiveGate(t *testing.T) {
 			activeGateReconcilerBuilder: createActivegateReconcilerBuilder(mockActiveGateReconciler),
 		}
 
-		err := controller.reconcileActiveGate(ctx, dk, nil, nil)
+		err := controller.reconcileActiveGate(ctx, dk, nil, nil, nil)
 		require.NoError(t, err)
 	})
 	t.Run("no active-gate configured => active-gate reconcile returns error => returns error", func(t *testing.T) {
@@ -62,7 +62,7 @@ func TestReconcileActiveGate(t *testing.T) {
 			activeGateReconcilerBuilder: createActivegateReconcilerBuilder(mockActiveGateReconciler),
 		}
 
-		err := controller.reconcileActiveGate(ctx, dk, nil, nil)
+		err := controller.reconcileActiveGate(ctx, dk, nil, nil, nil)
 		require.Error(t, err)
 		require.Equal(t, "failed to reconcile ActiveGate: BOOM", err.Error())
 	})
@@ -104,7 +104,7 @@ func TestReconcileActiveGate(t *testing.T) {
 		}
 
 		mockClient := createDTMockClient(t, dtclient.TokenScopes{}, dtclient.TokenScopes{})
-		err := controller.reconcileActiveGate(ctx, dk, mockClient, nil)
+		err := controller.reconcileActiveGate(ctx, dk, mockClient, nil, nil)
 		require.NoError(t, err)
 
 		mockAPIMonitoringReconciler.AssertNotCalled(t, "Reconcile", mock.Anything)
@@ -147,7 +147,7 @@ func TestReconcileActiveGate(t *testing.T) {
 			apiMonitoringReconcilerBuilder: createAPIMonitoringReconcilerBuilder(mockAPIMonitoringReconciler),
 		}
 
-		err := controller.reconcileActiveGate(ctx, dk, mockClient, nil)
+		err := controller.reconcileActiveGate(ctx, dk, mockClient, nil, nil)
 		require.NoError(t, err)
 
 		mockAPIMonitoringReconciler.AssertCalled(t, "Reconcile", mock.Anything)
@@ -196,7 +196,7 @@ func TestReconcileActiveGate(t *testing.T) {
 			apiMonitoringReconcilerBuilder: apimonitoring.NewReconciler,
 		}
 
-		err := controller.reconcileActiveGate(ctx, dk, mockClient, nil)
+		err := controller.reconcileActiveGate(ctx, dk, mockClient, nil, nil)
 		require.NoError(t, err)
 		mockClient.AssertCalled(t, "CreateOrUpdateKubernetesSetting",
 			mock.AnythingOfType("context.backgroundCtx"),
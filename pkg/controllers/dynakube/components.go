@@ -0,0 +1,232 @@
+package dynakube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	statefulSetKind = "StatefulSet"
+	daemonSetKind   = "DaemonSet"
+)
+
+// componentObserver returns the observed state of a component, or false if the component is not enabled for the DynaKube.
+type componentObserver func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool)
+
+// updateComponentsStatus observes the workloads of all enabled components and rolls them up into the status of the DynaKube.
+func (controller *Controller) updateComponentsStatus(ctx context.Context, dk *dynakube.DynaKube) {
+	observers := []componentObserver{
+		controller.observeOneAgent,
+		controller.observeActiveGate,
+		controller.observeExtensionsExecutionController,
+		controller.observeExtensionsCollector,
+		controller.observeLogAgent,
+		controller.observeKSPM,
+		controller.observeCSIDriver,
+	}
+
+	components := make([]dynakube.ComponentStatus, 0, len(observers))
+
+	for _, observe := range observers {
+		if component, ok := observe(ctx, dk); ok {
+			components = append(components, component)
+		}
+	}
+
+	components = append(components, controller.observeActiveGateGroups(ctx, dk)...)
+
+	dk.Status.SetComponents(components)
+}
+
+func (controller *Controller) observeOneAgent(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool) {
+	if !dk.OneAgent().IsDaemonsetRequired() {
+		return dynakube.ComponentStatus{}, false
+	}
+
+	component := controller.observeDaemonSet(ctx, dynakube.OneAgentComponent, dk.Namespace, dk.OneAgent().GetDaemonsetName())
+	component.Version = dk.Status.OneAgent.Version
+
+	return component, true
+}
+
+func (controller *Controller) observeActiveGate(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool) {
+	if !dk.ActiveGate().IsDefaultEnabled() {
+		return dynakube.ComponentStatus{}, false
+	}
+
+	component := controller.observeStatefulSet(ctx, dynakube.ActiveGateComponent, dk.Namespace, capability.CalculateStatefulSetName(dk.Name))
+	component.Version = dk.Status.ActiveGate.Version
+
+	return component, true
+}
+
+// observeActiveGateGroups reports one component per ActiveGate group, as every group has its own StatefulSet.
+func (controller *Controller) observeActiveGateGroups(ctx context.Context, dk *dynakube.DynaKube) []dynakube.ComponentStatus {
+	components := make([]dynakube.ComponentStatus, 0, len(dk.Spec.ActiveGate.Groups))
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		name := dynakube.ComponentName(fmt.Sprintf("%s-%s", dynakube.ActiveGateComponent, group.Name))
+
+		component := controller.observeStatefulSet(ctx, name, dk.Namespace, capability.CalculateGroupStatefulSetName(dk.Name, group.Name))
+		component.Version = dk.Status.ActiveGate.Version
+
+		components = append(components, component)
+	}
+
+	return components
+}
+
+func (controller *Controller) observeExtensionsExecutionController(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool) {
+	if !dk.IsExtensionsEnabled() {
+		return dynakube.ComponentStatus{}, false
+	}
+
+	return controller.observeStatefulSet(ctx, dynakube.ExtensionsExecutionControllerComponent, dk.Namespace, dk.ExtensionsExecutionControllerStatefulsetName()), true
+}
+
+func (controller *Controller) observeExtensionsCollector(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool) {
+	if !dk.IsExtensionsEnabled() {
+		return dynakube.ComponentStatus{}, false
+	}
+
+	return controller.observeStatefulSet(ctx, dynakube.OtelCollectorComponent, dk.Namespace, dk.OtelCollectorStatefulsetName()), true
+}
+
+func (controller *Controller) observeLogAgent(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool) {
+	if !dk.LogMonitoring().IsStandalone() {
+		return dynakube.ComponentStatus{}, false
+	}
+
+	return controller.observeDaemonSet(ctx, dynakube.LogMonitoringComponent, dk.Namespace, dk.LogMonitoring().GetDaemonSetName()), true
+}
+
+func (controller *Controller) observeKSPM(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool) {
+	if !dk.KSPM().IsEnabled() {
+		return dynakube.ComponentStatus{}, false
+	}
+
+	return controller.observeDaemonSet(ctx, dynakube.KSPMComponent, dk.Namespace, dk.KSPM().GetDaemonSetName()), true
+}
+
+// observeCSIDriver reports the CSI driver for DynaKubes that inject code modules with it.
+// The CSI driver is deployed with the operator, so it lives in the namespace of the operator.
+func (controller *Controller) observeCSIDriver(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, bool) {
+	if !dk.OneAgent().IsCSIAvailable() || !(dk.OneAgent().IsCloudNativeFullstackMode() || dk.OneAgent().IsApplicationMonitoringMode()) {
+		return dynakube.ComponentStatus{}, false
+	}
+
+	return controller.observeDaemonSet(ctx, dynakube.CSIDriverComponent, controller.operatorNamespace, dtcsi.DaemonSetName), true
+}
+
+func (controller *Controller) observeStatefulSet(ctx context.Context, name dynakube.ComponentName, namespace, statefulSetName string) dynakube.ComponentStatus {
+	component := dynakube.ComponentStatus{Name: name, Kind: statefulSetKind}
+
+	statefulSet := &appsv1.StatefulSet{}
+
+	err := controller.client.Get(ctx, types.NamespacedName{Name: statefulSetName, Namespace: namespace}, statefulSet)
+	if err != nil {
+		return withLookupError(component, statefulSetName, err)
+	}
+
+	// This check is needed as in our unit tests replicas is always nil. We can't set it manually as this function
+	// is called from the same function where the statefulset is created
+	if statefulSet.Spec.Replicas != nil {
+		component.Desired = *statefulSet.Spec.Replicas
+	}
+
+	component.Ready = statefulSet.Status.ReadyReplicas
+	component.Updated = statefulSet.Status.UpdatedReplicas
+	component.Image = getImage(statefulSet.Spec.Template)
+
+	return withRolloutPhase(component, statefulSet.Generation, statefulSet.Status.ObservedGeneration)
+}
+
+func (controller *Controller) observeDaemonSet(ctx context.Context, name dynakube.ComponentName, namespace, daemonSetName string) dynakube.ComponentStatus {
+	component := dynakube.ComponentStatus{Name: name, Kind: daemonSetKind}
+
+	daemonSet := &appsv1.DaemonSet{}
+
+	err := controller.client.Get(ctx, types.NamespacedName{Name: daemonSetName, Namespace: namespace}, daemonSet)
+	if err != nil {
+		return withLookupError(component, daemonSetName, err)
+	}
+
+	component.Desired = daemonSet.Status.DesiredNumberScheduled
+	component.Ready = daemonSet.Status.NumberReady
+	component.Updated = daemonSet.Status.UpdatedNumberScheduled
+	component.Image = getImage(daemonSet.Spec.Template)
+
+	return withRolloutPhase(component, daemonSet.Generation, daemonSet.Status.ObservedGeneration)
+}
+
+func withLookupError(component dynakube.ComponentStatus, workloadName string, err error) dynakube.ComponentStatus {
+	if k8serrors.IsNotFound(err) {
+		log.Info("component not yet available", "component", component.Name, "kind", component.Kind, "name", workloadName)
+
+		component.Phase = status.Deploying
+		component.Message = fmt.Sprintf("%s %s not yet created", component.Kind, workloadName)
+
+		return component
+	}
+
+	log.Error(err, "component could not be accessed", "component", component.Name, "kind", component.Kind, "name", workloadName)
+
+	component.Phase = status.Error
+	component.Message = fmt.Sprintf("%s %s could not be accessed", component.Kind, workloadName)
+
+	return component
+}
+
+// withRolloutPhase derives the phase from the replica counts of the workload.
+// The counts are only meaningful once the workload controller observed the latest generation of the spec,
+// before that they still describe the previous pod template.
+func withRolloutPhase(component dynakube.ComponentStatus, generation, observedGeneration int64) dynakube.ComponentStatus {
+	if observedGeneration < generation {
+		log.Info("component spec change not yet observed", "component", component.Name, "generation", generation, "observedGeneration", observedGeneration)
+
+		component.Phase = status.Deploying
+		component.Message = fmt.Sprintf("generation %d of the %s not yet observed", generation, component.Kind)
+
+		return component
+	}
+
+	if component.Ready < component.Desired {
+		log.Info("component is still deploying", "component", component.Name, "ready", component.Ready, "desired", component.Desired)
+
+		component.Phase = status.Deploying
+		component.Message = fmt.Sprintf("%d of %d pods ready", component.Ready, component.Desired)
+
+		return component
+	}
+
+	// During a rolling update the pods of the previous revision are still ready, so the rollout is only done once all pods are updated.
+	if component.Updated < component.Desired {
+		log.Info("component is still rolling out", "component", component.Name, "updated", component.Updated, "desired", component.Desired)
+
+		component.Phase = status.Deploying
+		component.Message = fmt.Sprintf("%d of %d pods updated", component.Updated, component.Desired)
+
+		return component
+	}
+
+	component.Phase = status.Running
+
+	return component
+}
+
+func getImage(template corev1.PodTemplateSpec) string {
+	if len(template.Spec.Containers) == 0 {
+		return ""
+	}
+
+	return template.Spec.Containers[0].Image
+}
@@ -1,6 +1,7 @@
 package dynakube
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
@@ -11,8 +12,11 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -34,7 +38,7 @@ func TestActiveGatePhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("error accessing k8s api -> error", func(t *testing.T) {
@@ -43,7 +47,7 @@ func TestActiveGatePhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Error, phase)
 	})
 	t.Run("activegate pods not ready -> deploying", func(t *testing.T) {
@@ -59,7 +63,7 @@ func TestActiveGatePhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("activegate deployed -> running", func(t *testing.T) {
@@ -75,7 +79,42 @@ func TestActiveGatePhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
+		assert.Equal(t, status.Running, phase)
+	})
+	t.Run("activegate pods of previous revision ready during rolling update -> deploying", func(t *testing.T) {
+		activeGate := createStatefulset(testNamespace, "test-name-activegate", 3, 3)
+		activeGate.Status.UpdatedReplicas = 1
+
+		fakeClient := fake.NewClient(activeGate)
+
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+		phase := determinePhase(controller, dk)
+		assert.Equal(t, status.Deploying, phase)
+		assert.Equal(t, "1 of 3 pods updated", dk.Status.Components[0].Message)
+	})
+	t.Run("activegate spec change not yet observed by the statefulset controller -> deploying", func(t *testing.T) {
+		activeGate := createStatefulset(testNamespace, "test-name-activegate", 3, 3)
+		activeGate.Generation = 2
+		activeGate.Status.ObservedGeneration = 1
+
+		fakeClient := fake.NewClient(activeGate)
+
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+		phase := determinePhase(controller, dk)
+		assert.Equal(t, status.Deploying, phase)
+		assert.Equal(t, "generation 2 of the StatefulSet not yet observed", dk.Status.Components[0].Message)
+
+		activeGate.Status.ObservedGeneration = 2
+		require.NoError(t, fakeClient.Status().Update(context.Background(), activeGate))
+
+		phase = determinePhase(controller, dk)
 		assert.Equal(t, status.Running, phase)
 	})
 }
@@ -90,8 +129,9 @@ func createStatefulset(namespace, name string, replicas, readyReplicas int32) *a
 			Replicas: &replicas,
 		},
 		Status: appsv1.StatefulSetStatus{
-			Replicas:      replicas,
-			ReadyReplicas: readyReplicas,
+			Replicas:        replicas,
+			ReadyReplicas:   readyReplicas,
+			UpdatedReplicas: replicas,
 		},
 	}
 }
@@ -115,7 +155,7 @@ func TestOneAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("Error accessing k8s api", func(t *testing.T) {
@@ -124,7 +164,7 @@ func TestOneAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Error, phase)
 	})
 	t.Run("OneAgent daemonsets in cluster not all ready -> deploying", func(t *testing.T) {
@@ -133,7 +173,7 @@ func TestOneAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("OneAgent daemonsets in cluster all ready -> running", func(t *testing.T) {
@@ -142,9 +182,21 @@ func TestOneAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Running, phase)
 	})
+	t.Run("OneAgent daemonsets in cluster not all updated -> deploying", func(t *testing.T) {
+		oneAgent := createDaemonSet(testNamespace, "test-name-oneagent", 3, 3)
+		oneAgent.Status.UpdatedNumberScheduled = 2
+
+		fakeClient := fake.NewClient(oneAgent)
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+		phase := determinePhase(controller, dk)
+		assert.Equal(t, status.Deploying, phase)
+	})
 }
 
 func createDaemonSet(namespace, name string, replicas, readyReplicas int32) *appsv1.DaemonSet {
@@ -154,8 +206,10 @@ func createDaemonSet(namespace, name string, replicas, readyReplicas int32) *app
 			Namespace: namespace,
 		},
 		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: replicas,
 			CurrentNumberScheduled: replicas,
 			NumberReady:            readyReplicas,
+			UpdatedNumberScheduled: replicas,
 		},
 	}
 }
@@ -177,7 +231,7 @@ func TestExtensionsExecutionControllerPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsExecutionControllerPhase(dk)
+		phase := observePhase(controller.observeExtensionsExecutionController, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("error accessing k8s api -> error", func(t *testing.T) {
@@ -186,7 +240,7 @@ func TestExtensionsExecutionControllerPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsExecutionControllerPhase(dk)
+		phase := observePhase(controller.observeExtensionsExecutionController, dk)
 		assert.Equal(t, status.Error, phase)
 	})
 	t.Run("eec pods not ready -> deploying", func(t *testing.T) {
@@ -196,7 +250,7 @@ func TestExtensionsExecutionControllerPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsExecutionControllerPhase(dk)
+		phase := observePhase(controller.observeExtensionsExecutionController, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("eec deployed -> running", func(t *testing.T) {
@@ -206,7 +260,7 @@ func TestExtensionsExecutionControllerPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsExecutionControllerPhase(dk)
+		phase := observePhase(controller.observeExtensionsExecutionController, dk)
 		assert.Equal(t, status.Running, phase)
 	})
 }
@@ -228,7 +282,7 @@ func TestExtensionsCollectorPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsCollectorPhase(dk)
+		phase := observePhase(controller.observeExtensionsCollector, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("error accessing k8s api -> error", func(t *testing.T) {
@@ -237,7 +291,7 @@ func TestExtensionsCollectorPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsCollectorPhase(dk)
+		phase := observePhase(controller.observeExtensionsCollector, dk)
 		assert.Equal(t, status.Error, phase)
 	})
 	t.Run("otelc pods not ready -> deploying", func(t *testing.T) {
@@ -247,7 +301,7 @@ func TestExtensionsCollectorPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsCollectorPhase(dk)
+		phase := observePhase(controller.observeExtensionsCollector, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("otelc deployed -> running", func(t *testing.T) {
@@ -257,7 +311,7 @@ func TestExtensionsCollectorPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineExtensionsCollectorPhase(dk)
+		phase := observePhase(controller.observeExtensionsCollector, dk)
 		assert.Equal(t, status.Running, phase)
 	})
 }
@@ -287,7 +341,7 @@ func TestLogAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("Error accessing k8s api", func(t *testing.T) {
@@ -296,7 +350,7 @@ func TestLogAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Error, phase)
 	})
 	t.Run("LogAgent daemonsets in cluster not all ready -> deploying", func(t *testing.T) {
@@ -305,7 +359,7 @@ func TestLogAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("LogAgent daemonsets in cluster all ready -> running", func(t *testing.T) {
@@ -314,7 +368,7 @@ func TestLogAgentPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Running, phase)
 	})
 }
@@ -344,7 +398,7 @@ func TestKSPMPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("Error accessing k8s api", func(t *testing.T) {
@@ -353,7 +407,7 @@ func TestKSPMPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Error, phase)
 	})
 	t.Run("KSPM daemonsets in cluster not all ready -> deploying", func(t *testing.T) {
@@ -362,7 +416,7 @@ func TestKSPMPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Deploying, phase)
 	})
 	t.Run("KSPM daemonsets in cluster all ready -> running", func(t *testing.T) {
@@ -371,7 +425,7 @@ func TestKSPMPhaseChanges(t *testing.T) {
 			client:    fakeClient,
 			apiReader: fakeClient,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, status.Running, phase)
 	})
 }
@@ -491,7 +545,162 @@ func TestDynakubePhaseChanges(t *testing.T) {
 			client:    test.clt,
 			apiReader: test.clt,
 		}
-		phase := controller.determineDynaKubePhase(dk)
+		phase := determinePhase(controller, dk)
 		assert.Equal(t, test.phase, phase, "failed", "testcase", i)
 	}
 }
+
+func TestComponentsStatus(t *testing.T) {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			OneAgent: oneagent.Spec{
+				CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{},
+			},
+			ActiveGate: activegate.Spec{Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName}},
+		},
+		Status: dynakube.DynaKubeStatus{
+			OneAgent:   oneagent.Status{VersionStatus: status.VersionStatus{Version: "1.2.3"}},
+			ActiveGate: activegate.Status{VersionStatus: status.VersionStatus{Version: "1.300.0"}},
+		},
+	}
+
+	activeGate := createStatefulset(testNamespace, "test-name-activegate", 2, 1)
+	activeGate.Status.UpdatedReplicas = 2
+	activeGate.Spec.Template.Spec.Containers = []corev1.Container{{Image: "activegate:1.300.0"}}
+
+	oneAgent := createDaemonSet(testNamespace, "test-name-oneagent", 3, 3)
+	oneAgent.Status.UpdatedNumberScheduled = 3
+	oneAgent.Spec.Template.Spec.Containers = []corev1.Container{{Image: "oneagent:1.2.3"}}
+
+	t.Run("reports the rollout state of every enabled component", func(t *testing.T) {
+		installconfig.SetModulesOverride(t, installconfig.Modules{CSIDriver: true})
+
+		fakeClient := fake.NewClient(activeGate, oneAgent)
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+
+		controller.updateComponentsStatus(context.Background(), dk)
+
+		require.Len(t, dk.Status.Components, 3)
+
+		assert.Equal(t, dynakube.OneAgentComponent, dk.Status.Components[0].Name)
+		assert.Equal(t, daemonSetKind, dk.Status.Components[0].Kind)
+		assert.Equal(t, status.Running, dk.Status.Components[0].Phase)
+		assert.Equal(t, "oneagent:1.2.3", dk.Status.Components[0].Image)
+		assert.Equal(t, "1.2.3", dk.Status.Components[0].Version)
+		assert.Equal(t, int32(3), dk.Status.Components[0].Desired)
+		assert.Equal(t, int32(3), dk.Status.Components[0].Ready)
+		assert.Equal(t, int32(3), dk.Status.Components[0].Updated)
+
+		assert.Equal(t, dynakube.ActiveGateComponent, dk.Status.Components[1].Name)
+		assert.Equal(t, statefulSetKind, dk.Status.Components[1].Kind)
+		assert.Equal(t, status.Deploying, dk.Status.Components[1].Phase)
+		assert.Equal(t, "1 of 2 pods ready", dk.Status.Components[1].Message)
+		assert.Equal(t, "activegate:1.300.0", dk.Status.Components[1].Image)
+		assert.Equal(t, "1.300.0", dk.Status.Components[1].Version)
+		assert.Equal(t, int32(2), dk.Status.Components[1].Updated)
+
+		assert.Equal(t, dynakube.CSIDriverComponent, dk.Status.Components[2].Name)
+		assert.Equal(t, status.Deploying, dk.Status.Components[2].Phase)
+		assert.Equal(t, "DaemonSet dynatrace-oneagent-csi-driver not yet created", dk.Status.Components[2].Message)
+
+		assert.Equal(t, status.Deploying, dk.Status.ComponentsPhase())
+	})
+	t.Run("csi driver is observed in the operator namespace", func(t *testing.T) {
+		installconfig.SetModulesOverride(t, installconfig.Modules{CSIDriver: true})
+
+		csiDriver := createDaemonSet("operator-namespace", "dynatrace-oneagent-csi-driver", 2, 2)
+		fakeClient := fake.NewClient(activeGate, oneAgent, csiDriver)
+		controller := &Controller{
+			client:            fakeClient,
+			apiReader:         fakeClient,
+			operatorNamespace: "operator-namespace",
+		}
+
+		controller.updateComponentsStatus(context.Background(), dk)
+
+		require.Len(t, dk.Status.Components, 3)
+		assert.Equal(t, dynakube.CSIDriverComponent, dk.Status.Components[2].Name)
+		assert.Equal(t, status.Running, dk.Status.Components[2].Phase)
+		assert.Equal(t, int32(2), dk.Status.Components[2].Ready)
+	})
+	t.Run("csi driver is not reported if not installed", func(t *testing.T) {
+		installconfig.SetModulesOverride(t, installconfig.Modules{CSIDriver: false})
+
+		fakeClient := fake.NewClient(activeGate, oneAgent)
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+
+		controller.updateComponentsStatus(context.Background(), dk)
+
+		require.Len(t, dk.Status.Components, 2)
+		assert.Equal(t, dynakube.OneAgentComponent, dk.Status.Components[0].Name)
+		assert.Equal(t, dynakube.ActiveGateComponent, dk.Status.Components[1].Name)
+	})
+}
+
+func TestActiveGateGroupsComponentsStatus(t *testing.T) {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			ActiveGate: activegate.Spec{
+				Groups: []activegate.GroupSpec{
+					{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+					{Name: "kubemon", Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName}},
+				},
+			},
+		},
+		Status: dynakube.DynaKubeStatus{
+			ActiveGate: activegate.Status{VersionStatus: status.VersionStatus{Version: "1.300.0"}},
+		},
+	}
+
+	t.Run("reports a component per group if only groups are configured", func(t *testing.T) {
+		routing := createStatefulset(testNamespace, "test-name-activegate-routing", 2, 2)
+		routing.Status.UpdatedReplicas = 2
+
+		fakeClient := fake.NewClient(routing)
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+
+		controller.updateComponentsStatus(context.Background(), dk)
+
+		require.Len(t, dk.Status.Components, 2)
+
+		assert.Equal(t, dynakube.ActiveGateComponent+"-routing", dk.Status.Components[0].Name)
+		assert.Equal(t, statefulSetKind, dk.Status.Components[0].Kind)
+		assert.Equal(t, status.Running, dk.Status.Components[0].Phase)
+		assert.Equal(t, "1.300.0", dk.Status.Components[0].Version)
+
+		assert.Equal(t, dynakube.ActiveGateComponent+"-kubemon", dk.Status.Components[1].Name)
+		assert.Equal(t, status.Deploying, dk.Status.Components[1].Phase)
+		assert.Equal(t, "StatefulSet test-name-activegate-kubemon not yet created", dk.Status.Components[1].Message)
+
+		assert.Equal(t, status.Deploying, dk.Status.ComponentsPhase())
+	})
+}
+
+func determinePhase(controller *Controller, dk *dynakube.DynaKube) status.DeploymentPhase {
+	controller.updateComponentsStatus(context.Background(), dk)
+
+	return dk.Status.ComponentsPhase()
+}
+
+func observePhase(observe componentObserver, dk *dynakube.DynaKube) status.DeploymentPhase {
+	component, _ := observe(context.Background(), dk)
+
+	return component.Phase
+}
@@ -2,8 +2,41 @@ package dynakube
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// MaxConcurrentReconcilesEnv sets how many DynaKubes are reconciled in parallel, defaults to 1.
+	MaxConcurrentReconcilesEnv = "DT_DYNAKUBE_MAX_CONCURRENT_RECONCILES"
 )
 
 var (
 	log = logd.Get().WithName("dynakube")
+
+	subReconcilerLabels = []string{"namespace", "name", "reconciler"}
+
+	subReconcilerDurationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "dynatrace",
+		Subsystem: "dynakube_subreconciler",
+		Name:      "duration_seconds",
+		Help:      "Duration of the sub-reconcilers run by the DynaKube controller",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, subReconcilerLabels)
+	subReconcilerErrorsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "dynakube_subreconciler",
+		Name:      "errors_total",
+		Help:      "Number of failed runs of the sub-reconcilers run by the DynaKube controller",
+	}, subReconcilerLabels)
+	subReconcilerLastSuccessMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "dynakube_subreconciler",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful run of the sub-reconcilers run by the DynaKube controller",
+	}, subReconcilerLabels)
 )
+
+func init() {
+	metrics.Registry.MustRegister(subReconcilerDurationMetric, subReconcilerErrorsMetric, subReconcilerLastSuccessMetric)
+}
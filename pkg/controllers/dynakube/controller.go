@@ -6,9 +6,11 @@ import (
 	"os"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	dynatracestatus "github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/apimonitoring"
 	oaconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/oneagent"
@@ -19,16 +21,21 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/injection"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/lastapplied"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring"
 	logmondaemonset "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring/daemonset"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/mapper"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/events"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	appsv1 "k8s.io/api/apps/v1"
@@ -38,6 +45,8 @@ import (
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -58,11 +67,31 @@ func Add(mgr manager.Manager, _ string) error {
 }
 
 func NewController(mgr manager.Manager, clusterID string) *Controller {
-	return NewDynaKubeController(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetConfig(), clusterID)
+	controller := NewDynaKubeController(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetConfig(), clusterID)
+	controller.statusRecorder = events.NewStatusRecorder(mgr.GetEventRecorderFor("dynakube-controller"))
+
+	return controller
+}
+
+// Option customizes a Controller created by NewDynaKubeController.
+type Option func(*Controller)
+
+// WithDynatraceClientBuilder replaces the builder of the clients used to access the Dynatrace API.
+func WithDynatraceClientBuilder(dynatraceClientBuilder dynatraceclient.Builder) Option {
+	return func(controller *Controller) {
+		controller.dynatraceClientBuilder = dynatraceClientBuilder
+	}
 }
 
-func NewDynaKubeController(kubeClient client.Client, apiReader client.Reader, config *rest.Config, clusterID string) *Controller {
-	return &Controller{
+// WithIstioClientBuilder replaces the builder of the clients used to manage the istio objects.
+func WithIstioClientBuilder(istioClientBuilder istio.ClientBuilder) Option {
+	return func(controller *Controller) {
+		controller.istioClientBuilder = istioClientBuilder
+	}
+}
+
+func NewDynaKubeController(kubeClient client.Client, apiReader client.Reader, config *rest.Config, clusterID string, opts ...Option) *Controller {
+	controller := &Controller{
 		client:                 kubeClient,
 		apiReader:              apiReader,
 		fs:                     afero.Afero{Fs: afero.NewOsFs()},
@@ -83,17 +112,31 @@ func NewDynaKubeController(kubeClient client.Client, apiReader client.Reader, co
 		logMonitoringReconcilerBuilder:      logmonitoring.NewReconciler,
 		proxyReconcilerBuilder:              proxy.NewReconciler,
 		kspmReconcilerBuilder:               kspm.NewReconciler,
+		networkPolicyReconcilerBuilder:      networkpolicy.NewReconciler,
 	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
 }
 
 func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	if err := setupIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dynakube.DynaKube{}).
 		Named("dynakube-controller").
+		WithOptions(k8scontroller.Options{MaxConcurrentReconciles: controllers.MaxConcurrentReconciles(MaxConcurrentReconcilesEnv)}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(controller.requestsForReferencingDynaKubes(referencedSecretsIndex))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(controller.requestsForReferencingDynaKubes(referencedConfigMapsIndex))).
 		Complete(controller)
 }
 
@@ -108,6 +151,7 @@ type Controller struct {
 	dynatraceClientBuilder dynatraceclient.Builder
 	config                 *rest.Config
 	istioClientBuilder     istio.ClientBuilder
+	statusRecorder         events.StatusRecorder
 
 	deploymentMetadataReconcilerBuilder deploymentmetadata.ReconcilerBuilder
 	activeGateReconcilerBuilder         activegate.ReconcilerBuilder
@@ -120,12 +164,10 @@ type Controller struct {
 	logMonitoringReconcilerBuilder      logmonitoring.ReconcilerBuilder
 	proxyReconcilerBuilder              proxy.ReconcilerBuilder
 	kspmReconcilerBuilder               kspm.ReconcilerBuilder
+	networkPolicyReconcilerBuilder      networkpolicy.ReconcilerBuilder
 
-	tokens            token.Tokens
 	operatorNamespace string
 	clusterID         string
-
-	requeueAfter time.Duration
 }
 
 // Reconcile reads that state of the cluster for a DynaKube object and makes changes based on the state read
@@ -134,9 +176,12 @@ type Controller struct {
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (controller *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (controller *Controller) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
 	log.Info("reconciling DynaKube", "namespace", request.Namespace, "name", request.Name)
 
+	ctx, span := tracing.Start(ctx, "dynakube.reconcile", tracing.NamespaceKey.String(request.Namespace), tracing.DynaKubeKey.String(request.Name))
+	defer func() { tracing.End(span, err) }()
+
 	dk, err := controller.getDynakubeOrCleanup(ctx, request.Name, request.Namespace)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -147,9 +192,32 @@ func (controller *Controller) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	oldStatus := *dk.Status.DeepCopy()
-	controller.requeueAfter = defaultUpdateInterval
-	err = controller.reconcileDynaKube(ctx, dk)
-	result, err := controller.handleError(ctx, dk, err, oldStatus)
+	state := newReconcileState()
+
+	if dk.IsPaused() {
+		// none of the sub-reconcilers run, the components are only observed to keep the status up to date
+		log.Info("reconciliation of DynaKube is paused", "namespace", request.Namespace, "name", request.Name, "annotation", api.AnnotationPaused)
+		conditions.SetPaused(dk.Conditions())
+	} else {
+		conditions.RemovePaused(dk.Conditions())
+
+		start := time.Now()
+		err = controller.reconcileDynaKube(ctx, state, dk)
+		state.logTimings(dk, time.Since(start))
+
+		if err == nil {
+			requeueForMaintenanceWindow(state, dk, time.Now())
+			state.rolloutNowConsumed = isRolloutNowConsumed(dk)
+
+			// the webhook keeps injecting with this spec while the reconciliation is paused
+			err = lastapplied.Update(ctx, controller.client, controller.apiReader, dk)
+		}
+	}
+
+	result, err = controller.handleError(ctx, state, dk, err, oldStatus)
+	if err == nil && state.rolloutNowConsumed {
+		err = controller.consumeRolloutNow(ctx, dk)
+	}
 
 	log.Info("reconciling DynaKube finished", "namespace", request.Namespace, "name", request.Name, "result", result)
 
@@ -166,6 +234,8 @@ func (controller *Controller) getDynakubeOrCleanup(ctx context.Context, dkName,
 	err := controller.apiReader.Get(ctx, client.ObjectKey{Name: dk.Name, Namespace: dk.Namespace}, dk)
 
 	if k8serrors.IsNotFound(err) {
+		deleteSubReconcilerMetrics(dkNamespace, dkName)
+
 		namespaces, err := mapper.GetNamespacesForDynakube(ctx, controller.apiReader, dkName)
 		if err != nil {
 			return nil, errors.WithMessagef(err, "failed to list namespaces for dynakube %s", dkName)
@@ -181,6 +251,7 @@ func (controller *Controller) getDynakubeOrCleanup(ctx context.Context, dkName,
 
 func (controller *Controller) handleError(
 	ctx context.Context,
+	state *reconcileState,
 	dk *dynakube.DynaKube,
 	err error,
 	oldStatus dynakube.DynaKubeStatus,
@@ -193,39 +264,46 @@ func (controller *Controller) handleError(
 		return reconcile.Result{RequeueAfter: fastUpdateInterval}, nil
 
 	case err != nil:
-		controller.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
+		state.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
+		controller.updateComponentsStatus(ctx, dk)
 		dk.Status.SetPhase(dynatracestatus.Error)
 		log.Error(err, "error reconciling DynaKube", "namespace", dk.Namespace, "name", dk.Name)
 
 	default:
-		dk.Status.SetPhase(controller.determineDynaKubePhase(dk))
+		controller.updateComponentsStatus(ctx, dk)
+		dk.Status.SetPhase(dk.Status.ComponentsPhase())
 	}
 
 	if isStatusDifferent, err := hasher.IsDifferent(oldStatus, dk.Status); err != nil {
 		log.Error(err, "failed to generate hash for the status section")
 	} else if isStatusDifferent {
 		log.Info("status changed, updating DynaKube")
-		controller.setRequeueAfterIfNewIsShorter(changesUpdateInterval)
+		state.setRequeueAfterIfNewIsShorter(changesUpdateInterval)
 
 		if errClient := dk.UpdateStatus(ctx, controller.client); errClient != nil {
 			return reconcile.Result{}, errors.WithMessagef(errClient, "failed to update DynaKube after failure, original error: %s", err)
 		}
+
+		controller.recordStatusEvents(dk, oldStatus)
 	}
 
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{RequeueAfter: controller.requeueAfter}, nil
+	return reconcile.Result{RequeueAfter: state.requeueAfter}, nil
 }
 
-func (controller *Controller) setRequeueAfterIfNewIsShorter(requeueAfter time.Duration) {
-	if controller.requeueAfter > requeueAfter {
-		controller.requeueAfter = requeueAfter
-	}
+// recordStatusEvents emits Events for the phase, condition and version transitions of the persisted status.
+func (controller *Controller) recordStatusEvents(dk *dynakube.DynaKube, oldStatus dynakube.DynaKubeStatus) {
+	controller.statusRecorder.RecordPhase(dk, oldStatus.Phase, dk.Status.Phase)
+	controller.statusRecorder.RecordConditions(dk, oldStatus.Conditions, dk.Status.Conditions)
+	controller.statusRecorder.RecordVersion(dk, "OneAgent", oldStatus.OneAgent.VersionStatus, dk.Status.OneAgent.VersionStatus)
+	controller.statusRecorder.RecordVersion(dk, "CodeModules", oldStatus.CodeModules.VersionStatus, dk.Status.CodeModules.VersionStatus)
+	controller.statusRecorder.RecordVersion(dk, "ActiveGate", oldStatus.ActiveGate.VersionStatus, dk.Status.ActiveGate.VersionStatus)
 }
 
-func (controller *Controller) reconcileDynaKube(ctx context.Context, dk *dynakube.DynaKube) error {
+func (controller *Controller) reconcileDynaKube(ctx context.Context, state *reconcileState, dk *dynakube.DynaKube) error {
 	var istioClient *istio.Client
 
 	var err error
@@ -240,13 +318,23 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dk *dynakub
 	if istioClient != nil {
 		istioReconciler := controller.istioReconcilerBuilder(istioClient)
 
-		err := istioReconciler.ReconcileAPIUrl(ctx, dk)
+		err := state.runSubReconciler(ctx, dk, istioSubReconciler, func(ctx context.Context) error {
+			return istioReconciler.ReconcileAPIUrl(ctx, dk)
+		})
 		if err != nil {
 			return errors.WithMessage(err, "failed to reconcile istio objects for API url")
 		}
 	}
 
-	dynatraceClient, err := controller.setupTokensAndClient(ctx, dk)
+	var dynatraceClient dtclient.Client
+
+	err = state.runSubReconciler(ctx, dk, tokensSubReconciler, func(ctx context.Context) error {
+		var setupErr error
+
+		dynatraceClient, setupErr = controller.setupTokensAndClient(ctx, state, dk)
+
+		return setupErr
+	})
 	if err != nil {
 		return err
 	}
@@ -255,19 +343,23 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dk *dynakub
 
 	log.Info("start reconciling deployment meta data")
 
-	err = controller.deploymentMetadataReconcilerBuilder(controller.client, controller.apiReader, *dk, controller.clusterID).Reconcile(ctx)
+	err = state.runSubReconciler(ctx, dk, deploymentMetadataSubReconciler, func(ctx context.Context) error {
+		return controller.deploymentMetadataReconcilerBuilder(controller.client, controller.apiReader, *dk, controller.clusterID).Reconcile(ctx)
+	})
 	if err != nil {
 		return err
 	}
 
 	proxyReconciler := controller.proxyReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = proxyReconciler.Reconcile(ctx)
+	err = state.runSubReconciler(ctx, dk, proxySubReconciler, func(ctx context.Context) error {
+		return proxyReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		return err
 	}
 
-	return controller.reconcileComponents(ctx, dynatraceClient, istioClient, dk)
+	return controller.reconcileComponents(ctx, state, dynatraceClient, istioClient, dk)
 }
 
 func (controller *Controller) setupIstioClient(dk *dynakube.DynaKube) (*istio.Client, error) {
@@ -286,7 +378,7 @@ func (controller *Controller) setupIstioClient(dk *dynakube.DynaKube) (*istio.Cl
 	return istioClient, nil
 }
 
-func (controller *Controller) setupTokensAndClient(ctx context.Context, dk *dynakube.DynaKube) (dtclient.Client, error) {
+func (controller *Controller) setupTokensAndClient(ctx context.Context, state *reconcileState, dk *dynakube.DynaKube) (dtclient.Client, error) {
 	tokenReader := token.NewReader(controller.apiReader, dk)
 
 	tokens, err := tokenReader.ReadTokens(ctx)
@@ -296,7 +388,7 @@ func (controller *Controller) setupTokensAndClient(ctx context.Context, dk *dyna
 		return nil, err
 	}
 
-	controller.tokens = tokens
+	state.tokens = tokens
 
 	dynatraceClientBuilder := controller.dynatraceClientBuilder.
 		SetContext(ctx).
@@ -315,12 +407,14 @@ func (controller *Controller) setupTokensAndClient(ctx context.Context, dk *dyna
 	return dynatraceClient, nil
 }
 
-func (controller *Controller) reconcileComponents(ctx context.Context, dynatraceClient dtclient.Client, istioClient *istio.Client, dk *dynakube.DynaKube) error {
+func (controller *Controller) reconcileComponents(ctx context.Context, state *reconcileState, dynatraceClient dtclient.Client, istioClient *istio.Client, dk *dynakube.DynaKube) error {
 	var componentErrors []error
 
 	log.Info("start reconciling ActiveGate")
 
-	err := controller.reconcileActiveGate(ctx, dk, dynatraceClient, istioClient)
+	err := state.runSubReconciler(ctx, dk, activeGateSubReconciler, func(ctx context.Context) error {
+		return controller.reconcileActiveGate(ctx, dk, dynatraceClient, istioClient, state.tokens)
+	})
 	if err != nil {
 		log.Info("could not reconcile ActiveGate")
 
@@ -329,7 +423,9 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	extensionReconciler := controller.extensionReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = extensionReconciler.Reconcile(ctx)
+	err = state.runSubReconciler(ctx, dk, extensionSubReconciler, func(ctx context.Context) error {
+		return extensionReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		log.Info("could not reconcile Extensions")
 
@@ -340,7 +436,9 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	otelcReconciler := controller.otelcReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = otelcReconciler.Reconcile(ctx)
+	err = state.runSubReconciler(ctx, dk, otelcSubReconciler, func(ctx context.Context) error {
+		return otelcReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		log.Info("could not reconcile otelc")
 
@@ -351,10 +449,12 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	logMonitoringReconciler := controller.logMonitoringReconcilerBuilder(controller.client, controller.apiReader, dynatraceClient, dk)
 
-	err = logMonitoringReconciler.Reconcile(ctx)
+	err = state.runSubReconciler(ctx, dk, logMonitoringSubReconciler, func(ctx context.Context) error {
+		return logMonitoringReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		if errors.Is(err, oaconnectioninfo.NoOneAgentCommunicationHostsError) || errors.Is(err, logmondaemonset.KubernetesSettingsNotAvailableError) {
-			controller.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
+			state.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
 
 			return goerrors.Join(componentErrors...)
 		}
@@ -366,17 +466,19 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	log.Info("start reconciling app injection")
 
-	err = controller.injectionReconcilerBuilder(controller.client,
-		controller.apiReader,
-		dynatraceClient,
-		istioClient,
-		dk).
-		Reconcile(ctx)
+	err = state.runSubReconciler(ctx, dk, injectionSubReconciler, func(ctx context.Context) error {
+		return controller.injectionReconcilerBuilder(controller.client,
+			controller.apiReader,
+			dynatraceClient,
+			istioClient,
+			dk).
+			Reconcile(ctx)
+	})
 	if err != nil {
 		if errors.Is(err, oaconnectioninfo.NoOneAgentCommunicationHostsError) {
 			// missing communication hosts is not an error per se, just make sure next the reconciliation is happening ASAP
 			// this situation will clear itself after AG has been started
-			controller.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
+			state.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
 
 			return goerrors.Join(componentErrors...)
 		}
@@ -388,20 +490,29 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	log.Info("start reconciling OneAgent")
 
-	err = controller.oneAgentReconcilerBuilder(
+	oneAgentReconciler := controller.oneAgentReconcilerBuilder(
 		controller.client,
 		controller.apiReader,
 		dynatraceClient,
 		dk,
-		controller.tokens,
+		state.tokens,
 		controller.clusterID,
-	).
-		Reconcile(ctx)
+	)
+
+	err = state.runSubReconciler(ctx, dk, oneAgentSubReconciler, func(ctx context.Context) error {
+		return oneAgentReconciler.Reconcile(ctx)
+	})
+
+	// a running canary rollout has to be checked again when its bake time or ready timeout ends
+	if requeueAfter := oneAgentReconciler.RequeueAfter(); requeueAfter > 0 {
+		state.setRequeueAfterIfNewIsShorter(requeueAfter)
+	}
+
 	if err != nil {
 		if errors.Is(err, oaconnectioninfo.NoOneAgentCommunicationHostsError) {
 			// missing communication hosts is not an error per se, just make sure next the reconciliation is happening ASAP
 			// this situation will clear itself after AG has been started
-			controller.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
+			state.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
 
 			return goerrors.Join(componentErrors...)
 		}
@@ -413,13 +524,25 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	kspmReconciler := controller.kspmReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = kspmReconciler.Reconcile(ctx)
+	err = state.runSubReconciler(ctx, dk, kspmSubReconciler, func(ctx context.Context) error {
+		return kspmReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		log.Info("could not reconcile kspm")
 
 		componentErrors = append(componentErrors, err)
 	}
 
+	// runs last, so the NetworkPolicies follow the components and injected namespaces reconciled above
+	err = state.runSubReconciler(ctx, dk, networkPolicySubReconciler, func(ctx context.Context) error {
+		return controller.networkPolicyReconcilerBuilder(controller.client, controller.apiReader, dk).Reconcile(ctx)
+	})
+	if err != nil {
+		log.Info("could not reconcile NetworkPolicies")
+
+		componentErrors = append(componentErrors, err)
+	}
+
 	return goerrors.Join(componentErrors...)
 }
 
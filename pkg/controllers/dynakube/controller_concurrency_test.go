@@ -0,0 +1,124 @@
+package dynakube
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	ag "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/apimonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/injection"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kspm"
+	logmon "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	oneagentcontroller "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
+	"github.com/Dynatrace/dynatrace-operator/test/helpers/faketenant"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TestConcurrentReconcile reconciles several DynaKubes in parallel with a single Controller, run it with -race to detect shared state.
+func TestConcurrentReconcile(t *testing.T) {
+	const dynakubeCount = 5
+
+	tenant := faketenant.New(t)
+
+	objects := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: kubesystem.Namespace, UID: testUID}},
+	}
+
+	dynakubes := make([]*dynakube.DynaKube, 0, dynakubeCount)
+
+	for i := range dynakubeCount {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("dynakube-%d", i),
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: tenant.APIURL(),
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+				},
+			},
+		}
+		dynakubes = append(dynakubes, dk)
+
+		objects = append(objects, dk, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: dk.Tokens(), Namespace: testNamespace},
+			Data: map[string][]byte{
+				dtclient.APIToken:  []byte(faketenant.DefaultAPIToken),
+				dtclient.PaasToken: []byte(faketenant.DefaultPaasToken),
+			},
+		})
+	}
+
+	fakeClient := fake.NewClientWithIndex(objects...)
+
+	controller := &Controller{
+		client:                              fakeClient,
+		apiReader:                           fakeClient,
+		dynatraceClientBuilder:              dynatraceclient.NewBuilder(fakeClient),
+		fs:                                  afero.Afero{Fs: afero.NewMemMapFs()},
+		deploymentMetadataReconcilerBuilder: deploymentmetadata.NewReconciler,
+		activeGateReconcilerBuilder:         ag.NewReconciler,
+		apiMonitoringReconcilerBuilder:      apimonitoring.NewReconciler,
+		injectionReconcilerBuilder:          injection.NewReconciler,
+		oneAgentReconcilerBuilder:           oneagentcontroller.NewReconciler,
+		logMonitoringReconcilerBuilder:      logmon.NewReconciler,
+		proxyReconcilerBuilder:              proxy.NewReconciler,
+		extensionReconcilerBuilder:          extension.NewReconciler,
+		otelcReconcilerBuilder:              otelc.NewReconciler,
+		kspmReconcilerBuilder:               kspm.NewReconciler,
+		networkPolicyReconcilerBuilder:      networkpolicy.NewReconciler,
+		clusterID:                           testUID,
+	}
+
+	var wg sync.WaitGroup
+
+	results := make([]reconcile.Result, dynakubeCount)
+	errs := make([]error, dynakubeCount)
+
+	for i, dk := range dynakubes {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i], errs[i] = controller.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: dk.Namespace, Name: dk.Name},
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	for i, dk := range dynakubes {
+		require.NoError(t, errs[i], dk.Name)
+		assert.Positive(t, results[i].RequeueAfter, dk.Name)
+
+		var reconciled dynakube.DynaKube
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(dk), &reconciled))
+		assert.Equal(t, testUID, reconciled.Status.KubeSystemUUID, dk.Name)
+		assert.NotEmpty(t, reconciled.Status.ActiveGate.ConnectionInfo.TenantUUID, dk.Name)
+	}
+
+	assert.Len(t, tenant.ActiveGateTokens(), dynakubeCount)
+}
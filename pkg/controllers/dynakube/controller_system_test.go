@@ -22,12 +22,14 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/injection"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kspm"
 	logmon "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
 	oneagentcontroller "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
 	semVersion "github.com/Dynatrace/dynatrace-operator/pkg/version"
+	"github.com/Dynatrace/dynatrace-operator/test/helpers/faketenant"
 	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	dtbuildermock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers/dynakube/dynatraceclient"
 	"github.com/spf13/afero"
@@ -327,6 +329,51 @@ func TestAPIError(t *testing.T) {
 	})
 }
 
+func TestReconcileAgainstFakeTenant(t *testing.T) {
+	t.Run("creates kubernetes settings and activegate token", func(t *testing.T) {
+		tenant := faketenant.New(t)
+		tenant.Update(func(tenant *faketenant.Tenant) {
+			tenant.MonitoredEntities = []dtclient.MonitoredEntity{{EntityID: "KUBERNETES_CLUSTER-0E30FE4BF2007587", DisplayName: testName}}
+		})
+
+		dtc, err := dtclient.NewClient(tenant.APIURL(), faketenant.DefaultAPIToken, faketenant.DefaultPaasToken)
+		require.NoError(t, err)
+
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+				Annotations: map[string]string{
+					exp.AGAutomaticK8sAPIMonitoringKey: "true",
+				},
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: tenant.APIURL(),
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.KubeMonCapability.DisplayName,
+					},
+				},
+			},
+			Status: *getTestDynkubeStatus(),
+		}
+		controller := createFakeClientAndReconciler(t, dtc, dk, faketenant.DefaultPaasToken, faketenant.DefaultAPIToken)
+
+		_, err = controller.Reconcile(context.Background(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+		})
+		require.NoError(t, err)
+
+		settings := tenant.SettingsObjects()
+		require.Len(t, settings, 1)
+		assert.Equal(t, dtclient.KubernetesSettingsSchemaID, settings[0].SchemaID)
+		assert.Len(t, tenant.ActiveGateTokens(), 1)
+
+		require.NoError(t, controller.client.Get(context.Background(), client.ObjectKey{Name: testName, Namespace: testNamespace}, dk))
+		assert.Equal(t, status.Running, dk.Status.Phase)
+	})
+}
+
 func createDTMockClient(t *testing.T, paasTokenScopes, apiTokenScopes dtclient.TokenScopes) *dtclientmock.Client {
 	mockClient := dtclientmock.NewClient(t)
 
@@ -440,6 +487,7 @@ func createFakeClientAndReconciler(t *testing.T, mockClient dtclient.Client, dk
 		extensionReconcilerBuilder:          extension.NewReconciler,
 		otelcReconcilerBuilder:              otelc.NewReconciler,
 		kspmReconcilerBuilder:               kspm.NewReconciler,
+		networkPolicyReconcilerBuilder:      networkpolicy.NewReconciler,
 		clusterID:                           testUID,
 	}
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
@@ -24,13 +25,17 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kspm"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
 	oneagentcontroller "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/events"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	controllermock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers"
 	dtbuildermock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers/dynakube/dynatraceclient"
+	oneagentmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers/dynakube/oneagent"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +48,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -137,6 +143,56 @@ func TestMinimalRequest(t *testing.T) {
 	})
 }
 
+func TestReconcilePaused(t *testing.T) {
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: testName, Namespace: testNamespace}}
+
+	t.Run("paused => no sub-reconciler runs, paused condition set", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testName,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{api.AnnotationPaused: "true"},
+			},
+			Spec: dynakube.DynaKubeSpec{APIURL: testAPIURL},
+		}
+		fakeClient := fake.NewClientWithIndex(dk)
+		// the builder mock fails the test if the tokens or the client are set up
+		controller := &Controller{
+			client:                 fakeClient,
+			apiReader:              fakeClient,
+			dynatraceClientBuilder: dtbuildermock.NewBuilder(t),
+		}
+
+		_, err := controller.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		require.NoError(t, fakeClient.Get(ctx, request.NamespacedName, dk))
+		assert.True(t, meta.IsStatusConditionTrue(dk.Status.Conditions, conditions.PausedConditionType))
+	})
+	t.Run("unpaused => paused condition removed", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{APIURL: testAPIURL},
+		}
+		conditions.SetPaused(dk.Conditions())
+		fakeClient := fake.NewClientWithIndex(dk)
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+
+		_, err := controller.Reconcile(ctx, request)
+		require.Error(t, err)
+
+		require.NoError(t, fakeClient.Get(ctx, request.NamespacedName, dk))
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditions.PausedConditionType))
+	})
+}
+
 func TestHandleError(t *testing.T) {
 	ctx := context.Background()
 	dynakubeBase := &dynakube.DynaKube{
@@ -151,19 +207,19 @@ func TestHandleError(t *testing.T) {
 		oldDynakube := dynakubeBase.DeepCopy()
 		fakeClient := fake.NewClientWithIndex(oldDynakube)
 		controller := &Controller{
-			client:       fakeClient,
-			apiReader:    fakeClient,
-			requeueAfter: 12345 * time.Second,
+			client:    fakeClient,
+			apiReader: fakeClient,
 		}
+		state := &reconcileState{requeueAfter: 12345 * time.Second}
 		expectedDynakube := dynakubeBase.DeepCopy()
 		expectedDynakube.Status = dynakube.DynaKubeStatus{
 			Phase: status.Running,
 		}
 
-		result, err := controller.handleError(ctx, oldDynakube, nil, oldDynakube.Status)
+		result, err := controller.handleError(ctx, state, oldDynakube, nil, oldDynakube.Status)
 
 		require.NoError(t, err)
-		assert.Equal(t, controller.requeueAfter, result.RequeueAfter)
+		assert.Equal(t, state.requeueAfter, result.RequeueAfter)
 
 		dk := &dynakube.DynaKube{}
 		err = fakeClient.Get(ctx, types.NamespacedName{Name: expectedDynakube.Name, Namespace: expectedDynakube.Namespace}, dk)
@@ -178,7 +234,7 @@ func TestHandleError(t *testing.T) {
 			apiReader: fakeClient,
 		}
 
-		result, err := controller.handleError(ctx, oldDynakube, nil, oldDynakube.Status)
+		result, err := controller.handleError(ctx, newReconcileState(), oldDynakube, nil, oldDynakube.Status)
 		require.Error(t, err)
 		assert.Empty(t, result)
 	})
@@ -191,7 +247,7 @@ func TestHandleError(t *testing.T) {
 		}
 		serverError := dtclient.ServerError{Code: http.StatusTooManyRequests}
 
-		result, err := controller.handleError(ctx, oldDynakube, serverError, oldDynakube.Status)
+		result, err := controller.handleError(ctx, newReconcileState(), oldDynakube, serverError, oldDynakube.Status)
 		require.NoError(t, err)
 		assert.Equal(t, fastUpdateInterval, result.RequeueAfter)
 	})
@@ -204,7 +260,7 @@ func TestHandleError(t *testing.T) {
 		}
 		randomError := errors.New("BOOM")
 
-		result, err := controller.handleError(ctx, oldDynakube, randomError, oldDynakube.Status)
+		result, err := controller.handleError(ctx, newReconcileState(), oldDynakube, randomError, oldDynakube.Status)
 		assert.Empty(t, result)
 		require.Error(t, err)
 
@@ -213,6 +269,25 @@ func TestHandleError(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, status.Error, dk.Status.Phase)
 	})
+	t.Run("status transitions => events, unchanged status => no events", func(t *testing.T) {
+		oldDynakube := dynakubeBase.DeepCopy()
+		fakeClient := fake.NewClientWithIndex(oldDynakube)
+		recorder := record.NewFakeRecorder(10)
+		controller := &Controller{
+			client:         fakeClient,
+			apiReader:      fakeClient,
+			statusRecorder: events.NewStatusRecorder(recorder),
+		}
+
+		_, err := controller.handleError(ctx, newReconcileState(), oldDynakube, errors.New("BOOM"), oldDynakube.Status)
+		require.Error(t, err)
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning PhaseChanged Phase set to Error", <-recorder.Events)
+
+		_, err = controller.handleError(ctx, newReconcileState(), oldDynakube, errors.New("BOOM"), oldDynakube.Status)
+		require.Error(t, err)
+		assert.Empty(t, recorder.Events)
+	})
 }
 
 func TestSetupTokensAndClient(t *testing.T) {
@@ -233,7 +308,7 @@ func TestSetupTokensAndClient(t *testing.T) {
 			apiReader: fakeClient,
 		}
 
-		dtc, err := controller.setupTokensAndClient(ctx, dk)
+		dtc, err := controller.setupTokensAndClient(ctx, newReconcileState(), dk)
 		require.Error(t, err)
 		assert.Nil(t, dtc)
 		assertTokenCondition(t, dk, true)
@@ -264,7 +339,7 @@ func TestSetupTokensAndClient(t *testing.T) {
 			dynatraceClientBuilder: mockDtcBuilder,
 		}
 
-		dtc, err := controller.setupTokensAndClient(ctx, dk)
+		dtc, err := controller.setupTokensAndClient(ctx, newReconcileState(), dk)
 		require.Error(t, err)
 		assert.Nil(t, dtc)
 		assertTokenCondition(t, dk, true)
@@ -299,7 +374,7 @@ func TestSetupTokensAndClient(t *testing.T) {
 			dynatraceClientBuilder: mockDtcBuilder,
 		}
 
-		dtc, err := controller.setupTokensAndClient(ctx, dk)
+		dtc, err := controller.setupTokensAndClient(ctx, newReconcileState(), dk)
 		require.NoError(t, err)
 		assert.NotNil(t, dtc)
 		assertTokenCondition(t, dk, false)
@@ -337,8 +412,9 @@ func TestReconcileComponents(t *testing.T) {
 		dk := dkBaser.DeepCopy()
 		fakeClient := fake.NewClientWithIndex(dk)
 		// ReconcileCodeModuleCommunicationHosts
-		mockOneAgentReconciler := controllermock.NewReconciler(t)
+		mockOneAgentReconciler := oneagentmock.NewRequeueingReconciler(t)
 		mockOneAgentReconciler.On("Reconcile", mock.Anything).Return(errors.New("BOOM"))
+		mockOneAgentReconciler.On("RequeueAfter").Return(time.Duration(0))
 
 		mockActiveGateReconciler := controllermock.NewReconciler(t)
 		mockActiveGateReconciler.On("Reconcile", mock.Anything).Return(errors.New("BOOM"))
@@ -358,6 +434,9 @@ func TestReconcileComponents(t *testing.T) {
 		mockKSPMReconciler := controllermock.NewReconciler(t)
 		mockKSPMReconciler.On("Reconcile", mock.Anything).Return(errors.New("BOOM"))
 
+		mockNetworkPolicyReconciler := controllermock.NewReconciler(t)
+		mockNetworkPolicyReconciler.On("Reconcile", mock.Anything).Return(errors.New("BOOM"))
+
 		controller := &Controller{
 			client:    fakeClient,
 			apiReader: fakeClient,
@@ -370,14 +449,48 @@ func TestReconcileComponents(t *testing.T) {
 			extensionReconcilerBuilder:     createExtensionReconcilerBuilder(mockExtensionReconciler),
 			otelcReconcilerBuilder:         createOtelcReconcilerBuilder(mockOtelcReconciler),
 			kspmReconcilerBuilder:          createKSPMReconcilerBuilder(mockKSPMReconciler),
+			networkPolicyReconcilerBuilder: createNetworkPolicyReconcilerBuilder(mockNetworkPolicyReconciler),
 		}
 		mockedDtc := dtclientmock.NewClient(t)
 
-		err := controller.reconcileComponents(ctx, mockedDtc, nil, dk)
+		err := controller.reconcileComponents(ctx, newReconcileState(), mockedDtc, nil, dk)
 
 		require.Error(t, err)
 		// goerrors.Join concats errors with \n
-		assert.Len(t, strings.Split(err.Error(), "\n"), 7) // ActiveGate, Extension, OtelC, OneAgent LogMonitoring, and Injection reconcilers
+		assert.Len(t, strings.Split(err.Error(), "\n"), 8) // ActiveGate, Extension, OtelC, OneAgent LogMonitoring, Injection, KSPM and NetworkPolicy reconcilers
+	})
+
+	t.Run("requeue of the oneagent reconciler is kept", func(t *testing.T) {
+		dk := dkBaser.DeepCopy()
+		fakeClient := fake.NewClientWithIndex(dk)
+
+		mockOneAgentReconciler := oneagentmock.NewRequeueingReconciler(t)
+		mockOneAgentReconciler.On("Reconcile", mock.Anything).Return(nil)
+		mockOneAgentReconciler.On("RequeueAfter").Return(3 * time.Minute)
+
+		mockReconciler := controllermock.NewReconciler(t)
+		mockReconciler.On("Reconcile", mock.Anything).Return(nil)
+
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+			fs:        afero.Afero{Fs: afero.NewMemMapFs()},
+
+			activeGateReconcilerBuilder:    createActivegateReconcilerBuilder(mockReconciler),
+			injectionReconcilerBuilder:     createInjectionReconcilerBuilder(mockReconciler),
+			oneAgentReconcilerBuilder:      createOneAgentReconcilerBuilder(mockOneAgentReconciler),
+			logMonitoringReconcilerBuilder: createLogMonitoringReconcilerBuilder(mockReconciler),
+			extensionReconcilerBuilder:     createExtensionReconcilerBuilder(mockReconciler),
+			otelcReconcilerBuilder:         createOtelcReconcilerBuilder(mockReconciler),
+			kspmReconcilerBuilder:          createKSPMReconcilerBuilder(mockReconciler),
+			networkPolicyReconcilerBuilder: createNetworkPolicyReconcilerBuilder(mockReconciler),
+		}
+		state := newReconcileState()
+
+		err := controller.reconcileComponents(ctx, state, dtclientmock.NewClient(t), nil, dk)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Minute, state.requeueAfter)
 	})
 
 	t.Run("exit early in case of no oneagent conncection info", func(t *testing.T) {
@@ -407,7 +520,7 @@ func TestReconcileComponents(t *testing.T) {
 		}
 		mockedDtc := dtclientmock.NewClient(t)
 
-		err := controller.reconcileComponents(ctx, mockedDtc, nil, dk)
+		err := controller.reconcileComponents(ctx, newReconcileState(), mockedDtc, nil, dk)
 
 		require.Error(t, err)
 		// goerrors.Join concats errors with \n
@@ -421,8 +534,8 @@ func createActivegateReconcilerBuilder(reconciler controllers.Reconciler) ag.Rec
 	}
 }
 
-func createOneAgentReconcilerBuilder(reconciler controllers.Reconciler) oneagentcontroller.ReconcilerBuilder {
-	return func(_ client.Client, _ client.Reader, _ dtclient.Client, _ *dynakube.DynaKube, _ token.Tokens, _ string) controllers.Reconciler {
+func createOneAgentReconcilerBuilder(reconciler oneagentcontroller.RequeueingReconciler) oneagentcontroller.ReconcilerBuilder {
+	return func(_ client.Client, _ client.Reader, _ dtclient.Client, _ *dynakube.DynaKube, _ token.Tokens, _ string) oneagentcontroller.RequeueingReconciler {
 		return reconciler
 	}
 }
@@ -457,6 +570,12 @@ func createKSPMReconcilerBuilder(reconciler controllers.Reconciler) kspm.Reconci
 	}
 }
 
+func createNetworkPolicyReconcilerBuilder(reconciler controllers.Reconciler) networkpolicy.ReconcilerBuilder {
+	return func(_ client.Client, _ client.Reader, _ *dynakube.DynaKube) controllers.Reconciler {
+		return reconciler
+	}
+}
+
 func createAPIMonitoringReconcilerBuilder(reconciler controllers.Reconciler) apimonitoring.ReconcilerBuilder {
 	return func(_ dtclient.Client, _ *dynakube.DynaKube, _ string) controllers.Reconciler {
 		return reconciler
@@ -545,7 +664,7 @@ func TestTokenConditions(t *testing.T) {
 			apiReader: fakeClient,
 		}
 
-		_, err := controller.setupTokensAndClient(ctx, dk)
+		_, err := controller.setupTokensAndClient(ctx, newReconcileState(), dk)
 
 		require.Error(t, err)
 		assertCondition(t, dk, dynakube.TokenConditionType, metav1.ConditionFalse, dynakube.ReasonTokenError, "secrets \"\" not found")
@@ -580,7 +699,7 @@ func TestTokenConditions(t *testing.T) {
 			dynatraceClientBuilder: mockDtcBuilder,
 		}
 
-		_, err := controller.setupTokensAndClient(ctx, dk)
+		_, err := controller.setupTokensAndClient(ctx, newReconcileState(), dk)
 
 		require.NoError(t, err)
 		assertCondition(t, dk, dynakube.TokenConditionType, metav1.ConditionTrue, dynakube.ReasonTokenReady, TokenWithoutDataIngestConditionMessage)
@@ -600,7 +719,7 @@ func TestTokenConditions(t *testing.T) {
 			apiReader:              fakeClient,
 			dynatraceClientBuilder: mockDtcBuilder,
 		}
-		_, err = controller.setupTokensAndClient(ctx, dk)
+		_, err = controller.setupTokensAndClient(ctx, newReconcileState(), dk)
 
 		require.NoError(t, err)
 		assertCondition(t, dk, dynakube.TokenConditionType, metav1.ConditionTrue, dynakube.ReasonTokenReady, TokenReadyConditionMessage)
@@ -49,7 +49,7 @@ func (r *Reconciler) GenerateData() (map[string][]byte, error) {
 	case r.tokens.APIToken().Value != "":
 		registryToken = r.tokens.APIToken().Value
 	default:
-		return nil, errors.New("token secret does not contain a paas or api token, cannot generate docker config")
+		return nil, errors.New("token secret does not contain a paas or api token, cannot generate docker config (OAuth client credentials can not be used to pull images, set a customPullSecret or add a paasToken)")
 	}
 
 	tenantUUID, err := r.dk.TenantUUID()
@@ -77,6 +77,10 @@ func (dynatraceClientBuilder builder) Build() (dtclient.Client, error) {
 	opts.appendCertCheck(dynatraceClientBuilder.dk.Spec.SkipCertCheck)
 	opts.appendNetworkZone(dynatraceClientBuilder.dk.Spec.NetworkZone)
 	opts.appendHostGroup(dynatraceClientBuilder.dk.OneAgent().GetHostGroup())
+	opts.appendRetryPolicy()
+	opts.appendRateLimit(sharedRateLimiter())
+	opts.appendCache(sharedResponseCache())
+	opts.appendInstrumentation(dynatraceClientBuilder.dk.Namespace, dynatraceClientBuilder.dk.Name)
 
 	err := opts.appendProxySettings(apiReader, &dynatraceClientBuilder.dk)
 	if err != nil {
@@ -88,8 +92,13 @@ func (dynatraceClientBuilder builder) Build() (dtclient.Client, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	apiToken := dynatraceClientBuilder.getTokens().APIToken().Value
-	paasToken := dynatraceClientBuilder.getTokens().PaasToken().Value
+	tokens := dynatraceClientBuilder.getTokens()
+	if tokens.IsOAuth() {
+		opts.appendOAuth(tokens.OAuthCredentials())
+	}
+
+	apiToken := tokens.APIToken().Value
+	paasToken := tokens.PaasToken().Value
 
 	if paasToken == "" {
 		paasToken = apiToken
@@ -1,6 +1,10 @@
 package dynatraceclient
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
@@ -131,3 +135,48 @@ func TestBuildDynatraceClient(t *testing.T) {
 		assert.Nil(t, dtc)
 	})
 }
+
+func TestBuildDynatraceClientWithOAuth(t *testing.T) {
+	t.Run(`clients built with the same credentials share the bearer token`, func(t *testing.T) {
+		tokenRequests := atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Path == "/token" {
+				tokenRequests.Add(1)
+				writer.Header().Set("Content-Type", "application/json")
+				_, _ = writer.Write([]byte(`{"access_token": "access-token", "token_type": "Bearer", "expires_in": 300}`))
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{"source": "some.registry.com", "tag": "1.2.3"}`))
+		}))
+		defer server.Close()
+
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: server.URL,
+			}}
+		dynatraceClientBuilder := builder{
+			apiReader: fake.NewClient(dk),
+			tokens: map[string]*token.Token{
+				dtclient.OAuthClientID:      {Value: testValue},
+				dtclient.OAuthClientSecret:  {Value: testValueAlternative},
+				dtclient.OAuthTokenEndpoint: {Value: server.URL + "/token"},
+			},
+			dk: *dk,
+		}
+
+		for range 2 {
+			dtc, err := dynatraceClientBuilder.Build()
+			require.NoError(t, err)
+
+			_, err = dtc.GetLatestActiveGateImage(context.Background())
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), tokenRequests.Load())
+	})
+}
@@ -0,0 +1,36 @@
+package dynatraceclient
+
+import (
+	"os"
+	"strconv"
+	"sync"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+)
+
+// CacheEnabledEnv can be set to "false" to disable caching of Dynatrace API responses.
+const CacheEnabledEnv = "DT_API_CACHE_ENABLED"
+
+// sharedResponseCache is the process-wide cache every client built by the Builder uses, nil if caching is disabled.
+var sharedResponseCache = sync.OnceValue(func() *dtclient.ResponseCache {
+	if raw := os.Getenv(CacheEnabledEnv); raw != "" {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			log.Info("failed to parse envvar, using default", "env", CacheEnabledEnv, "value", raw, "default", true)
+		} else if !enabled {
+			log.Info("dynatrace api response cache disabled", "env", CacheEnabledEnv)
+
+			return nil
+		}
+	}
+
+	return dtclient.NewResponseCache(dtclient.DefaultCacheTTLs())
+})
+
+func cacheStats() dtclient.CacheStats {
+	if cache := sharedResponseCache(); cache != nil {
+		return cache.Stats()
+	}
+
+	return dtclient.CacheStats{}
+}
@@ -2,6 +2,39 @@ package dynatraceclient
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
-var log = logd.Get().WithName("dynatrace-client")
+var (
+	log = logd.Get().WithName("dynatrace-client")
+
+	rateLimitQueueDepthMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_rate_limit",
+		Name:      "queue_depth",
+		Help:      "Number of requests waiting for the Dynatrace API rate limit",
+	}, []string{"host"})
+	rateLimitRejectedMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_rate_limit",
+		Name:      "rejected_requests_total",
+		Help:      "Number of requests to the Dynatrace API rejected by the rate limit",
+	}, []string{"host", "reason"})
+	cacheHitsMetric = prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_cache",
+		Name:      "hits_total",
+		Help:      "Number of Dynatrace API requests answered from the response cache",
+	}, func() float64 { return float64(cacheStats().Hits) })
+	cacheMissesMetric = prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_cache",
+		Name:      "misses_total",
+		Help:      "Number of cacheable Dynatrace API requests that were downloaded from the tenant",
+	}, func() float64 { return float64(cacheStats().Misses) })
+)
+
+func init() {
+	metrics.Registry.MustRegister(rateLimitQueueDepthMetric, rateLimitRejectedMetric, cacheHitsMetric, cacheMissesMetric)
+}
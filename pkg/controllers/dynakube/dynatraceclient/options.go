@@ -38,6 +38,30 @@ func (opts *options) appendCertCheck(skipCertCheck bool) {
 	opts.Opts = append(opts.Opts, dtclient.SkipCertificateValidation(skipCertCheck))
 }
 
+func (opts *options) appendRetryPolicy() {
+	opts.Opts = append(opts.Opts, dtclient.Retry(dtclient.DefaultRetryPolicy()))
+}
+
+func (opts *options) appendRateLimit(limiter dtclient.RequestLimiter) {
+	opts.Opts = append(opts.Opts, dtclient.RateLimit(limiter))
+}
+
+func (opts *options) appendCache(cache *dtclient.ResponseCache) {
+	if cache != nil {
+		opts.Opts = append(opts.Opts, dtclient.Cache(cache))
+	}
+}
+
+func (opts *options) appendInstrumentation(dynakubeNamespace, dynakubeName string) {
+	if dynakubeName != "" {
+		opts.Opts = append(opts.Opts, dtclient.Instrument(dynakubeNamespace, dynakubeName))
+	}
+}
+
+func (opts *options) appendOAuth(credentials dtclient.OAuthCredentials) {
+	opts.Opts = append(opts.Opts, dtclient.OAuth(credentials))
+}
+
 func (opts *options) appendProxySettings(apiReader client.Reader, dk *dynakube.DynaKube) error {
 	if dk == nil || !dk.HasProxy() {
 		return nil
@@ -44,6 +44,15 @@ func TestOptions(t *testing.T) {
 
 		assert.NotEmpty(t, opts.Opts)
 	})
+	t.Run(`Test append retry policy`, func(t *testing.T) {
+		opts := newOptions(context.Background())
+
+		assert.Empty(t, opts.Opts)
+
+		opts.appendRetryPolicy()
+
+		assert.Len(t, opts.Opts, 1)
+	})
 	t.Run(`Test append cert check`, func(t *testing.T) {
 		opts := newOptions(context.Background())
 
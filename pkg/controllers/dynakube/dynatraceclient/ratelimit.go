@@ -0,0 +1,143 @@
+package dynatraceclient
+
+import (
+	"context"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// RateLimitEnv is the number of requests per second the operator sends to a single Dynatrace API host, 0 disables the limit.
+	RateLimitEnv = "DT_API_RATE_LIMIT"
+	// RateLimitBurstEnv is the number of requests that may be sent at once to a single Dynatrace API host, at least 1.
+	RateLimitBurstEnv = "DT_API_RATE_LIMIT_BURST"
+	// RateLimitMaxQueueEnv is the number of requests that may wait for a single Dynatrace API host, further requests are rejected.
+	// It has to be at least 1, otherwise every request that has to wait for the rate limit would be rejected.
+	RateLimitMaxQueueEnv = "DT_API_RATE_LIMIT_MAX_QUEUE"
+
+	defaultRateLimit         = 5.0
+	defaultRateLimitBurst    = 10
+	defaultRateLimitMaxQueue = 100
+
+	rejectReasonQueueFull = "queue_full"
+	rejectReasonDeadline  = "deadline"
+)
+
+// sharedRateLimiter is the process-wide limiter every client built by the Builder goes through,
+// so the tenant sees the same request rate regardless of how many DynaKubes point to it.
+var sharedRateLimiter = sync.OnceValue(func() *hostRateLimiter {
+	limit := getFloatFromEnvWithDefault(RateLimitEnv, defaultRateLimit)
+	burst := getIntFromEnvWithDefault(RateLimitBurstEnv, defaultRateLimitBurst)
+	maxQueue := getIntFromEnvWithDefault(RateLimitMaxQueueEnv, defaultRateLimitMaxQueue)
+
+	log.Info("dynatrace api rate limit configured", "requestsPerSecond", limit, "burst", burst, "maxQueue", maxQueue)
+
+	return newHostRateLimiter(limit, burst, maxQueue)
+})
+
+// hostRateLimiter is a token-bucket limiter per Dynatrace API host.
+type hostRateLimiter struct {
+	limiters map[string]*rate.Limiter
+	queued   map[string]int
+	limit    rate.Limit
+	burst    int
+	maxQueue int
+	mutex    sync.Mutex
+}
+
+func newHostRateLimiter(requestsPerSecond float64, burst, maxQueue int) *hostRateLimiter {
+	limit := rate.Limit(requestsPerSecond)
+	if requestsPerSecond <= 0 {
+		limit = rate.Inf
+	}
+
+	return &hostRateLimiter{
+		limiters: map[string]*rate.Limiter{},
+		queued:   map[string]int{},
+		limit:    limit,
+		burst:    max(burst, 1),
+		maxQueue: max(maxQueue, 0),
+	}
+}
+
+// Wait blocks until a request to the host is allowed by its token bucket.
+// Returns an error without waiting if too many requests are already queued for the host,
+// or if the request would have to wait past the deadline of ctx.
+func (l *hostRateLimiter) Wait(ctx context.Context, host string) error {
+	limiter, ok := l.enqueue(host)
+	if !ok {
+		rateLimitRejectedMetric.WithLabelValues(host, rejectReasonQueueFull).Inc()
+
+		return errors.Errorf("too many queued requests to dynatrace api host %s", host)
+	}
+
+	defer l.dequeue(host)
+
+	if err := limiter.Wait(ctx); err != nil {
+		rateLimitRejectedMetric.WithLabelValues(host, rejectReasonDeadline).Inc()
+
+		return errors.WithMessagef(err, "rate limit for dynatrace api host %s exceeded", host)
+	}
+
+	return nil
+}
+
+func (l *hostRateLimiter) enqueue(host string) (*rate.Limiter, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.limit != rate.Inf && l.queued[host] >= l.maxQueue {
+		return nil, false
+	}
+
+	limiter, ok := l.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[host] = limiter
+	}
+
+	l.queued[host]++
+	rateLimitQueueDepthMetric.WithLabelValues(host).Set(float64(l.queued[host]))
+
+	return limiter, true
+}
+
+func (l *hostRateLimiter) dequeue(host string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.queued[host]--
+	rateLimitQueueDepthMetric.WithLabelValues(host).Set(float64(l.queued[host]))
+}
+
+func getFloatFromEnvWithDefault(envName string, defaultValue float64) float64 {
+	if raw := os.Getenv(envName); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err == nil && value >= 0 && !math.IsInf(value, 0) {
+			return value
+		}
+
+		log.Info("failed to parse envvar, using default", "env", envName, "value", raw, "default", defaultValue)
+	}
+
+	return defaultValue
+}
+
+// getIntFromEnvWithDefault falls back to the default for values below 1.
+func getIntFromEnvWithDefault(envName string, defaultValue int) int {
+	if raw := os.Getenv(envName); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err == nil && value >= 1 {
+			return value
+		}
+
+		log.Info("failed to parse envvar, using default", "env", envName, "value", raw, "default", defaultValue)
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,74 @@
+package dynatraceclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHost = "test.dynatrace.com"
+
+func TestHostRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("allows burst without waiting", func(t *testing.T) {
+		limiter := newHostRateLimiter(0.001, 2, 10)
+
+		require.NoError(t, limiter.Wait(ctx, testHost))
+		require.NoError(t, limiter.Wait(ctx, testHost))
+	})
+	t.Run("buckets are per host", func(t *testing.T) {
+		limiter := newHostRateLimiter(0.001, 1, 10)
+
+		require.NoError(t, limiter.Wait(ctx, testHost))
+		require.NoError(t, limiter.Wait(ctx, "other.dynatrace.com"))
+	})
+	t.Run("rejects requests that would wait past the deadline", func(t *testing.T) {
+		limiter := newHostRateLimiter(0.001, 1, 10)
+
+		require.NoError(t, limiter.Wait(ctx, testHost))
+
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		require.Error(t, limiter.Wait(deadlineCtx, testHost))
+		assert.Equal(t, 0, limiter.queued[testHost])
+	})
+	t.Run("rejects requests if queue is full", func(t *testing.T) {
+		limiter := newHostRateLimiter(0.001, 1, 0)
+
+		require.Error(t, limiter.Wait(ctx, testHost))
+	})
+	t.Run("zero rate disables limit", func(t *testing.T) {
+		limiter := newHostRateLimiter(0, 0, 0)
+
+		for range 100 {
+			require.NoError(t, limiter.Wait(ctx, testHost))
+		}
+	})
+}
+
+func TestSharedRateLimiterEnv(t *testing.T) {
+	t.Run("falls back to defaults on invalid values", func(t *testing.T) {
+		t.Setenv(RateLimitEnv, "fast")
+		t.Setenv(RateLimitBurstEnv, "-1")
+
+		assert.InDelta(t, defaultRateLimit, getFloatFromEnvWithDefault(RateLimitEnv, defaultRateLimit), 0)
+		assert.Equal(t, defaultRateLimitBurst, getIntFromEnvWithDefault(RateLimitBurstEnv, defaultRateLimitBurst))
+	})
+	t.Run("zero max queue falls back to default, as it would reject every limited request", func(t *testing.T) {
+		t.Setenv(RateLimitMaxQueueEnv, "0")
+
+		assert.Equal(t, defaultRateLimitMaxQueue, getIntFromEnvWithDefault(RateLimitMaxQueueEnv, defaultRateLimitMaxQueue))
+	})
+	t.Run("reads values", func(t *testing.T) {
+		t.Setenv(RateLimitEnv, "0.5")
+		t.Setenv(RateLimitMaxQueueEnv, "3")
+
+		assert.InDelta(t, 0.5, getFloatFromEnvWithDefault(RateLimitEnv, defaultRateLimit), 0)
+		assert.Equal(t, 3, getIntFromEnvWithDefault(RateLimitMaxQueueEnv, defaultRateLimitMaxQueue))
+	})
+}
@@ -1,3 +1,5 @@
 package eec
 
 const extensionsControllerStatefulSetConditionType string = "ExtensionsControllerStatefulSet"
+
+const extensionsControllerPodDisruptionBudgetConditionType string = "ExtensionsControllerPodDisruptionBudget"
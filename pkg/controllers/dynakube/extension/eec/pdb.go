@@ -0,0 +1,20 @@
+package eec
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	k8spdb "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pdb"
+)
+
+func (r *reconciler) reconcilePodDisruptionBudget(ctx context.Context) error {
+	var budget *pdb.Spec
+	if r.dk.IsExtensionsEnabled() {
+		budget = pdb.GetBudget(r.dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget, 1)
+	}
+
+	appLabels := buildAppLabels(r.dk.Name)
+
+	return k8spdb.NewReconciler(r.client, r.apiReader, log, r.dk, extensionsControllerPodDisruptionBudgetConditionType).
+		Reconcile(ctx, r.dk.ExtensionsExecutionControllerStatefulsetName(), appLabels.BuildMatchLabels(), budget, k8spdb.SetLabels(appLabels.BuildLabels()))
+}
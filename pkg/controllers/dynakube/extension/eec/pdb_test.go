@@ -0,0 +1,75 @@
+package eec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	maxUnavailable := intstr.FromInt32(0)
+
+	t.Run("no budget configured => no PodDisruptionBudget", func(t *testing.T) {
+		dk := getTestDynakube()
+
+		mockK8sClient := fake.NewClient(dk)
+		mockK8sClient = mockTLSSecret(t, mockK8sClient, dk)
+
+		err := NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(context.Background())
+		require.NoError(t, err)
+
+		podDisruptionBudget := &policyv1.PodDisruptionBudget{}
+		err = mockK8sClient.Get(context.Background(), client.ObjectKey{Name: dk.ExtensionsExecutionControllerStatefulsetName(), Namespace: dk.Namespace}, podDisruptionBudget)
+		assert.True(t, errors.IsNotFound(err))
+	})
+	t.Run("budget configured => PodDisruptionBudget created", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget = &pdb.Spec{MaxUnavailable: &maxUnavailable}
+
+		mockK8sClient := fake.NewClient(dk)
+		mockK8sClient = mockTLSSecret(t, mockK8sClient, dk)
+
+		err := NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(context.Background())
+		require.NoError(t, err)
+
+		podDisruptionBudget := &policyv1.PodDisruptionBudget{}
+		err = mockK8sClient.Get(context.Background(), client.ObjectKey{Name: dk.ExtensionsExecutionControllerStatefulsetName(), Namespace: dk.Namespace}, podDisruptionBudget)
+		require.NoError(t, err)
+		assert.Equal(t, maxUnavailable, *podDisruptionBudget.Spec.MaxUnavailable)
+		assert.Equal(t, buildAppLabels(dk.Name).BuildMatchLabels(), podDisruptionBudget.Spec.Selector.MatchLabels)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), extensionsControllerPodDisruptionBudgetConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.PodDisruptionBudgetCreatedReason, condition.Reason)
+	})
+	t.Run("extensions are disabled => PodDisruptionBudget deleted", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget = &pdb.Spec{MaxUnavailable: &maxUnavailable}
+
+		mockK8sClient := fake.NewClient(dk)
+		mockK8sClient = mockTLSSecret(t, mockK8sClient, dk)
+
+		err := NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(context.Background())
+		require.NoError(t, err)
+
+		dk.Spec.Extensions = nil
+
+		err = NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(context.Background())
+		require.NoError(t, err)
+
+		podDisruptionBudget := &policyv1.PodDisruptionBudget{}
+		err = mockK8sClient.Get(context.Background(), client.ObjectKey{Name: dk.ExtensionsExecutionControllerStatefulsetName(), Namespace: dk.Namespace}, podDisruptionBudget)
+		assert.True(t, errors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), extensionsControllerPodDisruptionBudgetConditionType))
+	})
+}
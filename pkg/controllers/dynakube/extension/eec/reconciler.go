@@ -34,6 +34,10 @@ func NewReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.Dyna
 
 func (r *reconciler) Reconcile(ctx context.Context) error {
 	if !r.dk.IsExtensionsEnabled() {
+		if err := r.reconcilePodDisruptionBudget(ctx); err != nil {
+			return err
+		}
+
 		if meta.FindStatusCondition(*r.dk.Conditions(), extensionsControllerStatefulSetConditionType) == nil {
 			return nil
 		}
@@ -69,5 +73,10 @@ func (r *reconciler) Reconcile(ctx context.Context) error {
 		return errors.New("kubeSystemUUID unknown")
 	}
 
-	return r.createOrUpdateStatefulset(ctx)
+	err := r.createOrUpdateStatefulset(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcilePodDisruptionBudget(ctx)
 }
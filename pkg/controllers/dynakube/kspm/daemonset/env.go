@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -46,7 +47,7 @@ func getEnvs(dk dynakube.DynaKube, tenantUUID string) []corev1.EnvVar {
 
 func getActiveGateEndpointTemplate(dk dynakube.DynaKube, tenantUUID string) string {
 	activeGateEndpointTemplate := "https://%s.%s/e/%s/api/v2/kubernetes/node-config"
-	serviceName := capability.BuildServiceName(dk.Name)
+	serviceName := capability.BuildCapabilityServiceName(dk, activegate.KubeMonCapability.DisplayName)
 
 	return fmt.Sprintf(activeGateEndpointTemplate, serviceName, dk.Namespace, tenantUUID)
 }
@@ -0,0 +1,14 @@
+package lastapplied
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	// SpecKey holds the spec of the DynaKube as JSON
+	SpecKey = "spec"
+)
+
+var (
+	log = logd.Get().WithName("dynakube-last-applied")
+)
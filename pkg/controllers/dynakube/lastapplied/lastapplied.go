@@ -0,0 +1,57 @@
+// Package lastapplied keeps a snapshot of the spec of a DynaKube that was reconciled last,
+// so the webhook can keep injecting with it while the reconciliation is paused.
+package lastapplied
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/configmap"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func GetConfigMapName(dynakubeName string) string {
+	return dynakubeName + "-last-applied"
+}
+
+// Update stores the spec of the reconciled DynaKube in a ConfigMap next to it.
+func Update(ctx context.Context, clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube) error {
+	spec, err := json.Marshal(dk.Spec)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	configMap, err := configmap.Build(dk, GetConfigMapName(dk.Name), map[string]string{SpecKey: string(spec)})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = configmap.Query(clt, apiReader, log).CreateOrUpdate(ctx, configMap)
+
+	return errors.WithStack(err)
+}
+
+// Restore replaces the spec of the DynaKube with the one that was reconciled last.
+// If the DynaKube was never reconciled, the spec is kept as it is.
+func Restore(ctx context.Context, apiReader client.Reader, dk *dynakube.DynaKube) error {
+	configMap, err := configmap.Query(nil, apiReader, log).Get(ctx, client.ObjectKey{Name: GetConfigMapName(dk.Name), Namespace: dk.Namespace})
+	if k8serrors.IsNotFound(err) {
+		log.Info("no spec was reconciled yet, using the current one", "dynakube", dk.Name)
+
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var spec dynakube.DynaKubeSpec
+	if err := json.Unmarshal([]byte(configMap.Data[SpecKey]), &spec); err != nil {
+		return errors.WithStack(err)
+	}
+
+	dk.Spec = spec
+
+	return nil
+}
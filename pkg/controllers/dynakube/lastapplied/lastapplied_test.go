@@ -0,0 +1,70 @@
+package lastapplied
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testName      = "test-name"
+	testNamespace = "test-namespace"
+)
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Spec:       dynakube.DynaKubeSpec{APIURL: "https://first"},
+	}
+	fakeClient := fake.NewClient(dk)
+
+	require.NoError(t, Update(ctx, fakeClient, fakeClient, dk))
+
+	var configMap corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: GetConfigMapName(testName), Namespace: testNamespace}, &configMap))
+	assert.Contains(t, configMap.Data[SpecKey], `"apiUrl":"https://first"`)
+	require.Len(t, configMap.OwnerReferences, 1)
+	assert.Equal(t, testName, configMap.OwnerReferences[0].Name)
+
+	dk.Spec.APIURL = "https://second"
+	require.NoError(t, Update(ctx, fakeClient, fakeClient, dk))
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: GetConfigMapName(testName), Namespace: testNamespace}, &configMap))
+	assert.Contains(t, configMap.Data[SpecKey], `"apiUrl":"https://second"`)
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("spec is replaced by the reconciled one", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Spec:       dynakube.DynaKubeSpec{APIURL: "https://reconciled"},
+		}
+		fakeClient := fake.NewClient(dk)
+		require.NoError(t, Update(ctx, fakeClient, fakeClient, dk))
+
+		dk.Spec.APIURL = "https://edited"
+		require.NoError(t, Restore(ctx, fakeClient, dk))
+
+		assert.Equal(t, "https://reconciled", dk.Spec.APIURL)
+	})
+	t.Run("never reconciled => spec is kept", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Spec:       dynakube.DynaKubeSpec{APIURL: "https://edited"},
+		}
+
+		require.NoError(t, Restore(ctx, fake.NewClient(), dk))
+
+		assert.Equal(t, "https://edited", dk.Spec.APIURL)
+	})
+}
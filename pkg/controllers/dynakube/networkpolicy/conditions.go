@@ -0,0 +1,3 @@
+package networkpolicy
+
+const conditionType = "NetworkPolicies"
@@ -0,0 +1,7 @@
+package networkpolicy
+
+import "github.com/Dynatrace/dynatrace-operator/pkg/logd"
+
+var (
+	log = logd.Get().WithName("dynakube-networkpolicy")
+)
@@ -0,0 +1,354 @@
+package networkpolicy
+
+import (
+	"context"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	otelcservice "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/service"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	dnsPort            = 53
+	defaultHTTPSPort   = 443
+	defaultHTTPPort    = 80
+	apiServerPort      = 6443
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+
+	apiServerServiceName      = "kubernetes"
+	apiServerServiceNamespace = "default"
+)
+
+var (
+	ingressAndEgress = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}
+	ingressOnly      = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	egressOnly       = []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
+)
+
+// buildActiveGatePolicy allows the traffic to the ActiveGate service ports from anywhere, and from the ActiveGate to the Dynatrace environment,
+// the proxy and, for Kubernetes monitoring, the Kubernetes API server.
+func (r *Reconciler) buildActiveGatePolicy(ctx context.Context) (*networkingv1.NetworkPolicy, error) {
+	if !r.dk.ActiveGate().IsEnabled() {
+		return nil, nil //nolint:nilnil
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{Ports: activeGatePorts()},
+	}
+	egress := dnsEgressRules()
+
+	if r.dk.ActiveGate().IsKubernetesMonitoringEnabled() {
+		apiServerRules, err := r.apiServerEgressRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		egress = append(egress, apiServerRules...)
+	}
+
+	tenantPorts := r.tenantPorts()
+
+	if r.dk.NeedsActiveGateProxy() {
+		proxyPort, err := r.proxyPort(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		tenantPorts = appendPort(tenantPorts, proxyPort)
+	}
+
+	egress = append(egress, networkingv1.NetworkPolicyEgressRule{Ports: tenantPorts})
+
+	return r.newPolicy(r.dk.ActiveGateNetworkPolicyName(), labels.ActiveGateComponentLabel, networkingv1.NetworkPolicySpec{
+		PodSelector: *activeGateSelector(r.dk.Name),
+		PolicyTypes: ingressAndEgress,
+		Ingress:     ingress,
+		Egress:      egress,
+	}), nil
+}
+
+// buildOtelCollectorPolicy allows the traffic to the telemetry ingest ports from anywhere.
+// The egress of the collector is left open, as the Prometheus endpoints scraped for the extensions can be anywhere in the cluster.
+func (r *Reconciler) buildOtelCollectorPolicy() *networkingv1.NetworkPolicy {
+	if !r.dk.IsExtensionsEnabled() && !r.dk.TelemetryIngest().IsEnabled() {
+		return nil
+	}
+
+	var ingress []networkingv1.NetworkPolicyIngressRule
+
+	if r.dk.TelemetryIngest().IsEnabled() {
+		var ports []networkingv1.NetworkPolicyPort
+
+		for _, servicePort := range otelcservice.BuildServicePortList(r.dk.TelemetryIngest().GetProtocols()) {
+			ports = append(ports, newPort(servicePort.Protocol, servicePort.TargetPort))
+		}
+
+		if len(ports) > 0 {
+			ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{Ports: ports})
+		}
+	}
+
+	return r.newPolicy(r.dk.OtelCollectorNetworkPolicyName(), labels.OtelCComponentLabel, networkingv1.NetworkPolicySpec{
+		PodSelector: *otelCollectorSelector(r.dk.Name),
+		PolicyTypes: ingressOnly,
+		Ingress:     ingress,
+	})
+}
+
+// buildExtensionsControllerPolicy allows the traffic from the collector to the extensions controller, and from the extensions controller to the ActiveGate.
+func (r *Reconciler) buildExtensionsControllerPolicy() *networkingv1.NetworkPolicy {
+	if !r.dk.IsExtensionsEnabled() {
+		return nil
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From:  []networkingv1.NetworkPolicyPeer{{PodSelector: otelCollectorSelector(r.dk.Name)}},
+			Ports: []networkingv1.NetworkPolicyPort{newPort(corev1.ProtocolTCP, intstr.FromString(consts.ExtensionsCollectorTargetPortName))},
+		},
+	}
+	egress := append(dnsEgressRules(), networkingv1.NetworkPolicyEgressRule{
+		To:    []networkingv1.NetworkPolicyPeer{{PodSelector: activeGateSelector(r.dk.Name)}},
+		Ports: activeGatePorts(),
+	})
+
+	return r.newPolicy(r.dk.ExtensionsExecutionControllerNetworkPolicyName(), labels.ExtensionComponentLabel, networkingv1.NetworkPolicySpec{
+		PodSelector: *extensionsControllerSelector(r.dk.Name),
+		PolicyTypes: ingressAndEgress,
+		Ingress:     ingress,
+		Egress:      egress,
+	})
+}
+
+// buildWebhookPolicy allows the traffic from the Kubernetes API server to the webhook server port.
+// The webhook is deployed with the operator, so nothing is needed if it isn't running in the namespace of the DynaKube.
+func (r *Reconciler) buildWebhookPolicy(ctx context.Context) (*networkingv1.NetworkPolicy, error) {
+	var webhookDeployment appsv1.Deployment
+
+	err := r.apiReader.Get(ctx, client.ObjectKey{Name: webhook.DeploymentName, Namespace: r.dk.Namespace}, &webhookDeployment)
+	if k8serrors.IsNotFound(err) {
+		log.Info("no webhook deployment found in the namespace of the DynaKube, skipping its NetworkPolicy", "namespace", r.dk.Namespace)
+
+		return nil, nil //nolint:nilnil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if webhookDeployment.Spec.Selector == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{Ports: []networkingv1.NetworkPolicyPort{newPort(corev1.ProtocolTCP, intstr.FromString(webhook.ServerPortName))}},
+	}
+
+	return r.newPolicy(r.dk.WebhookNetworkPolicyName(), labels.WebhookComponentLabel, networkingv1.NetworkPolicySpec{
+		PodSelector: *webhookDeployment.Spec.Selector.DeepCopy(),
+		PolicyTypes: ingressOnly,
+		Ingress:     ingress,
+	}), nil
+}
+
+// buildInjectionPolicy allows the traffic from the injected pods of a namespace to the DNS, the ActiveGate and the Dynatrace environment.
+// It only selects the pods labeled by the webhook, so the egress traffic of the other pods of the namespace isn't isolated by it.
+func (r *Reconciler) buildInjectionPolicy() *networkingv1.NetworkPolicy {
+	egress := dnsEgressRules()
+
+	if r.dk.ActiveGate().IsEnabled() {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: r.dk.Namespace}},
+				PodSelector:       activeGateSelector(r.dk.Name),
+			}},
+			Ports: activeGatePorts(),
+		})
+	}
+
+	egress = append(egress, networkingv1.NetworkPolicyEgressRule{Ports: r.tenantPorts()})
+
+	// the namespace is set for each of the injected namespaces
+	policy := r.newPolicy(consts.InjectionNetworkPolicyName, labels.CodeModuleComponentLabel, networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{webhook.LabelDynatraceInjected: "true"}},
+		PolicyTypes: egressOnly,
+		Egress:      egress,
+	})
+	policy.Namespace = ""
+
+	return policy
+}
+
+func (r *Reconciler) newPolicy(name, component string, spec networkingv1.NetworkPolicySpec) *networkingv1.NetworkPolicy {
+	coreLabels := labels.NewCoreLabels(r.dk.Name, component)
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: r.dk.Namespace,
+			Labels:    coreLabels.BuildLabels(),
+		},
+		Spec: spec,
+	}
+}
+
+// tenantPorts are the ports of the Dynatrace environment, taken from the API URL and the OneAgent communication hosts.
+// The hosts can't be used as destination, as NetworkPolicies only support IP blocks, so the traffic is allowed to any destination on these ports.
+func (r *Reconciler) tenantPorts() []networkingv1.NetworkPolicyPort {
+	portNumbers := []int32{urlPort(r.dk.APIURL())}
+
+	for _, communicationHost := range r.dk.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts {
+		if communicationHost.Port > 0 {
+			portNumbers = append(portNumbers, int32(communicationHost.Port)) //nolint:gosec
+		}
+	}
+
+	slices.Sort(portNumbers)
+	portNumbers = slices.Compact(portNumbers)
+
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(portNumbers))
+	for _, portNumber := range portNumbers {
+		ports = append(ports, newPort(corev1.ProtocolTCP, intstr.FromInt32(portNumber)))
+	}
+
+	return ports
+}
+
+// apiServerEgressRules allows the traffic to the Kubernetes API server, its addresses are taken from the endpoints of the `kubernetes` service.
+// If they can't be found, the traffic is allowed to any destination on the usual API server ports.
+func (r *Reconciler) apiServerEgressRules(ctx context.Context) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	var endpoints corev1.Endpoints //nolint:staticcheck
+
+	err := r.apiReader.Get(ctx, client.ObjectKey{Name: apiServerServiceName, Namespace: apiServerServiceNamespace}, &endpoints)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var rules []networkingv1.NetworkPolicyEgressRule
+
+	for _, subset := range endpoints.Subsets {
+		var rule networkingv1.NetworkPolicyEgressRule
+
+		for _, address := range subset.Addresses {
+			rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(address.IP)}})
+		}
+
+		for _, port := range subset.Ports {
+			rule.Ports = append(rule.Ports, newPort(port.Protocol, intstr.FromInt32(port.Port)))
+		}
+
+		if len(rule.To) > 0 && len(rule.Ports) > 0 {
+			rules = append(rules, rule)
+		}
+	}
+
+	if len(rules) == 0 {
+		log.Info("no endpoints of the Kubernetes API server found, allowing its default ports to any destination", "service", apiServerServiceName)
+
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				newPort(corev1.ProtocolTCP, intstr.FromInt32(defaultHTTPSPort)),
+				newPort(corev1.ProtocolTCP, intstr.FromInt32(apiServerPort)),
+			},
+		})
+	}
+
+	return rules, nil
+}
+
+func (r *Reconciler) proxyPort(ctx context.Context) (int32, error) {
+	proxyURL, err := r.dk.Proxy(ctx, r.apiReader)
+	if err != nil {
+		return 0, err
+	}
+
+	return urlPort(proxyURL), nil
+}
+
+func hostCIDR(ip string) string {
+	if addr, err := netip.ParseAddr(ip); err == nil && addr.Is6() {
+		return ip + "/128"
+	}
+
+	return ip + "/32"
+}
+
+// appendPort adds a TCP port, unless it is already part of the ports.
+func appendPort(ports []networkingv1.NetworkPolicyPort, portNumber int32) []networkingv1.NetworkPolicyPort {
+	port := newPort(corev1.ProtocolTCP, intstr.FromInt32(portNumber))
+
+	if slices.ContainsFunc(ports, func(existing networkingv1.NetworkPolicyPort) bool {
+		return *existing.Port == *port.Port
+	}) {
+		return ports
+	}
+
+	return append(ports, port)
+}
+
+func urlPort(rawURL string) int32 {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return defaultHTTPSPort
+	}
+
+	if port, err := strconv.ParseInt(parsedURL.Port(), 10, 32); err == nil {
+		return int32(port)
+	}
+
+	if parsedURL.Scheme == "http" {
+		return defaultHTTPPort
+	}
+
+	return defaultHTTPSPort
+}
+
+func dnsEgressRules() []networkingv1.NetworkPolicyEgressRule {
+	return []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				newPort(corev1.ProtocolUDP, intstr.FromInt32(dnsPort)),
+				newPort(corev1.ProtocolTCP, intstr.FromInt32(dnsPort)),
+			},
+		},
+	}
+}
+
+// activeGatePorts uses the names of the container ports targeted by the ActiveGate service.
+func activeGatePorts() []networkingv1.NetworkPolicyPort {
+	return []networkingv1.NetworkPolicyPort{
+		newPort(corev1.ProtocolTCP, intstr.FromString(agconsts.HTTPSServicePortName)),
+		newPort(corev1.ProtocolTCP, intstr.FromString(agconsts.HTTPServicePortName)),
+	}
+}
+
+func newPort(protocol corev1.Protocol, port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &port,
+	}
+}
+
+func activeGateSelector(dkName string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: labels.NewAppLabels(labels.ActiveGateComponentLabel, dkName, "", "").BuildMatchLabels()}
+}
+
+func otelCollectorSelector(dkName string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: labels.NewAppLabels(labels.OtelCComponentLabel, dkName, "", "").BuildMatchLabels()}
+}
+
+func extensionsControllerSelector(dkName string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: labels.NewAppLabels(labels.ExtensionComponentLabel, dkName, "", "").BuildMatchLabels()}
+}
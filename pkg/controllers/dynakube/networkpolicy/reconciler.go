@@ -0,0 +1,186 @@
+package networkpolicy
+
+import (
+	"context"
+	goerrors "errors"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/mapper"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	k8snetworkpolicy "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/networkpolicy"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ controllers.Reconciler = &Reconciler{}
+
+type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
+	dk        *dynakube.DynaKube
+}
+
+type ReconcilerBuilder func(client client.Client, apiReader client.Reader, dk *dynakube.DynaKube) controllers.Reconciler
+
+func NewReconciler(client client.Client, apiReader client.Reader, dk *dynakube.DynaKube) controllers.Reconciler { //nolint
+	return &Reconciler{
+		client:    client,
+		apiReader: apiReader,
+		dk:        dk,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	if !r.dk.NetworkPoliciesEnabled() {
+		if meta.FindStatusCondition(*r.dk.Conditions(), conditionType) == nil {
+			return nil
+		}
+		defer meta.RemoveStatusCondition(r.dk.Conditions(), conditionType)
+
+		return r.deleteAll(ctx)
+	}
+
+	err := goerrors.Join(r.reconcileComponentPolicies(ctx), r.reconcileInjectionPolicies(ctx))
+	if err != nil {
+		conditions.SetKubeAPIError(r.dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	conditions.SetNetworkPoliciesCreatedOrUpdated(r.dk.Conditions(), conditionType, "NetworkPolicies")
+
+	return nil
+}
+
+// reconcileComponentPolicies creates the NetworkPolicies of the enabled components in the namespace of the DynaKube,
+// and deletes the ones of the components that got disabled.
+func (r *Reconciler) reconcileComponentPolicies(ctx context.Context) error {
+	webhookPolicy, err := r.buildWebhookPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	activeGatePolicy, err := r.buildActiveGatePolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicies := map[string]*networkingv1.NetworkPolicy{
+		r.dk.WebhookNetworkPolicyName():                       webhookPolicy,
+		r.dk.ActiveGateNetworkPolicyName():                    activeGatePolicy,
+		r.dk.OtelCollectorNetworkPolicyName():                 r.buildOtelCollectorPolicy(),
+		r.dk.ExtensionsExecutionControllerNetworkPolicyName(): r.buildExtensionsControllerPolicy(),
+	}
+
+	query := k8snetworkpolicy.Query(r.client, r.apiReader, log).WithOwner(r.dk)
+
+	var errs []error
+
+	for name, policy := range desiredPolicies {
+		if policy == nil {
+			errs = append(errs, query.DeleteForNamespace(ctx, name, r.dk.Namespace))
+
+			continue
+		}
+
+		_, err := query.CreateOrUpdate(ctx, policy)
+		errs = append(errs, err)
+	}
+
+	return goerrors.Join(errs...)
+}
+
+// reconcileInjectionPolicies creates the NetworkPolicy for the pods in the namespaces injected by the DynaKube,
+// and deletes it from the namespaces that aren't injected anymore.
+func (r *Reconciler) reconcileInjectionPolicies(ctx context.Context) error {
+	var namespaces []corev1.Namespace
+
+	if r.dk.OneAgent().IsAppInjectionNeeded() {
+		var err error
+
+		namespaces, err = mapper.GetNamespacesForDynakube(ctx, r.apiReader, r.dk.Name)
+		if err != nil {
+			return errors.WithMessage(err, "failed to list injected namespaces")
+		}
+	}
+
+	query := k8snetworkpolicy.Query(r.client, r.apiReader, log)
+
+	if len(namespaces) > 0 {
+		policy := r.buildInjectionPolicy()
+
+		err := hasher.AddAnnotation(policy)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		err = query.CreateOrUpdateForNamespaces(ctx, policy, namespaces)
+		if err != nil {
+			return err
+		}
+	}
+
+	staleNamespaces, err := r.getStaleInjectionNamespaces(ctx, namespaces)
+	if err != nil {
+		return err
+	}
+
+	return query.DeleteForNamespaces(ctx, consts.InjectionNetworkPolicyName, staleNamespaces)
+}
+
+// getStaleInjectionNamespaces returns the namespaces that contain a NetworkPolicy of the DynaKube for injected pods, but aren't injected anymore.
+func (r *Reconciler) getStaleInjectionNamespaces(ctx context.Context, injectedNamespaces []corev1.Namespace) ([]string, error) {
+	existingPolicies, err := k8snetworkpolicy.Query(r.client, r.apiReader, log).GetAllFromNamespaces(ctx, consts.InjectionNetworkPolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	injected := make(map[string]bool, len(injectedNamespaces))
+	for _, namespace := range injectedNamespaces {
+		injected[namespace.Name] = true
+	}
+
+	var staleNamespaces []string
+
+	for _, policy := range existingPolicies {
+		if policy.Labels[labels.AppCreatedByLabel] == r.dk.Name && !injected[policy.Namespace] {
+			staleNamespaces = append(staleNamespaces, policy.Namespace)
+		}
+	}
+
+	return staleNamespaces, nil
+}
+
+func (r *Reconciler) deleteAll(ctx context.Context) error {
+	query := k8snetworkpolicy.Query(r.client, r.apiReader, log)
+
+	var errs []error
+
+	for _, name := range []string{
+		r.dk.WebhookNetworkPolicyName(),
+		r.dk.ActiveGateNetworkPolicyName(),
+		r.dk.OtelCollectorNetworkPolicyName(),
+		r.dk.ExtensionsExecutionControllerNetworkPolicyName(),
+	} {
+		errs = append(errs, query.Delete(ctx, &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.dk.Namespace},
+		}))
+	}
+
+	staleNamespaces, err := r.getStaleInjectionNamespaces(ctx, nil)
+	if err != nil {
+		return goerrors.Join(append(errs, err)...)
+	}
+
+	errs = append(errs, query.DeleteForNamespaces(ctx, consts.InjectionNetworkPolicyName, staleNamespaces))
+
+	return goerrors.Join(errs...)
+}
@@ -0,0 +1,285 @@
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testNamespace         = "dynatrace"
+	testDynakubeName      = "test-dynakube"
+	testInjectedNamespace = "injected"
+	testAPIURL            = "https://test.dev.dynatracelabs.com:9999/api"
+)
+
+func newDynaKube(enabled bool) *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testDynakubeName,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			APIURL:          testAPIURL,
+			NetworkPolicies: &dynakube.NetworkPoliciesSpec{Enabled: enabled},
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+			},
+			OneAgent: oneagent.Spec{
+				ApplicationMonitoring: &oneagent.ApplicationMonitoringSpec{},
+			},
+		},
+	}
+}
+
+func newInjectedNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{webhook.InjectionInstanceLabel: testDynakubeName},
+		},
+	}
+}
+
+func newWebhookDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      webhook.DeploymentName,
+			Namespace: testNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"internal.dynatrace.com/app": "webhook"}},
+		},
+	}
+}
+
+func getPolicy(t *testing.T, clt client.Client, name, namespace string) (*networkingv1.NetworkPolicy, error) {
+	t.Helper()
+
+	policy := &networkingv1.NetworkPolicy{}
+	err := clt.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, policy)
+
+	return policy, err
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("network policies disabled => no NetworkPolicies", func(t *testing.T) {
+		dk := newDynaKube(false)
+		fakeClient := fake.NewClientWithIndex(newInjectedNamespace(testInjectedNamespace), newWebhookDeployment())
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		policies := &networkingv1.NetworkPolicyList{}
+		require.NoError(t, fakeClient.List(ctx, policies))
+		assert.Empty(t, policies.Items)
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditionType))
+	})
+	t.Run("network policies enabled => NetworkPolicies for enabled components", func(t *testing.T) {
+		dk := newDynaKube(true)
+		fakeClient := fake.NewClientWithIndex(newWebhookDeployment())
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		activeGatePolicy, err := getPolicy(t, fakeClient, dk.ActiveGateNetworkPolicyName(), testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, ingressAndEgress, activeGatePolicy.Spec.PolicyTypes)
+		assert.Equal(t, activeGateSelector(testDynakubeName).MatchLabels, activeGatePolicy.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, activeGatePorts(), activeGatePolicy.Spec.Ingress[0].Ports)
+		require.Len(t, activeGatePolicy.OwnerReferences, 1)
+		assert.Equal(t, testDynakubeName, activeGatePolicy.OwnerReferences[0].Name)
+
+		tenantRule := activeGatePolicy.Spec.Egress[len(activeGatePolicy.Spec.Egress)-1]
+		require.Len(t, tenantRule.Ports, 1)
+		assert.Equal(t, intstr.FromInt32(9999), *tenantRule.Ports[0].Port)
+
+		webhookPolicy, err := getPolicy(t, fakeClient, dk.WebhookNetworkPolicyName(), testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, ingressOnly, webhookPolicy.Spec.PolicyTypes)
+		assert.Equal(t, newWebhookDeployment().Spec.Selector.MatchLabels, webhookPolicy.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, intstr.FromString(webhook.ServerPortName), *webhookPolicy.Spec.Ingress[0].Ports[0].Port)
+
+		_, err = getPolicy(t, fakeClient, dk.OtelCollectorNetworkPolicyName(), testNamespace)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		_, err = getPolicy(t, fakeClient, dk.ExtensionsExecutionControllerNetworkPolicyName(), testNamespace)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		condition := meta.FindStatusCondition(dk.Status.Conditions, conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.NetworkPoliciesReconciledReason, condition.Reason)
+	})
+	t.Run("extensions enabled => NetworkPolicies for collector and extensions controller", func(t *testing.T) {
+		dk := newDynaKube(true)
+		dk.Spec.Extensions = &dynakube.ExtensionsSpec{}
+		fakeClient := fake.NewClientWithIndex()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		collectorPolicy, err := getPolicy(t, fakeClient, dk.OtelCollectorNetworkPolicyName(), testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, otelCollectorSelector(testDynakubeName).MatchLabels, collectorPolicy.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, ingressOnly, collectorPolicy.Spec.PolicyTypes)
+		assert.Empty(t, collectorPolicy.Spec.Ingress)
+		assert.Empty(t, collectorPolicy.Spec.Egress)
+
+		controllerPolicy, err := getPolicy(t, fakeClient, dk.ExtensionsExecutionControllerNetworkPolicyName(), testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, extensionsControllerSelector(testDynakubeName).MatchLabels, controllerPolicy.Spec.PodSelector.MatchLabels)
+		require.Len(t, controllerPolicy.Spec.Ingress, 1)
+		assert.Equal(t, otelCollectorSelector(testDynakubeName), controllerPolicy.Spec.Ingress[0].From[0].PodSelector)
+
+		_, err = getPolicy(t, fakeClient, dk.WebhookNetworkPolicyName(), testNamespace)
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+	t.Run("injected namespaces => NetworkPolicy for injected pods", func(t *testing.T) {
+		dk := newDynaKube(true)
+		fakeClient := fake.NewClientWithIndex(newInjectedNamespace(testInjectedNamespace))
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		policy, err := getPolicy(t, fakeClient, consts.InjectionNetworkPolicyName, testInjectedNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, egressOnly, policy.Spec.PolicyTypes)
+		assert.Equal(t, map[string]string{webhook.LabelDynatraceInjected: "true"}, policy.Spec.PodSelector.MatchLabels)
+		require.Len(t, policy.Spec.Egress, 3)
+		assert.Equal(t, dnsEgressRules()[0], policy.Spec.Egress[0])
+		assert.Equal(t, testNamespace, policy.Spec.Egress[1].To[0].NamespaceSelector.MatchLabels[namespaceNameLabel])
+		assert.Equal(t, activeGateSelector(testDynakubeName), policy.Spec.Egress[1].To[0].PodSelector)
+	})
+	t.Run("injected namespaces => NetworkPolicy doesn't select pods that are not injected", func(t *testing.T) {
+		dk := newDynaKube(true)
+		fakeClient := fake.NewClientWithIndex(newInjectedNamespace(testInjectedNamespace))
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		policy, err := getPolicy(t, fakeClient, consts.InjectionNetworkPolicyName, testInjectedNamespace)
+		require.NoError(t, err)
+
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		require.NoError(t, err)
+		assert.False(t, selector.Empty())
+		assert.False(t, selector.Matches(k8slabels.Set{"app": "database"}))
+		assert.True(t, selector.Matches(k8slabels.Set{"app": "frontend", webhook.LabelDynatraceInjected: "true"}))
+	})
+	t.Run("kubernetes monitoring => ActiveGate may reach the Kubernetes API server", func(t *testing.T) {
+		dk := newDynaKube(true)
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName}
+		apiServerEndpoints := &corev1.Endpoints{ //nolint:staticcheck
+			ObjectMeta: metav1.ObjectMeta{Name: apiServerServiceName, Namespace: apiServerServiceNamespace},
+			Subsets: []corev1.EndpointSubset{{ //nolint:staticcheck
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "fd00::1"}},                      //nolint:staticcheck
+				Ports:     []corev1.EndpointPort{{Name: "https", Port: 6443, Protocol: corev1.ProtocolTCP}}, //nolint:staticcheck
+			}},
+		}
+		fakeClient := fake.NewClientWithIndex(apiServerEndpoints)
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		activeGatePolicy, err := getPolicy(t, fakeClient, dk.ActiveGateNetworkPolicyName(), testNamespace)
+		require.NoError(t, err)
+		require.Len(t, activeGatePolicy.Spec.Egress, 3)
+
+		apiServerRule := activeGatePolicy.Spec.Egress[1]
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}},
+			{IPBlock: &networkingv1.IPBlock{CIDR: "fd00::1/128"}},
+		}, apiServerRule.To)
+		assert.Equal(t, []networkingv1.NetworkPolicyPort{newPort(corev1.ProtocolTCP, intstr.FromInt32(6443))}, apiServerRule.Ports)
+	})
+	t.Run("kubernetes monitoring without API server endpoints => default API server ports allowed", func(t *testing.T) {
+		dk := newDynaKube(true)
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName}
+		fakeClient := fake.NewClientWithIndex()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		activeGatePolicy, err := getPolicy(t, fakeClient, dk.ActiveGateNetworkPolicyName(), testNamespace)
+		require.NoError(t, err)
+		require.Len(t, activeGatePolicy.Spec.Egress, 3)
+		assert.Empty(t, activeGatePolicy.Spec.Egress[1].To)
+		assert.Equal(t, []networkingv1.NetworkPolicyPort{
+			newPort(corev1.ProtocolTCP, intstr.FromInt32(defaultHTTPSPort)),
+			newPort(corev1.ProtocolTCP, intstr.FromInt32(apiServerPort)),
+		}, activeGatePolicy.Spec.Egress[1].Ports)
+	})
+	t.Run("proxy => ActiveGate may reach the proxy port", func(t *testing.T) {
+		dk := newDynaKube(true)
+		dk.Spec.Proxy = &value.Source{Value: "http://proxy.example.com:3128"}
+		fakeClient := fake.NewClientWithIndex()
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		activeGatePolicy, err := getPolicy(t, fakeClient, dk.ActiveGateNetworkPolicyName(), testNamespace)
+		require.NoError(t, err)
+
+		tenantRule := activeGatePolicy.Spec.Egress[len(activeGatePolicy.Spec.Egress)-1]
+		require.Len(t, tenantRule.Ports, 2)
+		assert.Equal(t, intstr.FromInt32(9999), *tenantRule.Ports[0].Port)
+		assert.Equal(t, intstr.FromInt32(3128), *tenantRule.Ports[1].Port)
+	})
+	t.Run("components disabled afterwards => their NetworkPolicies deleted", func(t *testing.T) {
+		dk := newDynaKube(true)
+		fakeClient := fake.NewClientWithIndex(newInjectedNamespace(testInjectedNamespace))
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.ActiveGate.Capabilities = nil
+		dk.Spec.OneAgent.ApplicationMonitoring = nil
+
+		err = NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		_, err = getPolicy(t, fakeClient, dk.ActiveGateNetworkPolicyName(), testNamespace)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		_, err = getPolicy(t, fakeClient, consts.InjectionNetworkPolicyName, testInjectedNamespace)
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+	t.Run("network policies disabled afterwards => NetworkPolicies deleted", func(t *testing.T) {
+		dk := newDynaKube(true)
+		fakeClient := fake.NewClientWithIndex(newInjectedNamespace(testInjectedNamespace), newWebhookDeployment())
+
+		err := NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.NetworkPolicies.Enabled = false
+
+		err = NewReconciler(fakeClient, fakeClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		policies := &networkingv1.NetworkPolicyList{}
+		require.NoError(t, fakeClient.List(ctx, policies))
+		assert.Empty(t, policies.Items)
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditionType))
+	})
+}
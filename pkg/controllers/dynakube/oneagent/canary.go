@@ -0,0 +1,293 @@
+package oneagent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	k8spod "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultCanaryPercentage = int32(10)
+	defaultCanaryBakeTime   = 10 * time.Minute
+
+	// canaryReadyTimeout limits how long the canary pods may take to become ready with the new version.
+	canaryReadyTimeout = 15 * time.Minute
+
+	// canaryMaxRestarts is the number of restarts of an updated canary pod that aborts the rollout.
+	canaryMaxRestarts = int32(3)
+)
+
+// startCanary starts a canary rollout if the image of the OneAgent DaemonSet is about to change.
+// It has to run before the DaemonSet is updated, so the update strategy is already switched when the new image is applied.
+func (r *Reconciler) startCanary(ctx context.Context) error {
+	spec := r.dk.OneAgent().GetCanary()
+	if spec == nil {
+		r.dk.Status.OneAgent.Canary = nil
+		meta.RemoveStatusCondition(r.dk.Conditions(), canaryConditionType)
+
+		return nil
+	}
+
+	canary := r.dk.Status.OneAgent.Canary
+	targetImage := r.dk.OneAgent().GetImage()
+
+	if canary != nil && canary.ImageID == targetImage {
+		return nil
+	}
+
+	if canary != nil && canary.Phase == oneagent.CanaryPhaseAborted && canary.PreviousImageID == targetImage {
+		// the previous version is rolled back to the canary nodes
+		return nil
+	}
+
+	if r.dk.OneAgent().IsCanaryRolloutInProgress() {
+		log.Info("newer OneAgent version available during canary rollout, restarting it", "version", r.dk.OneAgent().GetVersion())
+
+		canary.Version = r.dk.OneAgent().GetVersion()
+		canary.ImageID = targetImage
+		canary.Phase = oneagent.CanaryPhaseRollingOut
+		canary.StartTimestamp = r.timeProvider.Now()
+		canary.BakeStartTimestamp = nil
+		canary.UpdatedNodes = 0
+		canary.Message = ""
+		setCanaryCondition(r.dk.Conditions(), canary)
+
+		return nil
+	}
+
+	var ds appsv1.DaemonSet
+
+	err := r.apiReader.Get(ctx, client.ObjectKey{Name: r.dk.OneAgent().GetDaemonsetName(), Namespace: r.dk.Namespace}, &ds)
+	if k8serrors.IsNotFound(err) {
+		// the initial rollout goes to all nodes at once
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if len(ds.Spec.Template.Spec.Containers) == 0 || ds.Spec.Template.Spec.Containers[0].Image == targetImage {
+		return nil
+	}
+
+	canary = &oneagent.CanaryStatus{
+		Phase:           oneagent.CanaryPhaseRollingOut,
+		Version:         r.dk.OneAgent().GetVersion(),
+		ImageID:         targetImage,
+		PreviousVersion: ds.Labels[labels.AppVersionLabel],
+		PreviousImageID: ds.Spec.Template.Spec.Containers[0].Image,
+		StartTimestamp:  r.timeProvider.Now(),
+	}
+	r.dk.Status.OneAgent.Canary = canary
+
+	pods, err := r.listOneAgentPods(ctx)
+	if err != nil {
+		return err
+	}
+
+	canary.Nodes, err = r.selectCanaryNodes(ctx, spec, pods)
+	if err != nil {
+		r.abortCanary(fmt.Sprintf("failed to select canary nodes: %s", err.Error()))
+
+		return nil
+	}
+
+	if len(canary.Nodes) == 0 {
+		r.abortCanary("no OneAgent pod runs on a node matching the canary node selector")
+
+		return nil
+	}
+
+	log.Info("starting canary rollout of OneAgent", "version", canary.Version, "previousVersion", canary.PreviousVersion, "nodes", canary.Nodes)
+	setCanaryCondition(r.dk.Conditions(), canary)
+
+	return nil
+}
+
+// progressCanary replaces the OneAgent pods on the canary nodes and checks their health.
+// It has to run after the DaemonSet got the new image, otherwise the deleted pods are recreated with the previous one.
+func (r *Reconciler) progressCanary(ctx context.Context) error {
+	if !r.dk.OneAgent().IsCanaryRolloutInProgress() {
+		return nil
+	}
+
+	canary := r.dk.Status.OneAgent.Canary
+
+	pods, err := r.listOneAgentPods(ctx)
+	if err != nil {
+		return err
+	}
+
+	podsByNode := make(map[string]corev1.Pod, len(pods))
+	for _, pod := range pods {
+		podsByNode[pod.Spec.NodeName] = pod
+	}
+
+	expected := int32(0)
+	updated := int32(0)
+
+	for _, node := range canary.Nodes {
+		pod, ok := podsByNode[node]
+		if !ok {
+			// the node is gone or its pod is just recreated
+			continue
+		}
+
+		expected++
+
+		if len(pod.Spec.Containers) == 0 || pod.Spec.Containers[0].Image != canary.ImageID {
+			if pod.DeletionTimestamp == nil {
+				log.Info("replacing OneAgent pod on canary node", "node", node, "pod", pod.Name)
+
+				if err := r.client.Delete(ctx, &pod); client.IgnoreNotFound(err) != nil {
+					return errors.WithStack(err)
+				}
+			}
+
+			continue
+		}
+
+		if reason := k8spod.GetUnhealthyReason(pod, canaryMaxRestarts); reason != "" {
+			r.abortCanary(fmt.Sprintf("OneAgent pod %s on canary node %s is unhealthy: %s", pod.Name, node, reason))
+
+			return nil
+		}
+
+		if k8spod.IsReady(pod) {
+			updated++
+		}
+	}
+
+	canary.UpdatedNodes = updated
+	now := r.timeProvider.Now()
+	// without any canary pod, e.g. while they are recreated, nothing verified the new version yet
+	allReady := expected > 0 && updated == expected
+
+	switch canary.Phase {
+	case oneagent.CanaryPhaseRollingOut:
+		if allReady {
+			log.Info("all canary pods are updated and ready, baking", "version", canary.Version)
+
+			canary.Phase = oneagent.CanaryPhaseBaking
+			canary.BakeStartTimestamp = now
+			r.requeueAfter = canaryBakeTime(r.dk.OneAgent().GetCanary())
+		} else if elapsed := now.Sub(canary.StartTimestamp.Time); elapsed >= canaryReadyTimeout {
+			r.abortCanary(fmt.Sprintf("only %d of %d canary pods became ready within %s", updated, expected, canaryReadyTimeout))
+
+			return nil
+		} else {
+			r.requeueAfter = canaryReadyTimeout - elapsed
+		}
+	case oneagent.CanaryPhaseBaking:
+		if !allReady {
+			r.abortCanary(fmt.Sprintf("only %d of %d canary pods are ready during the bake time", updated, expected))
+
+			return nil
+		}
+
+		if elapsed := now.Sub(canary.BakeStartTimestamp.Time); elapsed >= canaryBakeTime(r.dk.OneAgent().GetCanary()) {
+			log.Info("canary pods stayed healthy, rolling out to the rest of the nodes", "version", canary.Version)
+
+			canary.Phase = oneagent.CanaryPhaseCompleted
+		} else {
+			r.requeueAfter = canaryBakeTime(r.dk.OneAgent().GetCanary()) - elapsed
+		}
+	}
+
+	setCanaryCondition(r.dk.Conditions(), canary)
+
+	return nil
+}
+
+// abortCanary restores the previous version, the DaemonSet rolls it back to the canary nodes.
+// The version reconciler doesn't roll out the aborted version again.
+func (r *Reconciler) abortCanary(message string) {
+	canary := r.dk.Status.OneAgent.Canary
+
+	log.Info("aborting canary rollout of OneAgent", "version", canary.Version, "reason", message)
+
+	canary.Phase = oneagent.CanaryPhaseAborted
+	canary.Message = message
+
+	r.dk.Status.OneAgent.ImageID = canary.PreviousImageID
+	r.dk.Status.OneAgent.Version = canary.PreviousVersion
+
+	setCanaryCondition(r.dk.Conditions(), canary)
+}
+
+func (r *Reconciler) listOneAgentPods(ctx context.Context) ([]corev1.Pod, error) {
+	appLabels := labels.NewAppLabels(labels.OneAgentComponentLabel, r.dk.Name, "", "")
+
+	var podList corev1.PodList
+
+	err := r.apiReader.List(ctx, &podList,
+		client.InNamespace(r.dk.Namespace),
+		client.MatchingLabels(appLabels.BuildMatchLabels()),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return podList.Items, nil
+}
+
+// selectCanaryNodes picks the canary nodes out of the nodes that run a OneAgent pod.
+// Without a node selector, the same nodes are picked for each rollout, as they are sorted by name.
+func (r *Reconciler) selectCanaryNodes(ctx context.Context, spec *oneagent.CanarySpec, pods []corev1.Pod) ([]string, error) {
+	nodes := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+	}
+
+	slices.Sort(nodes)
+	nodes = slices.Compact(nodes)
+
+	if spec.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.NodeSelector)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		var nodeList corev1.NodeList
+		if err := r.apiReader.List(ctx, &nodeList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		return slices.DeleteFunc(nodes, func(node string) bool {
+			return !slices.ContainsFunc(nodeList.Items, func(selected corev1.Node) bool {
+				return selected.Name == node
+			})
+		}), nil
+	}
+
+	percentage := defaultCanaryPercentage
+	if spec.Percentage != nil {
+		percentage = *spec.Percentage
+	}
+
+	count := int(math.Ceil(float64(len(nodes)) * float64(percentage) / 100))
+
+	return nodes[:count], nil
+}
+
+func canaryBakeTime(spec *oneagent.CanarySpec) time.Duration {
+	if spec == nil || spec.BakeTime == nil {
+		return defaultCanaryBakeTime
+	}
+
+	return spec.BakeTime.Duration
+}
@@ -0,0 +1,275 @@
+package oneagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testCanaryNamespace = "dynatrace"
+	testCanaryDynaKube  = "dynakube"
+
+	testPreviousVersion = "1.1.0.0-0"
+	testPreviousImage   = "registry/oneagent:1.1.0.0-0"
+	testNewVersion      = "1.2.0.0-0"
+	testNewImage        = "registry/oneagent:1.2.0.0-0"
+)
+
+func TestCanaryRollout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("canary nodes are updated, baked and then the rest is rolled out", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{Percentage: ptr.To(int32(50))})
+		fakeClient := fake.NewClient(newOneAgentDaemonSet(dk), newOneAgentPod(dk, "node-a", testPreviousImage), newOneAgentPod(dk, "node-b", testPreviousImage))
+		reconciler := newCanaryReconciler(dk, fakeClient)
+
+		require.NoError(t, reconciler.startCanary(ctx))
+
+		canary := dk.Status.OneAgent.Canary
+		require.NotNil(t, canary)
+		assert.Equal(t, oneagent.CanaryPhaseRollingOut, canary.Phase)
+		assert.Equal(t, []string{"node-a"}, canary.Nodes)
+		assert.Equal(t, testPreviousImage, canary.PreviousImageID)
+		assert.Equal(t, testPreviousVersion, canary.PreviousVersion)
+
+		ds, err := reconciler.buildDesiredDaemonSet(dk)
+		require.NoError(t, err)
+		assert.Equal(t, appsv1.OnDeleteDaemonSetStrategyType, ds.Spec.UpdateStrategy.Type)
+
+		// the pod on the canary node is replaced, the other one is kept
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assertPodDeleted(t, fakeClient, "node-a")
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "oneagent-node-b", Namespace: testCanaryNamespace}, &corev1.Pod{}))
+
+		// no pod on the canary node verifies the new version yet
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseRollingOut, canary.Phase)
+		assert.Equal(t, int32(0), canary.UpdatedNodes)
+
+		require.NoError(t, fakeClient.Create(ctx, newOneAgentPod(dk, "node-a", testNewImage)))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseBaking, canary.Phase)
+		assert.Equal(t, int32(1), canary.UpdatedNodes)
+		assert.Equal(t, defaultCanaryBakeTime, reconciler.RequeueAfter())
+
+		reconciler.timeProvider.Set(reconciler.timeProvider.Now().Add(4 * time.Minute))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseBaking, canary.Phase)
+		assert.Equal(t, defaultCanaryBakeTime-4*time.Minute, reconciler.RequeueAfter())
+
+		reconciler.timeProvider.Set(reconciler.timeProvider.Now().Add(defaultCanaryBakeTime - 4*time.Minute))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseCompleted, canary.Phase)
+		assert.True(t, meta.IsStatusConditionTrue(dk.Status.Conditions, canaryConditionType))
+
+		ds, err = reconciler.buildDesiredDaemonSet(dk)
+		require.NoError(t, err)
+		assert.NotNil(t, ds.Spec.UpdateStrategy.RollingUpdate)
+		assert.Equal(t, testNewImage, ds.Spec.Template.Spec.Containers[0].Image)
+
+		// nothing changes for the same version
+		require.NoError(t, reconciler.startCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseCompleted, dk.Status.OneAgent.Canary.Phase)
+	})
+	t.Run("unhealthy canary pod aborts the rollout and restores the previous version", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{})
+		crashingPod := newOneAgentPod(dk, "node-a", testNewImage)
+		crashingPod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}
+		fakeClient := fake.NewClient(newOneAgentDaemonSet(dk), crashingPod)
+		reconciler := newCanaryReconciler(dk, fakeClient)
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		require.NoError(t, reconciler.progressCanary(ctx))
+
+		canary := dk.Status.OneAgent.Canary
+		assert.Equal(t, oneagent.CanaryPhaseAborted, canary.Phase)
+		assert.Contains(t, canary.Message, "CrashLoopBackOff")
+		assert.Equal(t, testPreviousImage, dk.OneAgent().GetImage())
+		assert.Equal(t, testPreviousVersion, dk.OneAgent().GetVersion())
+		assert.True(t, meta.IsStatusConditionFalse(dk.Status.Conditions, canaryConditionType))
+
+		// the rollback isn't a new canary rollout
+		require.NoError(t, reconciler.startCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseAborted, dk.Status.OneAgent.Canary.Phase)
+	})
+	t.Run("canary pods not ready in time abort the rollout", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{})
+		unreadyPod := newOneAgentPod(dk, "node-a", testNewImage)
+		unreadyPod.Status.Conditions = nil
+		fakeClient := fake.NewClient(newOneAgentDaemonSet(dk), unreadyPod)
+		reconciler := newCanaryReconciler(dk, fakeClient)
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseRollingOut, dk.Status.OneAgent.Canary.Phase)
+		assert.Equal(t, canaryReadyTimeout, reconciler.RequeueAfter())
+
+		reconciler.timeProvider.Set(reconciler.timeProvider.Now().Add(canaryReadyTimeout + time.Second))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseAborted, dk.Status.OneAgent.Canary.Phase)
+	})
+	t.Run("canary pods missing => not baked and aborted after the ready timeout", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{})
+		fakeClient := fake.NewClient(newOneAgentDaemonSet(dk), newOneAgentPod(dk, "node-a", testPreviousImage))
+		reconciler := newCanaryReconciler(dk, fakeClient)
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		require.NoError(t, fakeClient.Delete(ctx, newOneAgentPod(dk, "node-a", testPreviousImage)))
+
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseRollingOut, dk.Status.OneAgent.Canary.Phase)
+
+		reconciler.timeProvider.Set(reconciler.timeProvider.Now().Add(canaryReadyTimeout + time.Second))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseAborted, dk.Status.OneAgent.Canary.Phase)
+	})
+	t.Run("canary pods missing while baking => aborted", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{})
+		fakeClient := fake.NewClient(newOneAgentDaemonSet(dk), newOneAgentPod(dk, "node-a", testNewImage))
+		reconciler := newCanaryReconciler(dk, fakeClient)
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		require.Equal(t, oneagent.CanaryPhaseBaking, dk.Status.OneAgent.Canary.Phase)
+
+		require.NoError(t, fakeClient.Delete(ctx, newOneAgentPod(dk, "node-a", testNewImage)))
+		require.NoError(t, reconciler.progressCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseAborted, dk.Status.OneAgent.Canary.Phase)
+	})
+	t.Run("node selector picks the canary nodes", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}})
+		canaryNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"canary": "true"}}}
+		fakeClient := fake.NewClient(newOneAgentDaemonSet(dk), canaryNode, newOneAgentPod(dk, "node-a", testPreviousImage), newOneAgentPod(dk, "node-b", testPreviousImage))
+		reconciler := newCanaryReconciler(dk, fakeClient)
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		assert.Equal(t, []string{"node-b"}, dk.Status.OneAgent.Canary.Nodes)
+	})
+	t.Run("no node matches the node selector => aborted", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}})
+		fakeClient := fake.NewClient(newOneAgentDaemonSet(dk), newOneAgentPod(dk, "node-a", testPreviousImage))
+		reconciler := newCanaryReconciler(dk, fakeClient)
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		assert.Equal(t, oneagent.CanaryPhaseAborted, dk.Status.OneAgent.Canary.Phase)
+		assert.Equal(t, testPreviousImage, dk.OneAgent().GetImage())
+	})
+	t.Run("initial rollout => no canary", func(t *testing.T) {
+		dk := newCanaryDynaKube(&oneagent.CanarySpec{})
+		reconciler := newCanaryReconciler(dk, fake.NewClient())
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		assert.Nil(t, dk.Status.OneAgent.Canary)
+	})
+	t.Run("canary disabled => status removed", func(t *testing.T) {
+		dk := newCanaryDynaKube(nil)
+		dk.Status.OneAgent.Canary = &oneagent.CanaryStatus{Phase: oneagent.CanaryPhaseAborted}
+		reconciler := newCanaryReconciler(dk, fake.NewClient())
+
+		require.NoError(t, reconciler.startCanary(ctx))
+		assert.Nil(t, dk.Status.OneAgent.Canary)
+	})
+}
+
+func TestSelectCanaryNodes(t *testing.T) {
+	dk := newCanaryDynaKube(nil)
+	reconciler := newCanaryReconciler(dk, fake.NewClient())
+
+	pods := []corev1.Pod{
+		*newOneAgentPod(dk, "node-c", testPreviousImage),
+		*newOneAgentPod(dk, "node-a", testPreviousImage),
+		*newOneAgentPod(dk, "node-b", testPreviousImage),
+	}
+
+	t.Run("default percentage picks at least one node", func(t *testing.T) {
+		nodes, err := reconciler.selectCanaryNodes(context.Background(), &oneagent.CanarySpec{}, pods)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"node-a"}, nodes)
+	})
+	t.Run("percentage is rounded up", func(t *testing.T) {
+		nodes, err := reconciler.selectCanaryNodes(context.Background(), &oneagent.CanarySpec{Percentage: ptr.To(int32(50))}, pods)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"node-a", "node-b"}, nodes)
+	})
+}
+
+func newCanaryDynaKube(canary *oneagent.CanarySpec) *dynakube.DynaKube {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: testCanaryDynaKube, Namespace: testCanaryNamespace},
+		Spec: dynakube.DynaKubeSpec{
+			OneAgent: oneagent.Spec{
+				HostMonitoring: &oneagent.HostInjectSpec{Canary: canary},
+			},
+		},
+	}
+	dk.Status.OneAgent.ImageID = testNewImage
+	dk.Status.OneAgent.Version = testNewVersion
+
+	return dk
+}
+
+func newCanaryReconciler(dk *dynakube.DynaKube, fakeClient client.Client) *Reconciler {
+	return &Reconciler{
+		client:       fakeClient,
+		apiReader:    fakeClient,
+		dk:           dk,
+		timeProvider: timeprovider.New().Freeze(),
+	}
+}
+
+func newOneAgentDaemonSet(dk *dynakube.DynaKube) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dk.OneAgent().GetDaemonsetName(),
+			Namespace: dk.Namespace,
+			Labels:    map[string]string{labels.AppVersionLabel: testPreviousVersion},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: testPreviousImage}}},
+			},
+		},
+	}
+}
+
+func newOneAgentPod(dk *dynakube.DynaKube, node, image string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oneagent-" + node,
+			Namespace: dk.Namespace,
+			Labels:    labels.NewAppLabels(labels.OneAgentComponentLabel, dk.Name, "", "").BuildMatchLabels(),
+		},
+		Spec: corev1.PodSpec{
+			NodeName:   node,
+			Containers: []corev1.Container{{Image: image}},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func assertPodDeleted(t *testing.T, fakeClient client.Client, node string) {
+	t.Helper()
+
+	err := fakeClient.Get(context.Background(), client.ObjectKey{Name: "oneagent-" + node, Namespace: testCanaryNamespace}, &corev1.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
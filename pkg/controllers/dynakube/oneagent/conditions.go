@@ -1,12 +1,17 @@
 package oneagent
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	oaConditionType = "OneAgentDaemonSet"
+	oaConditionType     = "OneAgentDaemonSet"
+	canaryConditionType = "OneAgentCanaryRollout"
 
 	daemonSetCreatedReason          = "DaemonSetCreated"
 	daemonSetGenerationFailedReason = "DaemonSetGenerationFailed"
@@ -31,3 +36,26 @@ func setDaemonSetGenerationFailedCondition(conditions *[]metav1.Condition, err e
 	}
 	_ = meta.SetStatusCondition(conditions, condition)
 }
+
+// setCanaryCondition reflects the phase of the canary rollout, the condition is only false if the rollout was aborted.
+func setCanaryCondition(conditions *[]metav1.Condition, canary *oneagent.CanaryStatus) {
+	condition := metav1.Condition{
+		Type:   canaryConditionType,
+		Status: metav1.ConditionTrue,
+		Reason: string(canary.Phase),
+	}
+
+	switch canary.Phase {
+	case oneagent.CanaryPhaseRollingOut:
+		condition.Message = fmt.Sprintf("Rolling out version %s to %d canary nodes, %d updated.", canary.Version, len(canary.Nodes), canary.UpdatedNodes)
+	case oneagent.CanaryPhaseBaking:
+		condition.Message = fmt.Sprintf("Version %s is baking on %d canary nodes since %s.", canary.Version, len(canary.Nodes), canary.BakeStartTimestamp.Format(time.RFC3339))
+	case oneagent.CanaryPhaseCompleted:
+		condition.Message = fmt.Sprintf("Version %s passed the canary nodes and is rolled out to all nodes.", canary.Version)
+	case oneagent.CanaryPhaseAborted:
+		condition.Status = metav1.ConditionFalse
+		condition.Message = fmt.Sprintf("Rollout of version %s was aborted, version %s is restored: %s", canary.Version, canary.PreviousVersion, canary.Message)
+	}
+
+	_ = meta.SetStatusCondition(conditions, condition)
+}
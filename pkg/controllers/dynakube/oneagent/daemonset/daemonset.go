@@ -186,6 +186,13 @@ func (b *builder) BuildDaemonSet() (*appsv1.DaemonSet, error) {
 		},
 	}
 
+	if dk.OneAgent().IsCanaryRolloutInProgress() {
+		// only the pods on the canary nodes are replaced, they are deleted by the canary rollout
+		result.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.OnDeleteDaemonSetStrategyType,
+		}
+	}
+
 	return result, nil
 }
 
@@ -0,0 +1,13 @@
+package oneagent
+
+import (
+	"context"
+	"time"
+)
+
+// RequeueingReconciler reconciles the OneAgent of a DynaKube, which may have to be reconciled again before the default update interval ends.
+type RequeueingReconciler interface {
+	Reconcile(ctx context.Context) error
+	// RequeueAfter returns the time after which the DynaKube has to be reconciled again, 0 if the default update interval is enough.
+	RequeueAfter() time.Duration
+}
@@ -51,7 +51,7 @@ type ReconcilerBuilder func(
 	dynakube *dynakube.DynaKube,
 	tokens token.Tokens,
 	clusterID string,
-) controllers.Reconciler
+) RequeueingReconciler
 
 // NewReconciler initializes a new ReconcileOneAgent instance
 func NewReconciler( //nolint
@@ -61,7 +61,7 @@ func NewReconciler( //nolint
 	dk *dynakube.DynaKube,
 	tokens token.Tokens,
 	clusterID string,
-) controllers.Reconciler {
+) RequeueingReconciler {
 	return &Reconciler{
 		client:                   client,
 		apiReader:                apiReader,
@@ -69,6 +69,7 @@ func NewReconciler( //nolint
 		dk:                       dk,
 		connectionInfoReconciler: oaconnectioninfo.NewReconciler(client, apiReader, dtClient, dk),
 		versionReconciler:        version.NewReconciler(apiReader, dtClient, timeprovider.New().Freeze()),
+		timeProvider:             timeprovider.New(),
 		tokens:                   tokens,
 	}
 }
@@ -80,9 +81,18 @@ type Reconciler struct {
 	apiReader                client.Reader
 	connectionInfoReconciler controllers.Reconciler
 	versionReconciler        version.Reconciler
+	timeProvider             *timeprovider.Provider
 	dk                       *dynakube.DynaKube
 	tokens                   token.Tokens
 	clusterID                string
+
+	// requeueAfter is the time until the running canary rollout has to be checked again, 0 if there is none.
+	requeueAfter time.Duration
+}
+
+// RequeueAfter returns the time after which the DynaKube has to be reconciled again, so the canary bake time and ready timeout are not missed.
+func (r *Reconciler) RequeueAfter() time.Duration {
+	return r.requeueAfter
 }
 
 // Reconcile reads that state of the cluster for a OneAgent object and makes changes based on the state read
@@ -127,11 +137,31 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	err = r.startCanary(ctx)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileRollout(ctx)
 	if err != nil {
 		return err
 	}
 
+	wasCanaryInProgress := r.dk.OneAgent().IsCanaryRolloutInProgress()
+
+	err = r.progressCanary(ctx)
+	if err != nil {
+		return err
+	}
+
+	if wasCanaryInProgress && !r.dk.OneAgent().IsCanaryRolloutInProgress() {
+		// the canary rollout completed or was aborted, the rest of the nodes get the new or the previous version
+		err = r.reconcileRollout(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = r.updateInstancesStatus(ctx)
 	if err != nil {
 		return err
@@ -84,7 +84,7 @@ func (r *Reconciler) ensureOtlpAPIEndpointConfigMap(ctx context.Context) error {
 }
 
 func (r *Reconciler) getDtEndpoint() (string, error) {
-	if r.dk.ActiveGate().IsEnabled() {
+	if r.dk.ActiveGate().IsDefaultEnabled() {
 		tenantUUID, err := r.dk.TenantUUID()
 		if err != nil {
 			return "", err
@@ -127,13 +127,14 @@ func (r *Reconciler) buildService() (*corev1.Service, error) {
 	return service.Build(r.dk,
 		r.dk.TelemetryIngest().GetServiceName(),
 		appLabels.BuildMatchLabels(),
-		buildServicePortList(r.dk.TelemetryIngest().GetProtocols()),
+		BuildServicePortList(r.dk.TelemetryIngest().GetProtocols()),
 		service.SetLabels(coreLabels.BuildLabels()),
 		service.SetType(corev1.ServiceTypeClusterIP),
 	)
 }
 
-func buildServicePortList(protocols []otelcgen.Protocol) []corev1.ServicePort {
+// BuildServicePortList returns the ports of the telemetry service for the given protocols.
+func BuildServicePortList(protocols []otelcgen.Protocol) []corev1.ServicePort {
 	if len(protocols) == 0 {
 		return nil
 	}
@@ -1,3 +1,5 @@
 package statefulset
 
 const conditionType string = "OtelStatefulSet"
+
+const podDisruptionBudgetConditionType string = "OtelPodDisruptionBudget"
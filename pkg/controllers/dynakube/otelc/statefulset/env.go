@@ -143,7 +143,7 @@ func getDynakubeNoProxyEnvValue(dk *dynakube.DynaKube) string {
 		noProxyValues = append(noProxyValues, dk.ExtensionsServiceNameFQDN())
 	}
 
-	if dk.ActiveGate().IsEnabled() {
+	if dk.ActiveGate().IsDefaultEnabled() {
 		noProxyValues = append(noProxyValues, capability.BuildServiceName(dk.Name)+"."+dk.Namespace)
 	}
 
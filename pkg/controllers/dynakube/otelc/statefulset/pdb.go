@@ -0,0 +1,20 @@
+package statefulset
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	k8spdb "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pdb"
+)
+
+func (r *Reconciler) reconcilePodDisruptionBudget(ctx context.Context) error {
+	var budget *pdb.Spec
+	if r.dk.IsExtensionsEnabled() || r.dk.TelemetryIngest().IsEnabled() {
+		budget = pdb.GetBudget(r.dk.Spec.Templates.OpenTelemetryCollector.PodDisruptionBudget, getReplicas(r.dk))
+	}
+
+	appLabels := buildAppLabels(r.dk.Name)
+
+	return k8spdb.NewReconciler(r.client, r.apiReader, log, r.dk, podDisruptionBudgetConditionType).
+		Reconcile(ctx, r.dk.OtelCollectorStatefulsetName(), appLabels.BuildMatchLabels(), budget, k8spdb.SetLabels(appLabels.BuildLabels()))
+}
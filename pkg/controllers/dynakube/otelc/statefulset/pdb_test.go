@@ -0,0 +1,77 @@
+package statefulset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestReconcilePodDisruptionBudget(t *testing.T) {
+	ctx := context.Background()
+	minAvailable := intstr.FromInt32(1)
+
+	t.Run("no budget for single replica => no PodDisruptionBudget", func(t *testing.T) {
+		dk := getTestDynakubeWithExtensions()
+
+		mockK8sClient := fake.NewClient()
+		mockK8sClient = mockTLSSecret(t, mockK8sClient, dk)
+
+		err := NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		var podDisruptionBudget policyv1.PodDisruptionBudget
+		err = mockK8sClient.Get(ctx, types.NamespacedName{Name: dk.OtelCollectorStatefulsetName(), Namespace: dk.Namespace}, &podDisruptionBudget)
+		require.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), podDisruptionBudgetConditionType))
+	})
+	t.Run("configured budget => PodDisruptionBudget created", func(t *testing.T) {
+		dk := getTestDynakubeWithExtensions()
+		dk.Spec.Templates.OpenTelemetryCollector.PodDisruptionBudget = &pdb.Spec{MinAvailable: &minAvailable}
+
+		mockK8sClient := fake.NewClient()
+		mockK8sClient = mockTLSSecret(t, mockK8sClient, dk)
+
+		err := NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		var podDisruptionBudget policyv1.PodDisruptionBudget
+		err = mockK8sClient.Get(ctx, types.NamespacedName{Name: dk.OtelCollectorStatefulsetName(), Namespace: dk.Namespace}, &podDisruptionBudget)
+		require.NoError(t, err)
+		assert.Equal(t, minAvailable, *podDisruptionBudget.Spec.MinAvailable)
+		assert.Equal(t, buildAppLabels(dk.Name).BuildMatchLabels(), podDisruptionBudget.Spec.Selector.MatchLabels)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), podDisruptionBudgetConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.PodDisruptionBudgetCreatedReason, condition.Reason)
+	})
+	t.Run("collector not needed anymore => PodDisruptionBudget deleted", func(t *testing.T) {
+		dk := getTestDynakubeWithExtensions()
+		dk.Spec.Templates.OpenTelemetryCollector.PodDisruptionBudget = &pdb.Spec{MinAvailable: &minAvailable}
+
+		mockK8sClient := fake.NewClient()
+		mockK8sClient = mockTLSSecret(t, mockK8sClient, dk)
+
+		err := NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.Extensions = nil
+
+		err = NewReconciler(mockK8sClient, mockK8sClient, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		var podDisruptionBudget policyv1.PodDisruptionBudget
+		err = mockK8sClient.Get(ctx, types.NamespacedName{Name: dk.OtelCollectorStatefulsetName(), Namespace: dk.Namespace}, &podDisruptionBudget)
+		require.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), podDisruptionBudgetConditionType))
+	})
+}
@@ -47,8 +47,17 @@ func NewReconciler(clt client.Client,
 
 func (r *Reconciler) Reconcile(ctx context.Context) error {
 	if r.dk.IsExtensionsEnabled() || r.dk.TelemetryIngest().IsEnabled() {
-		return r.createOrUpdateStatefulset(ctx)
+		err := r.createOrUpdateStatefulset(ctx)
+		if err != nil {
+			return err
+		}
+
+		return r.reconcilePodDisruptionBudget(ctx)
 	} else { // do cleanup or
+		if err := r.reconcilePodDisruptionBudget(ctx); err != nil {
+			return err
+		}
+
 		if meta.FindStatusCondition(*r.dk.Conditions(), conditionType) == nil {
 			return nil
 		}
@@ -0,0 +1,75 @@
+package dynakube
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func rolloutStatuses(dk *dynakube.DynaKube) []update.RolloutStatus {
+	return []update.RolloutStatus{
+		dk.Status.OneAgent.RolloutStatus,
+		dk.Status.ActiveGate.RolloutStatus,
+		dk.Status.CodeModules.RolloutStatus,
+	}
+}
+
+func hasPendingUpdate(dk *dynakube.DynaKube) bool {
+	for _, rollout := range rolloutStatuses(dk) {
+		if rollout.HasPendingUpdate() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requeueForMaintenanceWindow makes sure that held back updates are rolled out when the next maintenance window opens,
+// windows shorter than the default update interval would be missed otherwise.
+func requeueForMaintenanceWindow(state *reconcileState, dk *dynakube.DynaKube, now time.Time) {
+	if !hasPendingUpdate(dk) {
+		return
+	}
+
+	start, ok, err := dk.NextMaintenanceWindowStart(now)
+	if err != nil {
+		log.Info("could not determine the start of the next maintenance window", "error", err.Error())
+
+		return
+	} else if !ok {
+		return
+	}
+
+	log.Info("updates are held back until the next maintenance window", "start", start)
+	state.setRequeueAfterIfNewIsShorter(start.Sub(now))
+}
+
+// isRolloutNowConsumed checks if the rollout was forced and nothing is held back anymore,
+// so the rollout-now annotation has done its job and must not disable the maintenance windows any longer.
+func isRolloutNowConsumed(dk *dynakube.DynaKube) bool {
+	return dk.IsRolloutForced() && !hasPendingUpdate(dk)
+}
+
+// consumeRolloutNow removes the rollout-now annotation, once the forced rollout happened.
+func (controller *Controller) consumeRolloutNow(ctx context.Context, dk *dynakube.DynaKube) error {
+	consumed := dk.DeepCopy()
+	delete(consumed.Annotations, api.AnnotationRolloutNow)
+
+	err := controller.client.Update(ctx, consumed)
+	if k8serrors.IsConflict(err) {
+		log.Info("could not remove the rollout-now annotation due to conflict, retrying with next reconcile", "name", dk.Name)
+
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	log.Info("forced rollout done, removed the rollout-now annotation", "name", dk.Name, "annotation", api.AnnotationRolloutNow)
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package dynakube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRequeueForMaintenanceWindow(t *testing.T) {
+	// 2025-01-06 is a Monday
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	newDynaKube := func(pendingVersion string) *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{Spec: dynakube.DynaKubeSpec{MaintenanceWindows: []dynakube.MaintenanceWindow{
+			{Start: "09:10", End: "09:20"},
+		}}}
+		dk.Status.OneAgent.PendingVersion = pendingVersion
+
+		return dk
+	}
+
+	t.Run("pending update => requeue for the start of the next window", func(t *testing.T) {
+		state := newReconcileState()
+
+		requeueForMaintenanceWindow(state, newDynaKube("1.2.3"), now)
+
+		assert.Equal(t, 10*time.Minute, state.requeueAfter)
+	})
+	t.Run("no pending update => default interval", func(t *testing.T) {
+		state := newReconcileState()
+
+		requeueForMaintenanceWindow(state, newDynaKube(""), now)
+
+		assert.Equal(t, defaultUpdateInterval, state.requeueAfter)
+	})
+	t.Run("window starting after the default interval => default interval", func(t *testing.T) {
+		state := newReconcileState()
+
+		requeueForMaintenanceWindow(state, newDynaKube("1.2.3"), now.Add(-time.Hour))
+
+		assert.Equal(t, defaultUpdateInterval, state.requeueAfter)
+	})
+}
+
+func TestConsumeRolloutNow(t *testing.T) {
+	ctx := context.Background()
+	request := types.NamespacedName{Name: testName, Namespace: testNamespace}
+
+	newDynaKube := func(pendingVersion string) *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testName,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{api.AnnotationRolloutNow: "true", "other": "annotation"},
+			},
+		}
+		dk.Status.ActiveGate.PendingVersion = pendingVersion
+
+		return dk
+	}
+
+	t.Run("forced rollout without pending update => consumed", func(t *testing.T) {
+		assert.True(t, isRolloutNowConsumed(newDynaKube("")))
+	})
+	t.Run("pending update => not consumed", func(t *testing.T) {
+		assert.False(t, isRolloutNowConsumed(newDynaKube("1.2.3")))
+	})
+	t.Run("rollout not forced => not consumed", func(t *testing.T) {
+		assert.False(t, isRolloutNowConsumed(&dynakube.DynaKube{}))
+	})
+	t.Run("annotation is removed, other annotations are kept", func(t *testing.T) {
+		dk := newDynaKube("")
+		fakeClient := fake.NewClient(dk)
+		controller := &Controller{client: fakeClient}
+
+		require.NoError(t, fakeClient.Get(ctx, request, dk))
+		require.NoError(t, controller.consumeRolloutNow(ctx, dk))
+
+		require.NoError(t, fakeClient.Get(ctx, request, dk))
+		assert.False(t, dk.IsRolloutForced())
+		assert.Equal(t, "annotation", dk.Annotations["other"])
+	})
+	t.Run("conflict => retried with next reconcile", func(t *testing.T) {
+		dk := newDynaKube("")
+		fakeClient := fake.NewClient(dk)
+		controller := &Controller{client: fakeClient}
+
+		require.NoError(t, fakeClient.Get(ctx, request, dk))
+
+		dk.ResourceVersion = "0"
+		require.NoError(t, controller.consumeRolloutNow(ctx, dk))
+
+		require.NoError(t, fakeClient.Get(ctx, request, dk))
+		assert.True(t, dk.IsRolloutForced())
+	})
+}
@@ -0,0 +1,29 @@
+package dynakube
+
+import (
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+)
+
+// reconcileState holds everything collected during a single reconcile of a DynaKube.
+// It is created per request, so the Controller stays read-only and can reconcile several DynaKubes concurrently.
+type reconcileState struct {
+	tokens       token.Tokens
+	timings      []subReconcilerTiming
+	requeueAfter time.Duration
+	// rolloutNowConsumed is set once a forced rollout happened, the annotation is removed after the status is updated
+	rolloutNowConsumed bool
+}
+
+func newReconcileState() *reconcileState {
+	return &reconcileState{
+		requeueAfter: defaultUpdateInterval,
+	}
+}
+
+func (state *reconcileState) setRequeueAfterIfNewIsShorter(requeueAfter time.Duration) {
+	if state.requeueAfter > requeueAfter {
+		state.requeueAfter = requeueAfter
+	}
+}
@@ -0,0 +1,82 @@
+package dynakube
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Names of the sub-reconcilers, used as label of the sub-reconciler metrics.
+const (
+	istioSubReconciler              = "istio"
+	tokensSubReconciler             = "tokens"
+	deploymentMetadataSubReconciler = "deployment-metadata"
+	proxySubReconciler              = "proxy"
+	activeGateSubReconciler         = "activegate"
+	extensionSubReconciler          = "extension"
+	otelcSubReconciler              = "otelc"
+	logMonitoringSubReconciler      = "logmonitoring"
+	injectionSubReconciler          = "injection"
+	oneAgentSubReconciler           = "oneagent"
+	kspmSubReconciler               = "kspm"
+	networkPolicySubReconciler      = "networkpolicy"
+)
+
+// subReconcilerTiming is the outcome of a single sub-reconciler run during a reconcile of a DynaKube.
+type subReconcilerTiming struct {
+	name     string
+	duration time.Duration
+	failed   bool
+}
+
+// runSubReconciler runs the given sub-reconciler in its own span, recording its duration, errors and last success in the sub-reconciler metrics.
+func (state *reconcileState) runSubReconciler(ctx context.Context, dk *dynakube.DynaKube, name string, reconcile func(ctx context.Context) error) error {
+	ctx, span := tracing.Start(ctx, "dynakube.subreconciler."+name, tracing.ReconcilerKey.String(name))
+
+	start := time.Now()
+	err := reconcile(ctx)
+	duration := time.Since(start)
+
+	tracing.End(span, err)
+
+	labels := prometheus.Labels{"namespace": dk.Namespace, "name": dk.Name, "reconciler": name}
+	subReconcilerDurationMetric.With(labels).Observe(duration.Seconds())
+
+	if err != nil {
+		subReconcilerErrorsMetric.With(labels).Inc()
+	} else {
+		subReconcilerLastSuccessMetric.With(labels).SetToCurrentTime()
+	}
+
+	state.timings = append(state.timings, subReconcilerTiming{name: name, duration: duration, failed: err != nil})
+
+	return err
+}
+
+// logTimings summarizes the durations of all sub-reconcilers of the reconcile, only visible with debug logs enabled.
+func (state *reconcileState) logTimings(dk *dynakube.DynaKube, total time.Duration) {
+	timings := make([]string, 0, len(state.timings))
+
+	for _, timing := range state.timings {
+		summary := timing.name + "=" + timing.duration.Round(time.Millisecond).String()
+		if timing.failed {
+			summary += " (failed)"
+		}
+
+		timings = append(timings, summary)
+	}
+
+	log.Debug("sub-reconciler timings", "namespace", dk.Namespace, "name", dk.Name, "total", total.Round(time.Millisecond).String(), "timings", timings)
+}
+
+// deleteSubReconcilerMetrics removes the metrics of a deleted DynaKube, so they are not reported forever.
+func deleteSubReconcilerMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+
+	subReconcilerDurationMetric.DeletePartialMatch(labels)
+	subReconcilerErrorsMetric.DeletePartialMatch(labels)
+	subReconcilerLastSuccessMetric.DeletePartialMatch(labels)
+}
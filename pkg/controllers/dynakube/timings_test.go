@@ -0,0 +1,54 @@
+package dynakube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunSubReconciler(t *testing.T) {
+	dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "timings", Namespace: testNamespace}}
+
+	labels := func(reconciler string) prometheus.Labels {
+		return prometheus.Labels{"namespace": dk.Namespace, "name": dk.Name, "reconciler": reconciler}
+	}
+
+	t.Run("records successful and failed runs", func(t *testing.T) {
+		state := newReconcileState()
+
+		require.NoError(t, state.runSubReconciler(context.Background(), dk, oneAgentSubReconciler, func(context.Context) error { return nil }))
+		require.Error(t, state.runSubReconciler(context.Background(), dk, activeGateSubReconciler, func(context.Context) error { return errors.New("BOOM") }))
+
+		require.Len(t, state.timings, 2)
+		assert.Equal(t, oneAgentSubReconciler, state.timings[0].name)
+		assert.False(t, state.timings[0].failed)
+		assert.Equal(t, activeGateSubReconciler, state.timings[1].name)
+		assert.True(t, state.timings[1].failed)
+
+		assert.Positive(t, testutil.ToFloat64(subReconcilerLastSuccessMetric.With(labels(oneAgentSubReconciler))))
+		assert.InDelta(t, 0, testutil.ToFloat64(subReconcilerErrorsMetric.With(labels(oneAgentSubReconciler))), 0)
+		assert.InDelta(t, 1, testutil.ToFloat64(subReconcilerErrorsMetric.With(labels(activeGateSubReconciler))), 0)
+		assert.InDelta(t, 0, testutil.ToFloat64(subReconcilerLastSuccessMetric.With(labels(activeGateSubReconciler))), 0)
+
+		state.logTimings(dk, 0)
+	})
+	t.Run("metrics of deleted dynakube are removed", func(t *testing.T) {
+		state := newReconcileState()
+
+		require.NoError(t, state.runSubReconciler(context.Background(), dk, kspmSubReconciler, func(context.Context) error { return nil }))
+
+		deleteSubReconcilerMetrics(dk.Namespace, dk.Name)
+
+		// Delete reports whether the series still existed
+		assert.False(t, subReconcilerDurationMetric.Delete(labels(kspmSubReconciler)))
+		assert.False(t, subReconcilerErrorsMetric.Delete(labels(kspmSubReconciler)))
+		assert.False(t, subReconcilerLastSuccessMetric.Delete(labels(kspmSubReconciler)))
+	})
+}
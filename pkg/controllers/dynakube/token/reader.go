@@ -60,6 +60,10 @@ func (reader Reader) readTokens(ctx context.Context) (Tokens, error) {
 }
 
 func (reader Reader) verifyAPITokenExists(tokens Tokens) error {
+	if tokens.IsOAuth() {
+		return nil
+	}
+
 	apiToken, hasAPIToken := tokens[dtclient.APIToken]
 
 	if !hasAPIToken || len(apiToken.Value) == 0 {
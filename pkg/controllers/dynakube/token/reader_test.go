@@ -103,6 +103,20 @@ func testVerifyTokens(t *testing.T) {
 			},
 		})
 
+		require.NoError(t, err)
+	})
+	t.Run("no error if oauth client credentials exist", func(t *testing.T) {
+		reader := NewReader(nil, nil)
+
+		err := reader.verifyAPITokenExists(map[string]*Token{
+			dtclient.OAuthClientID: {
+				Value: "client-id",
+			},
+			dtclient.OAuthClientSecret: {
+				Value: "client-secret",
+			},
+		})
+
 		require.NoError(t, err)
 	})
 }
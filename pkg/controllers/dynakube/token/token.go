@@ -32,7 +32,7 @@ func (token *Token) verifyScopes(ctx context.Context, dtClient dtclient.Client,
 		return nil
 	}
 
-	scopes, err := dtClient.GetTokenScopes(ctx, token.Value)
+	scopes, err := token.getScopes(ctx, dtClient)
 	if err != nil {
 		return err
 	}
@@ -58,6 +58,15 @@ func (token *Token) verifyScopes(ctx context.Context, dtClient dtclient.Client,
 	return nil
 }
 
+// getScopes looks up the scopes of the token, for OAuth clients the scopes granted with the bearer token are used.
+func (token *Token) getScopes(ctx context.Context, dtClient dtclient.Client) (dtclient.TokenScopes, error) {
+	if token.Type == dtclient.OAuthClientID {
+		return dtClient.GetOAuthScopes(ctx)
+	}
+
+	return dtClient.GetTokenScopes(ctx, token.Value)
+}
+
 func (token *Token) verifyValue() error {
 	if strings.TrimSpace(token.Value) != token.Value {
 		return errors.Errorf("token '%s' contains leading or trailing whitespaces", token.Type)
@@ -3,6 +3,7 @@ package token
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
@@ -23,6 +24,21 @@ func (tokens Tokens) DataIngestToken() *Token {
 	return tokens.getToken(dtclient.DataIngestToken)
 }
 
+// IsOAuth returns true if the tokens secret holds OAuth client credentials, which are used instead of the apiToken and paasToken.
+func (tokens Tokens) IsOAuth() bool {
+	return tokens.getToken(dtclient.OAuthClientID).Value != "" && tokens.getToken(dtclient.OAuthClientSecret).Value != ""
+}
+
+func (tokens Tokens) OAuthCredentials() dtclient.OAuthCredentials {
+	return dtclient.OAuthCredentials{
+		ClientID:      tokens.getToken(dtclient.OAuthClientID).Value,
+		ClientSecret:  tokens.getToken(dtclient.OAuthClientSecret).Value,
+		TokenEndpoint: tokens.getToken(dtclient.OAuthTokenEndpoint).Value,
+		Resource:      tokens.getToken(dtclient.OAuthResource).Value,
+		Scopes:        strings.Fields(tokens.getToken(dtclient.OAuthScopes).Value),
+	}
+}
+
 func (tokens Tokens) getToken(tokenName string) *Token {
 	token, hasToken := tokens[tokenName]
 	if !hasToken {
@@ -43,6 +59,8 @@ func (tokens Tokens) AddFeatureScopesToTokens() Tokens {
 			token.addFeatures(getFeaturesForPaaSToken())
 		case dtclient.DataIngestToken:
 			token.addFeatures(getFeaturesForDataIngest())
+		case dtclient.OAuthClientID:
+			token.addFeatures(getFeaturesForAPIToken(hasPaasToken))
 		}
 	}
 
@@ -159,6 +159,62 @@ func TestTokens(t *testing.T) {
 		assert.Len(t, tokens.DataIngestToken().Features, 1)
 		assert.NoError(t, err)
 	})
+	t.Run("oauth client with all granted scopes => success", func(t *testing.T) {
+		clientID := newToken(dtclient.OAuthClientID, "client-id")
+		clientSecret := newToken(dtclient.OAuthClientSecret, "client-secret")
+		tokens := Tokens{
+			dtclient.OAuthClientID:     &clientID,
+			dtclient.OAuthClientSecret: &clientSecret,
+		}
+		fakeClient := dtclientmock.NewClient(t)
+		fakeClient.On("GetOAuthScopes", mock.Anything).
+			Return(append(getAllScopesForAPIToken(), getAllScopesForPaaSToken()...), nil)
+
+		tokens = tokens.AddFeatureScopesToTokens()
+		err := tokens.VerifyScopes(context.Background(), fakeClient, *enableKubernetesMonitoringAndMetricsIngest(&dynakube.DynaKube{}))
+
+		assert.True(t, tokens.IsOAuth())
+		assert.Len(t, tokens[dtclient.OAuthClientID].Features, 3)
+		assert.Empty(t, tokens[dtclient.OAuthClientSecret].Features)
+		assert.NoError(t, err)
+	})
+	t.Run("oauth client with missing scopes => fail", func(t *testing.T) {
+		clientID := newToken(dtclient.OAuthClientID, "client-id")
+		clientSecret := newToken(dtclient.OAuthClientSecret, "client-secret")
+		tokens := Tokens{
+			dtclient.OAuthClientID:     &clientID,
+			dtclient.OAuthClientSecret: &clientSecret,
+		}
+		fakeClient := dtclientmock.NewClient(t)
+		fakeClient.On("GetOAuthScopes", mock.Anything).Return(getAllScopesForAPIToken(), nil)
+
+		tokens = tokens.AddFeatureScopesToTokens()
+		err := tokens.VerifyScopes(context.Background(), fakeClient, dynakube.DynaKube{})
+
+		assert.EqualError(t, err, "token 'oauthClientId' has scope errors: [feature 'Download Installer' is missing scope 'InstallerDownload']")
+	})
+}
+
+func TestTokens_OAuthCredentials(t *testing.T) {
+	tokens := Tokens{}
+
+	for key, value := range map[string]string{
+		dtclient.OAuthClientID:      "client-id",
+		dtclient.OAuthClientSecret:  "client-secret",
+		dtclient.OAuthTokenEndpoint: "https://sso.example.com/token",
+		dtclient.OAuthScopes:        "settings:objects:read  settings:objects:write",
+	} {
+		token := newToken(key, value)
+		tokens[key] = &token
+	}
+
+	assert.Equal(t, dtclient.OAuthCredentials{
+		ClientID:      "client-id",
+		ClientSecret:  "client-secret",
+		TokenEndpoint: "https://sso.example.com/token",
+		Scopes:        []string{"settings:objects:read", "settings:objects:write"},
+	}, tokens.OAuthCredentials())
+	assert.False(t, Tokens{dtclient.OAuthClientID: tokens[dtclient.OAuthClientID]}.IsOAuth())
 }
 
 func enableKubernetesMonitoringAndMetricsIngest(dk *dynakube.DynaKube) *dynakube.DynaKube {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
@@ -53,6 +54,10 @@ func (updater *activeGateUpdater) Target() *status.VersionStatus {
 	return &updater.dk.Status.ActiveGate.VersionStatus
 }
 
+func (updater *activeGateUpdater) Rollout() *update.RolloutStatus {
+	return &updater.dk.Status.ActiveGate.RolloutStatus
+}
+
 func (updater activeGateUpdater) CustomImage() string {
 	customImage := updater.dk.ActiveGate().GetCustomImage()
 	if customImage != "" {
@@ -101,9 +106,30 @@ func (updater *activeGateUpdater) UseTenantRegistry(ctx context.Context) error {
 		return err
 	}
 
-	defaultImage := updater.dk.ActiveGate().GetDefaultImage(latestVersion)
+	return updater.UseTenantRegistryVersion(ctx, latestVersion)
+}
+
+func (updater activeGateUpdater) UpdatePolicy() *update.Policy {
+	return updater.dk.ActiveGate().UpdatePolicy
+}
+
+// AvailableVersions only returns the latest version, the tenant doesn't provide older ActiveGate versions.
+func (updater activeGateUpdater) AvailableVersions(ctx context.Context) ([]string, error) {
+	latestVersion, err := updater.dtClient.GetLatestActiveGateVersion(ctx, dtclient.OsUnix)
+	if err != nil {
+		log.Info("failed to determine image version", "error", err)
+		conditions.SetDynatraceAPIError(updater.dk.Conditions(), activeGateVersionConditionType, err)
+
+		return nil, err
+	}
+
+	return []string{latestVersion}, nil
+}
+
+func (updater *activeGateUpdater) UseTenantRegistryVersion(_ context.Context, version string) error {
+	defaultImage := updater.dk.ActiveGate().GetDefaultImage(version)
 
-	err = updateVersionStatusForTenantRegistry(updater.Target(), defaultImage, latestVersion)
+	err := updateVersionStatusForTenantRegistry(updater.Target(), defaultImage, version)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
@@ -46,6 +47,10 @@ func (updater *codeModulesUpdater) Target() *status.VersionStatus {
 	return &updater.dk.Status.CodeModules.VersionStatus
 }
 
+func (updater *codeModulesUpdater) Rollout() *update.RolloutStatus {
+	return &updater.dk.Status.CodeModules.RolloutStatus
+}
+
 func (updater codeModulesUpdater) CustomImage() string {
 	customImage := updater.dk.OneAgent().GetCustomCodeModulesImage()
 	if customImage != "" {
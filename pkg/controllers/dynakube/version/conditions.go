@@ -3,6 +3,7 @@ package version
 import (
 	"fmt"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -12,6 +13,7 @@ const (
 	verifiedReason            = "Verified"
 	verificationSkippedReason = "VerificationSkipped"
 	verificationFailedReason  = "VerificationFailed"
+	rolledBackReason          = "RolledBack"
 )
 
 func setDowngradeCondition(conditions *[]metav1.Condition, conditionType, previousVersion, newVersion string) {
@@ -53,3 +55,13 @@ func setVerificationFailedReasonCondition(conditions *[]metav1.Condition, condit
 	}
 	_ = meta.SetStatusCondition(conditions, condition)
 }
+
+func setRolledBackCondition(conditions *[]metav1.Condition, conditionType string, rolledBack *update.RolledBackVersion, currentVersion string) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  rolledBackReason,
+		Message: fmt.Sprintf("Rollout of version %s failed, due to: %s. Rolled back to %s, the failed version is only rolled out again if forced.", rolledBack.Version, rolledBack.Reason, currentVersion),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	"github.com/pkg/errors"
@@ -54,6 +56,10 @@ func (updater *oneAgentUpdater) Target() *status.VersionStatus {
 	return &updater.dk.Status.OneAgent.VersionStatus
 }
 
+func (updater *oneAgentUpdater) Rollout() *update.RolloutStatus {
+	return &updater.dk.Status.OneAgent.RolloutStatus
+}
+
 func (updater oneAgentUpdater) CustomImage() string {
 	customImage := updater.dk.OneAgent().GetCustomImage()
 	if customImage != "" {
@@ -105,14 +111,34 @@ func (updater oneAgentUpdater) UseTenantRegistry(ctx context.Context) error {
 		}
 	}
 
-	downgrade, err := updater.CheckForDowngrade(latestVersion)
+	return updater.UseTenantRegistryVersion(ctx, latestVersion)
+}
+
+func (updater oneAgentUpdater) UpdatePolicy() *update.Policy {
+	return updater.dk.OneAgent().GetUpdatePolicy()
+}
+
+func (updater oneAgentUpdater) AvailableVersions(ctx context.Context) ([]string, error) {
+	versions, err := updater.dtClient.GetAgentVersions(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault, arch.FlavorDefault)
+	if err != nil {
+		log.Info("failed to determine available image versions")
+		conditions.SetDynatraceAPIError(updater.dk.Conditions(), oaConditionType, err)
+
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (updater oneAgentUpdater) UseTenantRegistryVersion(_ context.Context, version string) error {
+	downgrade, err := updater.CheckForDowngrade(version)
 	if err != nil || downgrade {
 		return err
 	}
 
-	defaultImage := updater.dk.OneAgent().GetDefaultImage(latestVersion)
+	defaultImage := updater.dk.OneAgent().GetDefaultImage(version)
 
-	err = updateVersionStatusForTenantRegistry(updater.Target(), defaultImage, latestVersion)
+	err = updateVersionStatusForTenantRegistry(updater.Target(), defaultImage, version)
 	if err != nil {
 		return err
 	}
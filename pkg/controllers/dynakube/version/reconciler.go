@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
@@ -45,6 +46,12 @@ func (r *reconciler) ReconcileCodeModules(ctx context.Context, dk *dynakube.Dyna
 
 func (r *reconciler) ReconcileOneAgent(ctx context.Context, dk *dynakube.DynaKube) error {
 	updater := newOneAgentUpdater(dk, r.apiReader, r.dtClient)
+
+	err := r.checkRollout(ctx, updater, dk)
+	if err != nil {
+		return err
+	}
+
 	if r.needsUpdate(updater, dk) {
 		return r.updateVersionStatuses(ctx, updater, dk)
 	}
@@ -54,6 +61,12 @@ func (r *reconciler) ReconcileOneAgent(ctx context.Context, dk *dynakube.DynaKub
 
 func (r *reconciler) ReconcileActiveGate(ctx context.Context, dk *dynakube.DynaKube) error {
 	updater := newActiveGateUpdater(dk, r.apiReader, r.dtClient)
+
+	err := r.checkRollout(ctx, updater, dk)
+	if err != nil {
+		return err
+	}
+
 	if r.needsUpdate(updater, dk) {
 		err := r.updateVersionStatuses(ctx, updater, dk)
 
@@ -66,7 +79,14 @@ func (r *reconciler) ReconcileActiveGate(ctx context.Context, dk *dynakube.DynaK
 func (r *reconciler) updateVersionStatuses(ctx context.Context, updater StatusUpdater, dk *dynakube.DynaKube) error {
 	log.Info("updating version status", "updater", updater.Name())
 
+	previous := *updater.Target()
+
 	err := r.run(ctx, updater)
+
+	r.holdBackOutsideMaintenanceWindow(updater, dk, previous)
+	holdBackAbortedCanary(updater, dk, previous)
+	holdBackRolledBack(updater, dk, previous)
+
 	if err != nil {
 		if updater.Target().ImageID == "" && updater.Target().Version == "" {
 			log.Info("unable to set version info, no previous version to fallback to", "component", updater.Name())
@@ -77,19 +97,24 @@ func (r *reconciler) updateVersionStatuses(ctx context.Context, updater StatusUp
 		log.Error(err, "unable to refresh version info, moving on with version from previous run", "component", updater.Name())
 	}
 
-	_, ok := updater.(*oneAgentUpdater)
-	if ok {
-		healthConfig, err := getOneAgentHealthConfig(dk.OneAgent().GetVersion())
-		if err != nil {
-			log.Error(err, "could not set OneAgent healthcheck")
-		} else {
-			dk.Status.OneAgent.Healthcheck = healthConfig
-		}
-	}
+	setOneAgentHealthcheck(updater, dk)
 
 	return nil
 }
 
+func setOneAgentHealthcheck(updater StatusUpdater, dk *dynakube.DynaKube) {
+	if _, ok := updater.(*oneAgentUpdater); !ok {
+		return
+	}
+
+	healthConfig, err := getOneAgentHealthConfig(dk.OneAgent().GetVersion())
+	if err != nil {
+		log.Error(err, "could not set OneAgent healthcheck")
+	} else {
+		dk.Status.OneAgent.Healthcheck = healthConfig
+	}
+}
+
 func (r *reconciler) needsUpdate(updater StatusUpdater, dk *dynakube.DynaKube) bool {
 	if !updater.IsEnabled() {
 		log.Info("skipping version status update for disabled section", "updater", updater.Name())
@@ -107,6 +132,12 @@ func (r *reconciler) needsUpdate(updater StatusUpdater, dk *dynakube.DynaKube) b
 		return true
 	}
 
+	if updater.Rollout().HasPendingUpdate() && r.isUpdateAllowed(dk) {
+		log.Info("pending version can be rolled out, update for version status is needed", "updater", updater.Name())
+
+		return true
+	}
+
 	if !r.timeProvider.IsOutdated(updater.Target().LastProbeTimestamp, dk.APIRequestThreshold()) {
 		log.Info("status timestamp still valid, skipping version status updater", "updater", updater.Name())
 
@@ -116,6 +147,70 @@ func (r *reconciler) needsUpdate(updater StatusUpdater, dk *dynakube.DynaKube) b
 	return true
 }
 
+// holdBackOutsideMaintenanceWindow reverts an automatic update of the version status if no maintenance window is open,
+// the new version is recorded as pending instead. Initial rollouts and changes made by the user to the spec are never held back.
+func (r *reconciler) holdBackOutsideMaintenanceWindow(updater StatusUpdater, dk *dynakube.DynaKube, previous status.VersionStatus) {
+	target := updater.Target()
+
+	isUnchanged := target.ImageID == previous.ImageID && target.Version == previous.Version
+	isInitial := previous.ImageID == "" && previous.Version == ""
+	isUserChange := target.Source != previous.Source || target.Source == status.CustomImageVersionSource || target.Source == status.CustomVersionVersionSource
+
+	rollout := updater.Rollout()
+
+	if isUnchanged || isInitial || isUserChange || r.isUpdateAllowed(dk) {
+		rollout.PendingVersion = ""
+		rollout.PendingImageID = ""
+
+		return
+	}
+
+	log.Info("no maintenance window is open, holding back new version", "updater", updater.Name(), "version", previous.Version, "pendingVersion", target.Version)
+
+	rollout.PendingVersion = target.Version
+	rollout.PendingImageID = target.ImageID
+	target.Version = previous.Version
+	target.ImageID = previous.ImageID
+}
+
+// holdBackAbortedCanary keeps the OneAgent on the version it was rolled back to, after the canary rollout of the new version was aborted.
+// Only a different version starts the next rollout.
+func holdBackAbortedCanary(updater StatusUpdater, dk *dynakube.DynaKube, previous status.VersionStatus) {
+	if _, ok := updater.(*oneAgentUpdater); !ok {
+		return
+	}
+
+	canary := dk.Status.OneAgent.Canary
+	if canary == nil || canary.Phase != oneagent.CanaryPhaseAborted || dk.OneAgent().GetCanary() == nil {
+		return
+	}
+
+	target := updater.Target()
+	if target.ImageID != canary.ImageID || previous.ImageID == canary.ImageID {
+		return
+	}
+
+	log.Info("canary rollout of this version was aborted, keeping the previous one", "updater", updater.Name(), "version", previous.Version, "abortedVersion", canary.Version)
+
+	target.ImageID = previous.ImageID
+	target.Version = previous.Version
+}
+
+func (r *reconciler) isUpdateAllowed(dk *dynakube.DynaKube) bool {
+	if dk.IsRolloutForced() {
+		return true
+	}
+
+	isOpen, err := dk.IsInMaintenanceWindow(r.timeProvider.Now().Time)
+	if err != nil {
+		log.Error(err, "invalid maintenance window, updates are held back until it is fixed")
+
+		return false
+	}
+
+	return isOpen
+}
+
 func hasCustomFieldChanged(updater StatusUpdater) bool {
 	if updater.Target().Source == status.CustomImageVersionSource {
 		oldImage := updater.Target().ImageID
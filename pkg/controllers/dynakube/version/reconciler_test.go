@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
@@ -162,6 +163,155 @@ func TestReconcile(t *testing.T) {
 	})
 }
 
+func TestMaintenanceWindows(t *testing.T) {
+	ctx := context.Background()
+	previousVersion := "1.2.2.3-4"
+	latestVersion := "1.2.3.4-5"
+
+	// 2025-01-06 is a Monday
+	duringWindow := time.Date(2025, 1, 6, 23, 0, 0, 0, time.UTC)
+	outsideWindow := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+
+	newDynaKube := func(probe time.Time) *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:   testAPIURL,
+				OneAgent: oneagent.Spec{ApplicationMonitoring: &oneagent.ApplicationMonitoringSpec{}},
+				MaintenanceWindows: []dynakube.MaintenanceWindow{
+					{Start: "22:00", End: "02:00"},
+				},
+			},
+		}
+		dk.Status.CodeModules.VersionStatus = status.VersionStatus{
+			Version:            previousVersion,
+			Source:             status.TenantRegistryVersionSource,
+			LastProbeTimestamp: &metav1.Time{Time: probe.Add(-time.Hour)},
+		}
+
+		return dk
+	}
+
+	newReconciler := func(t *testing.T, now time.Time) *reconciler {
+		mockClient := dtclientmock.NewClient(t)
+		mockLatestAgentVersion(mockClient, latestVersion)
+
+		timeProvider := timeprovider.New().Freeze()
+		timeProvider.Set(now)
+
+		return &reconciler{
+			apiReader:    fake.NewClient(),
+			timeProvider: timeProvider,
+			dtClient:     mockClient,
+		}
+	}
+
+	t.Run("update outside of maintenance window => pending", func(t *testing.T) {
+		dk := newDynaKube(outsideWindow)
+
+		err := newReconciler(t, outsideWindow).ReconcileCodeModules(ctx, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, previousVersion, dk.Status.CodeModules.Version)
+		assert.Equal(t, latestVersion, dk.Status.CodeModules.PendingVersion)
+	})
+	t.Run("update during maintenance window => rolled out", func(t *testing.T) {
+		dk := newDynaKube(duringWindow)
+		dk.Status.CodeModules.PendingVersion = latestVersion
+
+		err := newReconciler(t, duringWindow).ReconcileCodeModules(ctx, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, latestVersion, dk.Status.CodeModules.Version)
+		assert.Empty(t, dk.Status.CodeModules.PendingVersion)
+	})
+	t.Run("rollout-now annotation => rolled out outside of maintenance window", func(t *testing.T) {
+		dk := newDynaKube(outsideWindow)
+		dk.Annotations = map[string]string{api.AnnotationRolloutNow: "true"}
+
+		err := newReconciler(t, outsideWindow).ReconcileCodeModules(ctx, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, latestVersion, dk.Status.CodeModules.Version)
+		assert.Empty(t, dk.Status.CodeModules.PendingVersion)
+	})
+	t.Run("initial version is never held back", func(t *testing.T) {
+		dk := newDynaKube(outsideWindow)
+		dk.Status.CodeModules.VersionStatus = status.VersionStatus{}
+
+		err := newReconciler(t, outsideWindow).ReconcileCodeModules(ctx, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, latestVersion, dk.Status.CodeModules.Version)
+		assert.Empty(t, dk.Status.CodeModules.PendingVersion)
+	})
+	t.Run("pending version and open window => update needed, even if probe is recent", func(t *testing.T) {
+		dk := newDynaKube(duringWindow)
+		dk.Status.CodeModules.LastProbeTimestamp = &metav1.Time{Time: duringWindow}
+		dk.Status.CodeModules.PendingVersion = latestVersion
+
+		timeProvider := timeprovider.New().Freeze()
+		timeProvider.Set(duringWindow)
+		versionReconciler := reconciler{timeProvider: timeProvider}
+
+		assert.True(t, versionReconciler.needsUpdate(newCodeModulesUpdater(dk, nil), dk))
+
+		timeProvider.Set(outsideWindow)
+		dk.Status.CodeModules.LastProbeTimestamp = &metav1.Time{Time: outsideWindow}
+		assert.False(t, versionReconciler.needsUpdate(newCodeModulesUpdater(dk, nil), dk))
+	})
+}
+
+func TestHoldBackAbortedCanary(t *testing.T) {
+	previous := status.VersionStatus{Version: "1.1.0.0-0", ImageID: "registry/oneagent:1.1.0.0-0"}
+	aborted := status.VersionStatus{Version: "1.2.0.0-0", ImageID: "registry/oneagent:1.2.0.0-0"}
+
+	newDynaKube := func(target status.VersionStatus) *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				OneAgent: oneagent.Spec{
+					HostMonitoring: &oneagent.HostInjectSpec{Canary: &oneagent.CanarySpec{}},
+				},
+			},
+		}
+		dk.Status.OneAgent.VersionStatus = target
+		dk.Status.OneAgent.Canary = &oneagent.CanaryStatus{
+			Phase:           oneagent.CanaryPhaseAborted,
+			Version:         aborted.Version,
+			ImageID:         aborted.ImageID,
+			PreviousVersion: previous.Version,
+			PreviousImageID: previous.ImageID,
+		}
+
+		return dk
+	}
+
+	t.Run("aborted version => previous version kept", func(t *testing.T) {
+		dk := newDynaKube(aborted)
+
+		holdBackAbortedCanary(newOneAgentUpdater(dk, nil, nil), dk, previous)
+
+		assert.Equal(t, previous.ImageID, dk.Status.OneAgent.ImageID)
+		assert.Equal(t, previous.Version, dk.Status.OneAgent.Version)
+	})
+	t.Run("different version => rolled out", func(t *testing.T) {
+		newer := status.VersionStatus{Version: "1.3.0.0-0", ImageID: "registry/oneagent:1.3.0.0-0"}
+		dk := newDynaKube(newer)
+
+		holdBackAbortedCanary(newOneAgentUpdater(dk, nil, nil), dk, previous)
+
+		assert.Equal(t, newer.ImageID, dk.Status.OneAgent.ImageID)
+	})
+	t.Run("canary disabled => rolled out", func(t *testing.T) {
+		dk := newDynaKube(aborted)
+		dk.Spec.OneAgent.HostMonitoring.Canary = nil
+
+		holdBackAbortedCanary(newOneAgentUpdater(dk, nil, nil), dk, previous)
+
+		assert.Equal(t, aborted.ImageID, dk.Status.OneAgent.ImageID)
+	})
+}
+
 func TestUpdateVersionStatuses(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,182 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	k8spod "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkRollout compares the pods of the component with its version status.
+// Once all pods run the current version and are ready, the version is remembered as last known good.
+// If a pod of the current version is unhealthy or doesn't become ready in time, the last known good version is restored.
+func (r *reconciler) checkRollout(ctx context.Context, updater StatusUpdater, dk *dynakube.DynaKube) error {
+	if !dk.FF().IsAutomaticRollback() {
+		return nil
+	}
+
+	component, conditionType := rolloutComponent(updater)
+	if component == "" {
+		return nil
+	}
+
+	if _, ok := updater.(*oneAgentUpdater); ok && dk.OneAgent().IsCanaryRolloutInProgress() {
+		// the canary rollout checks the health of the new version itself
+		return nil
+	}
+
+	target := updater.Target()
+	if target.ImageID == "" {
+		return nil
+	}
+
+	rollout := updater.Rollout()
+
+	pods, err := r.listComponentPods(ctx, dk, component)
+	if err != nil {
+		return err
+	}
+
+	failure, isRolledOut := r.evaluateRollout(dk, target.ImageID, pods)
+
+	switch {
+	case failure != "":
+		r.rollback(updater, dk, conditionType, failure)
+	case isRolledOut && (rollout.LastKnownGood == nil || rollout.LastKnownGood.ImageID != target.ImageID):
+		log.Info("all pods are ready, remembering version as last known good", "updater", updater.Name(), "version", target.Version)
+
+		rollout.LastKnownGood = &update.KnownVersion{
+			ImageID: target.ImageID,
+			Version: target.Version,
+		}
+	}
+
+	return nil
+}
+
+// evaluateRollout returns the reason why the rollout of the image failed, or if all pods run the image and are ready.
+func (r *reconciler) evaluateRollout(dk *dynakube.DynaKube, imageID string, pods []corev1.Pod) (string, bool) {
+	readinessTimeout := dk.FF().GetRollbackReadinessTimeout()
+	restartThreshold := dk.FF().GetRollbackRestartThreshold()
+	now := r.timeProvider.Now()
+
+	isRolledOut := len(pods) > 0
+
+	for _, pod := range pods {
+		if !runsImage(pod, imageID) {
+			isRolledOut = false
+
+			continue
+		}
+
+		if reason := k8spod.GetUnhealthyReason(pod, restartThreshold); reason != "" {
+			return fmt.Sprintf("pod %s is unhealthy: %s", pod.Name, reason), false
+		}
+
+		if k8spod.IsReady(pod) {
+			continue
+		}
+
+		isRolledOut = false
+
+		if now.Sub(pod.CreationTimestamp.Time) > readinessTimeout {
+			return fmt.Sprintf("pod %s didn't become ready within %s", pod.Name, readinessTimeout), false
+		}
+	}
+
+	return "", isRolledOut
+}
+
+// rollback restores the last known good version and remembers the failed one, so it isn't rolled out again.
+func (r *reconciler) rollback(updater StatusUpdater, dk *dynakube.DynaKube, conditionType, reason string) {
+	target := updater.Target()
+	rollout := updater.Rollout()
+
+	if rollout.LastKnownGood == nil || rollout.LastKnownGood.ImageID == target.ImageID {
+		log.Info("rollout of version failed, but there is no other known good version to roll back to", "updater", updater.Name(), "version", target.Version, "reason", reason)
+
+		return
+	}
+
+	log.Info("rollout of version failed, rolling back", "updater", updater.Name(), "version", target.Version, "lastKnownGoodVersion", rollout.LastKnownGood.Version, "reason", reason)
+
+	rollout.RolledBack = &update.RolledBackVersion{
+		Timestamp: r.timeProvider.Now(),
+		ImageID:   target.ImageID,
+		Version:   target.Version,
+		Reason:    reason,
+	}
+	target.ImageID = rollout.LastKnownGood.ImageID
+	target.Version = rollout.LastKnownGood.Version
+
+	setRolledBackCondition(dk.Conditions(), conditionType, rollout.RolledBack, target.Version)
+	setOneAgentHealthcheck(updater, dk)
+}
+
+// holdBackRolledBack keeps the component on its current version, if a version that was rolled back before is selected again.
+// The rolled back version is only rolled out again once the rollout is forced.
+func holdBackRolledBack(updater StatusUpdater, dk *dynakube.DynaKube, previous status.VersionStatus) {
+	target := updater.Target()
+	rollout := updater.Rollout()
+
+	if rollout.RolledBack == nil || target.ImageID != rollout.RolledBack.ImageID || previous.ImageID == target.ImageID {
+		return
+	}
+
+	if dk.IsRolloutForced() {
+		log.Info("rollout is forced, rolling out version that was rolled back before", "updater", updater.Name(), "version", target.Version)
+
+		rollout.RolledBack = nil
+
+		return
+	}
+
+	log.Info("version was rolled back before, keeping the current one", "updater", updater.Name(), "version", previous.Version, "rolledBackVersion", rollout.RolledBack.Version)
+
+	target.ImageID = previous.ImageID
+	target.Version = previous.Version
+
+	_, conditionType := rolloutComponent(updater)
+	setRolledBackCondition(dk.Conditions(), conditionType, rollout.RolledBack, target.Version)
+}
+
+func (r *reconciler) listComponentPods(ctx context.Context, dk *dynakube.DynaKube, component string) ([]corev1.Pod, error) {
+	appLabels := labels.NewAppLabels(component, dk.Name, "", "")
+
+	var podList corev1.PodList
+
+	err := r.apiReader.List(ctx, &podList,
+		client.InNamespace(dk.Namespace),
+		client.MatchingLabels(appLabels.BuildMatchLabels()),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return podList.Items, nil
+}
+
+func rolloutComponent(updater StatusUpdater) (string, string) {
+	switch updater.(type) {
+	case *oneAgentUpdater:
+		return labels.OneAgentComponentLabel, oaConditionType
+	case *activeGateUpdater:
+		return labels.ActiveGateComponentLabel, activeGateVersionConditionType
+	default:
+		return "", ""
+	}
+}
+
+func runsImage(pod corev1.Pod, imageID string) bool {
+	return slices.ContainsFunc(pod.Spec.Containers, func(container corev1.Container) bool {
+		return container.Image == imageID
+	})
+}
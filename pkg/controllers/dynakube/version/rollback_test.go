@@ -0,0 +1,222 @@
+package version
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testGoodVersion   = "1.1.0.0-0"
+	testGoodImage     = "registry/oneagent:1.1.0.0-0"
+	testFailedVersion = "1.2.0.0-0"
+	testFailedImage   = "registry/oneagent:1.2.0.0-0"
+)
+
+func TestCheckRollout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+
+	newDynaKube := func() *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: "dynakube", Namespace: testNamespace},
+			Spec: dynakube.DynaKubeSpec{
+				OneAgent: oneagent.Spec{HostMonitoring: &oneagent.HostInjectSpec{}},
+			},
+		}
+		dk.Status.OneAgent.VersionStatus = status.VersionStatus{
+			ImageID: testFailedImage,
+			Version: testFailedVersion,
+		}
+		dk.Status.OneAgent.LastKnownGood = &update.KnownVersion{ImageID: testGoodImage, Version: testGoodVersion}
+
+		return dk
+	}
+
+	newReconciler := func(objects ...client.Object) *reconciler {
+		timeProvider := timeprovider.New().Freeze()
+		timeProvider.Set(now)
+
+		return &reconciler{
+			apiReader:    fake.NewClient(objects...),
+			timeProvider: timeProvider,
+		}
+	}
+
+	t.Run("all pods ready => remembered as last known good", func(t *testing.T) {
+		dk := newDynaKube()
+		versionReconciler := newReconciler(
+			newComponentPod(dk, labels.OneAgentComponentLabel, "a", testFailedImage, now),
+			newComponentPod(dk, labels.OneAgentComponentLabel, "b", testFailedImage, now),
+		)
+
+		require.NoError(t, versionReconciler.checkRollout(ctx, newOneAgentUpdater(dk, nil, nil), dk))
+
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.LastKnownGood.ImageID)
+		assert.Equal(t, testFailedVersion, dk.Status.OneAgent.LastKnownGood.Version)
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.ImageID)
+	})
+	t.Run("rollout in progress => nothing changes", func(t *testing.T) {
+		dk := newDynaKube()
+		notReady := newComponentPod(dk, labels.OneAgentComponentLabel, "b", testFailedImage, now.Add(-time.Minute))
+		notReady.Status.Conditions = nil
+		versionReconciler := newReconciler(
+			newComponentPod(dk, labels.OneAgentComponentLabel, "a", testGoodImage, now),
+			notReady,
+		)
+
+		require.NoError(t, versionReconciler.checkRollout(ctx, newOneAgentUpdater(dk, nil, nil), dk))
+
+		assert.Equal(t, testGoodImage, dk.Status.OneAgent.LastKnownGood.ImageID)
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.ImageID)
+		assert.Nil(t, dk.Status.OneAgent.RolledBack)
+	})
+	t.Run("crash looping pod => rolled back to last known good", func(t *testing.T) {
+		dk := newDynaKube()
+		crashing := newComponentPod(dk, labels.OneAgentComponentLabel, "a", testFailedImage, now)
+		crashing.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}
+		versionReconciler := newReconciler(crashing)
+
+		require.NoError(t, versionReconciler.checkRollout(ctx, newOneAgentUpdater(dk, nil, nil), dk))
+
+		assert.Equal(t, testGoodImage, dk.Status.OneAgent.ImageID)
+		assert.Equal(t, testGoodVersion, dk.Status.OneAgent.Version)
+		require.NotNil(t, dk.Status.OneAgent.RolledBack)
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.RolledBack.ImageID)
+		assert.Contains(t, dk.Status.OneAgent.RolledBack.Reason, "CrashLoopBackOff")
+		assert.NotNil(t, dk.Status.OneAgent.Healthcheck)
+
+		condition := meta.FindStatusCondition(dk.Status.Conditions, oaConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, rolledBackReason, condition.Reason)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+	t.Run("pod not ready after the readiness timeout => rolled back", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Annotations = map[string]string{exp.RollbackReadinessTimeoutKey: "5"}
+		notReady := newComponentPod(dk, labels.OneAgentComponentLabel, "a", testFailedImage, now.Add(-6*time.Minute))
+		notReady.Status.Conditions = nil
+		versionReconciler := newReconciler(notReady)
+
+		require.NoError(t, versionReconciler.checkRollout(ctx, newOneAgentUpdater(dk, nil, nil), dk))
+
+		assert.Equal(t, testGoodImage, dk.Status.OneAgent.ImageID)
+		assert.Contains(t, dk.Status.OneAgent.RolledBack.Reason, "didn't become ready within 5m0s")
+	})
+	t.Run("no last known good version => nothing to roll back to", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Status.OneAgent.LastKnownGood = nil
+		crashing := newComponentPod(dk, labels.OneAgentComponentLabel, "a", testFailedImage, now)
+		crashing.Status.ContainerStatuses = []corev1.ContainerStatus{{RestartCount: 10}}
+		versionReconciler := newReconciler(crashing)
+
+		require.NoError(t, versionReconciler.checkRollout(ctx, newOneAgentUpdater(dk, nil, nil), dk))
+
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.ImageID)
+		assert.Nil(t, dk.Status.OneAgent.RolledBack)
+	})
+	t.Run("automatic rollback disabled => nothing changes", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Annotations = map[string]string{exp.AutomaticRollbackKey: "false"}
+		crashing := newComponentPod(dk, labels.OneAgentComponentLabel, "a", testFailedImage, now)
+		crashing.Status.ContainerStatuses = []corev1.ContainerStatus{{RestartCount: 10}}
+		versionReconciler := newReconciler(crashing)
+
+		require.NoError(t, versionReconciler.checkRollout(ctx, newOneAgentUpdater(dk, nil, nil), dk))
+
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.ImageID)
+	})
+	t.Run("ActiveGate pods are checked as well", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate = activegate.Spec{Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName}}
+		dk.Status.ActiveGate.VersionStatus = dk.Status.OneAgent.VersionStatus
+		dk.Status.ActiveGate.RolloutStatus = dk.Status.OneAgent.RolloutStatus
+		crashing := newComponentPod(dk, labels.ActiveGateComponentLabel, "0", testFailedImage, now)
+		crashing.Status.ContainerStatuses = []corev1.ContainerStatus{{RestartCount: 3}}
+		versionReconciler := newReconciler(crashing)
+
+		require.NoError(t, versionReconciler.checkRollout(ctx, newActiveGateUpdater(dk, nil, nil), dk))
+
+		assert.Equal(t, testGoodImage, dk.Status.ActiveGate.ImageID)
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.ImageID)
+	})
+}
+
+func TestHoldBackRolledBack(t *testing.T) {
+	previous := status.VersionStatus{ImageID: testGoodImage, Version: testGoodVersion}
+
+	newDynaKube := func(target status.VersionStatus) *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				OneAgent: oneagent.Spec{HostMonitoring: &oneagent.HostInjectSpec{}},
+			},
+		}
+		dk.Status.OneAgent.VersionStatus = target
+		dk.Status.OneAgent.RolledBack = &update.RolledBackVersion{ImageID: testFailedImage, Version: testFailedVersion}
+
+		return dk
+	}
+
+	t.Run("rolled back version => current version kept", func(t *testing.T) {
+		dk := newDynaKube(status.VersionStatus{ImageID: testFailedImage, Version: testFailedVersion})
+
+		holdBackRolledBack(newOneAgentUpdater(dk, nil, nil), dk, previous)
+
+		assert.Equal(t, testGoodImage, dk.Status.OneAgent.ImageID)
+		assert.Equal(t, testGoodVersion, dk.Status.OneAgent.Version)
+		assert.NotNil(t, dk.Status.OneAgent.RolledBack)
+		assert.Equal(t, rolledBackReason, meta.FindStatusCondition(dk.Status.Conditions, oaConditionType).Reason)
+	})
+	t.Run("rollout forced => rolled back version rolled out again", func(t *testing.T) {
+		dk := newDynaKube(status.VersionStatus{ImageID: testFailedImage, Version: testFailedVersion})
+		dk.Annotations = map[string]string{api.AnnotationRolloutNow: "true"}
+
+		holdBackRolledBack(newOneAgentUpdater(dk, nil, nil), dk, previous)
+
+		assert.Equal(t, testFailedImage, dk.Status.OneAgent.ImageID)
+		assert.Nil(t, dk.Status.OneAgent.RolledBack)
+	})
+	t.Run("different version => rolled out", func(t *testing.T) {
+		dk := newDynaKube(status.VersionStatus{ImageID: "registry/oneagent:1.3.0.0-0", Version: "1.3.0.0-0"})
+
+		holdBackRolledBack(newOneAgentUpdater(dk, nil, nil), dk, previous)
+
+		assert.Equal(t, "registry/oneagent:1.3.0.0-0", dk.Status.OneAgent.ImageID)
+	})
+}
+
+func newComponentPod(dk *dynakube.DynaKube, component, suffix, image string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              component + "-" + suffix,
+			Namespace:         dk.Namespace,
+			Labels:            labels.NewAppLabels(component, dk.Name, "", "").BuildMatchLabels(),
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Image: image}},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
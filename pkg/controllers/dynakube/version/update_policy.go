@@ -0,0 +1,156 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	"github.com/pkg/errors"
+)
+
+// policyUpdater is implemented by the updaters of components whose automatic updates can be restricted by an update policy.
+type policyUpdater interface {
+	UpdatePolicy() *update.Policy
+	AvailableVersions(ctx context.Context) ([]string, error)
+	UseTenantRegistryVersion(ctx context.Context, version string) error
+}
+
+func getUpdatePolicy(updater StatusUpdater) *update.Policy {
+	if policyUpdater, ok := updater.(policyUpdater); ok {
+		return policyUpdater.UpdatePolicy()
+	}
+
+	return nil
+}
+
+// useTenantRegistryWithPolicy rolls out the newest version available on the tenant that the update policy allows.
+func (r *reconciler) useTenantRegistryWithPolicy(ctx context.Context, updater StatusUpdater, policyUpdater policyUpdater) error {
+	availableVersions, err := policyUpdater.AvailableVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	selectedVersion, err := r.selectVersion(updater, policyUpdater.UpdatePolicy(), availableVersions)
+	if err != nil || selectedVersion == "" {
+		return err
+	}
+
+	return policyUpdater.UseTenantRegistryVersion(ctx, selectedVersion)
+}
+
+// selectVersion returns the newest of the available versions that the update policy allows, relative to the current version.
+// Why the version was selected is recorded in the version status. If no version is allowed, an empty version is returned.
+func (r *reconciler) selectVersion(updater StatusUpdater, policy *update.Policy, availableVersions []string) (string, error) {
+	target := updater.Target()
+	rollout := updater.Rollout()
+
+	var constraint *version.Constraint
+
+	if policy.Constraint != "" {
+		var err error
+
+		constraint, err = version.ParseConstraint(policy.Constraint)
+		if err != nil {
+			return "", errors.WithMessage(err, "invalid constraint in update policy")
+		}
+	}
+
+	candidates := parseCandidates(availableVersions)
+	if len(candidates) == 0 {
+		rollout.UpdatePolicyReason = "No valid versions are available."
+
+		return "", nil
+	}
+
+	current, currentErr := version.ExtractPartialSemanticVersion(target.Version)
+	hasCurrent := currentErr == nil
+	now := r.timeProvider.Now().Time
+
+	var newestExclusion string
+
+	for _, candidate := range candidates {
+		exclusion := policyExclusion(policy, constraint, candidate.semantic, current, hasCurrent, now)
+		if exclusion == "" {
+			rollout.UpdatePolicyReason = fmt.Sprintf("Version %s is the newest version allowed by the update policy.", candidate.raw)
+			if newestExclusion != "" {
+				rollout.UpdatePolicyReason += " " + newestExclusion
+			}
+
+			log.Info("version selected by update policy", "updater", updater.Name(), "version", candidate.raw, "reason", rollout.UpdatePolicyReason)
+
+			return candidate.raw, nil
+		}
+
+		if newestExclusion == "" {
+			newestExclusion = fmt.Sprintf("Newer version %s is excluded, as it %s.", candidate.raw, exclusion)
+		}
+	}
+
+	rollout.UpdatePolicyReason = "No available version is allowed by the update policy. " + newestExclusion
+	log.Info("no version allowed by update policy, keeping the current one", "updater", updater.Name(), "version", target.Version, "reason", rollout.UpdatePolicyReason)
+
+	return "", nil
+}
+
+type candidateVersion struct {
+	raw      string
+	semantic version.SemanticVersion
+}
+
+// parseCandidates skips versions that can't be parsed and sorts the rest, newest first.
+func parseCandidates(availableVersions []string) []candidateVersion {
+	candidates := make([]candidateVersion, 0, len(availableVersions))
+
+	for _, raw := range availableVersions {
+		semantic, err := version.ExtractPartialSemanticVersion(raw)
+		if err != nil {
+			log.Info("skipping version that can't be parsed", "version", raw)
+
+			continue
+		}
+
+		candidates = append(candidates, candidateVersion{raw: raw, semantic: semantic})
+	}
+
+	slices.SortFunc(candidates, func(a, b candidateVersion) int {
+		return version.CompareSemanticVersions(b.semantic, a.semantic)
+	})
+
+	return candidates
+}
+
+// policyExclusion returns why the update policy doesn't allow the candidate, or an empty string if it's allowed.
+func policyExclusion(policy *update.Policy, constraint *version.Constraint, candidate, current version.SemanticVersion, hasCurrent bool, now time.Time) string {
+	if constraint != nil && !constraint.Matches(candidate) {
+		return fmt.Sprintf("doesn't satisfy the constraint '%s'", policy.Constraint)
+	}
+
+	if hasCurrent {
+		switch policy.Mode {
+		case update.PatchOnlyMode:
+			if candidate.Major() != current.Major() || candidate.Minor() != current.Minor() {
+				return fmt.Sprintf("isn't a patch of the current version %d.%d", current.Major(), current.Minor())
+			}
+		case update.MinorOnlyMode:
+			if candidate.Major() != current.Major() {
+				return fmt.Sprintf("isn't a minor update of the current version %d", current.Major())
+			}
+		}
+	}
+
+	if policy.MinReleaseAgeDays > 0 {
+		buildDate, err := candidate.BuildDate()
+		if err != nil {
+			return "has no build date to check the minimum release age"
+		}
+
+		if now.Sub(buildDate) < time.Duration(policy.MinReleaseAgeDays)*24*time.Hour {
+			return fmt.Sprintf("was released less than %d days ago", policy.MinReleaseAgeDays)
+		}
+	}
+
+	return ""
+}
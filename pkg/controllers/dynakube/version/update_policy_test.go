@@ -0,0 +1,186 @@
+package version
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectVersion(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	availableVersions := []string{
+		"1.311.70.20250301-100000",
+		"1.320.10.20250425-100000",
+		"1.312.5.20250320-100000",
+		"1.311.80.20250410-100000",
+		"2.1.0.20250428-100000",
+		"not-a-version",
+	}
+
+	newReconciler := func() *reconciler {
+		timeProvider := timeprovider.New().Freeze()
+		timeProvider.Set(now)
+
+		return &reconciler{timeProvider: timeProvider}
+	}
+
+	newUpdater := func(currentVersion string) *oneAgentUpdater {
+		dk := &dynakube.DynaKube{}
+		dk.Status.OneAgent.Version = currentVersion
+
+		return newOneAgentUpdater(dk, nil, nil)
+	}
+
+	testCases := []struct {
+		title    string
+		policy   update.Policy
+		current  string
+		expected string
+	}{
+		{"no restrictions => newest version", update.Policy{}, "1.311.70.20250301-100000", "2.1.0.20250428-100000"},
+		{"constraint => newest matching version", update.Policy{Constraint: "<1.320"}, "1.311.70.20250301-100000", "1.312.5.20250320-100000"},
+		{"tilde constraint => same minor version", update.Policy{Constraint: "~1.311"}, "", "1.311.80.20250410-100000"},
+		{"patch-only => same minor version", update.Policy{Mode: update.PatchOnlyMode}, "1.311.70.20250301-100000", "1.311.80.20250410-100000"},
+		{"minor-only => same major version", update.Policy{Mode: update.MinorOnlyMode}, "1.311.70.20250301-100000", "1.320.10.20250425-100000"},
+		{"mode without current version => not restricted", update.Policy{Mode: update.PatchOnlyMode}, "", "2.1.0.20250428-100000"},
+		{"minimum release age => old enough version", update.Policy{MinReleaseAgeDays: 14}, "1.311.70.20250301-100000", "1.312.5.20250320-100000"},
+		{"combined => all restrictions apply", update.Policy{Constraint: ">=1.312", Mode: update.MinorOnlyMode, MinReleaseAgeDays: 7}, "1.311.70.20250301-100000", "1.312.5.20250320-100000"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			updater := newUpdater(testCase.current)
+
+			selected, err := newReconciler().selectVersion(updater, &testCase.policy, availableVersions)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, selected)
+			assert.Contains(t, updater.Rollout().UpdatePolicyReason, testCase.expected)
+		})
+	}
+
+	t.Run("newer version excluded => recorded in reason", func(t *testing.T) {
+		updater := newUpdater("1.311.70.20250301-100000")
+
+		_, err := newReconciler().selectVersion(updater, &update.Policy{Mode: update.MinorOnlyMode}, availableVersions)
+		require.NoError(t, err)
+
+		assert.Contains(t, updater.Rollout().UpdatePolicyReason, "Newer version 2.1.0.20250428-100000 is excluded, as it isn't a minor update of the current version 1")
+	})
+	t.Run("no version allowed => nothing selected", func(t *testing.T) {
+		updater := newUpdater("1.311.70.20250301-100000")
+
+		selected, err := newReconciler().selectVersion(updater, &update.Policy{Constraint: "<1.300"}, availableVersions)
+		require.NoError(t, err)
+
+		assert.Empty(t, selected)
+		assert.Contains(t, updater.Rollout().UpdatePolicyReason, "No available version is allowed by the update policy")
+		assert.Equal(t, "1.311.70.20250301-100000", updater.Target().Version)
+	})
+	t.Run("version without build date and minimum release age => excluded", func(t *testing.T) {
+		updater := newUpdater("")
+
+		selected, err := newReconciler().selectVersion(updater, &update.Policy{MinReleaseAgeDays: 1}, []string{"1.311.70"})
+		require.NoError(t, err)
+
+		assert.Empty(t, selected)
+		assert.Contains(t, updater.Rollout().UpdatePolicyReason, "has no build date")
+	})
+	t.Run("invalid constraint => error", func(t *testing.T) {
+		_, err := newReconciler().selectVersion(newUpdater(""), &update.Policy{Constraint: "latest"}, availableVersions)
+		require.Error(t, err)
+	})
+}
+
+func TestReconcileWithUpdatePolicy(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	newDynaKube := func(policy *update.Policy) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				OneAgent: oneagent.Spec{
+					ClassicFullStack: &oneagent.HostInjectSpec{UpdatePolicy: policy},
+				},
+			},
+		}
+	}
+
+	newReconciler := func(mockClient dtclient.Client) *reconciler {
+		timeProvider := timeprovider.New().Freeze()
+		timeProvider.Set(now)
+
+		return &reconciler{
+			apiReader:    fake.NewClient(),
+			timeProvider: timeProvider,
+			dtClient:     mockClient,
+		}
+	}
+
+	t.Run("update policy => version selected from available versions", func(t *testing.T) {
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("GetAgentVersions", mock.AnythingOfType("context.backgroundCtx"), dtclient.OsUnix, dtclient.InstallerTypeDefault, arch.FlavorDefault).
+			Return([]string{"1.319.10.20250301-100000", "1.320.0.20250420-100000"}, nil)
+
+		dk := newDynaKube(&update.Policy{Constraint: "<1.320"})
+
+		err := newReconciler(mockClient).ReconcileOneAgent(ctx, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1.319.10.20250301-100000", dk.Status.OneAgent.Version)
+		assert.Equal(t, status.TenantRegistryVersionSource, dk.Status.OneAgent.Source)
+		assert.Contains(t, dk.Status.OneAgent.ImageID, ":1.319.10")
+		assert.NotEmpty(t, dk.Status.OneAgent.UpdatePolicyReason)
+	})
+	t.Run("no update policy => latest version", func(t *testing.T) {
+		mockClient := dtclientmock.NewClient(t)
+		mockLatestAgentVersion(mockClient, "1.320.0.20250420-100000")
+
+		dk := newDynaKube(nil)
+		dk.Status.OneAgent.UpdatePolicyReason = "outdated"
+
+		err := newReconciler(mockClient).ReconcileOneAgent(ctx, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1.320.0.20250420-100000", dk.Status.OneAgent.Version)
+		assert.Empty(t, dk.Status.OneAgent.UpdatePolicyReason)
+	})
+	t.Run("public registry => latest image checked against update policy", func(t *testing.T) {
+		mockClient := dtclientmock.NewClient(t)
+		mockOneAgentImageInfo(mockClient, dtclient.LatestImageInfo{Source: "some.registry.com/oneagent", Tag: "1.320.0.20250420-100000"})
+
+		// public registry isn't used for classicFullStack
+		dk := newDynaKube(nil)
+		dk.Spec.OneAgent.ClassicFullStack = nil
+		dk.Spec.OneAgent.CloudNativeFullStack = &oneagent.CloudNativeFullStackSpec{HostInjectSpec: oneagent.HostInjectSpec{UpdatePolicy: &update.Policy{MinReleaseAgeDays: 30}}}
+		dk.Annotations = map[string]string{exp.PublicRegistryKey: "true"}
+		dk.Status.OneAgent.VersionStatus = status.VersionStatus{
+			ImageID: "some.registry.com/oneagent:1.319.10.20250301-100000",
+			Version: "1.319.10.20250301-100000",
+			Source:  status.PublicRegistryVersionSource,
+		}
+
+		err := newReconciler(mockClient).ReconcileOneAgent(ctx, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1.319.10.20250301-100000", dk.Status.OneAgent.Version)
+		assert.Contains(t, dk.Status.OneAgent.UpdatePolicyReason, "was released less than 30 days ago")
+	})
+}
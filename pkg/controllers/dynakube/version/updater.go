@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
@@ -16,6 +17,7 @@ type StatusUpdater interface {
 	Name() string
 	IsEnabled() bool
 	Target() *status.VersionStatus
+	Rollout() *update.RolloutStatus
 
 	CustomImage() string
 	CustomVersion() string
@@ -33,6 +35,11 @@ func (r *reconciler) run(ctx context.Context, updater StatusUpdater) error {
 
 	var err error
 
+	policy := getUpdatePolicy(updater)
+	if policy == nil {
+		updater.Rollout().UpdatePolicyReason = ""
+	}
+
 	defer func() {
 		if err == nil {
 			updater.Target().LastProbeTimestamp = r.timeProvider.Now()
@@ -71,7 +78,12 @@ func (r *reconciler) run(ctx context.Context, updater StatusUpdater) error {
 
 	log.Info("updating version status according to the tenant registry", "updater", updater.Name())
 
-	err = updater.UseTenantRegistry(ctx)
+	if policyUpdater, ok := updater.(policyUpdater); ok && policy != nil && updater.CustomVersion() == "" {
+		err = r.useTenantRegistryWithPolicy(ctx, updater, policyUpdater)
+	} else {
+		err = updater.UseTenantRegistry(ctx)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -91,6 +103,13 @@ func (r *reconciler) processPublicRegistry(ctx context.Context, updater StatusUp
 		return err
 	}
 
+	if policy := getUpdatePolicy(updater); policy != nil {
+		selectedVersion, err := r.selectVersion(updater, policy, []string{publicImage.Tag})
+		if err != nil || selectedVersion == "" {
+			return err
+		}
+	}
+
 	isDowngrade, err := updater.CheckForDowngrade(publicImage.Tag)
 	if err != nil || isDowngrade {
 		return err
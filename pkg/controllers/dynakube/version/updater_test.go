@@ -6,6 +6,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
@@ -341,6 +342,7 @@ func newBaseUpdater(t *testing.T, target *status.VersionStatus, autoUpdate bool)
 	updater := versionmock.NewStatusUpdater(t)
 	updater.On("Name").Maybe().Return("mock")
 	updater.On("Target").Maybe().Return(target)
+	updater.On("Rollout").Maybe().Return(&update.RolloutStatus{})
 	updater.On("IsEnabled").Maybe().Return(true)
 	updater.On("IsAutoUpdateEnabled").Maybe().Return(autoUpdate)
 	updater.On("ValidateStatus").Maybe().Return(nil)
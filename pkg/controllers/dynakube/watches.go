@@ -0,0 +1,92 @@
+package dynakube
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Field indexes of the DynaKubes, holding the names of the user-provided secrets and configmaps a DynaKube refers to.
+const (
+	referencedSecretsIndex    = "spec.referencedSecrets"
+	referencedConfigMapsIndex = "spec.referencedConfigMaps"
+)
+
+// setupIndexes registers the field indexes used to find the DynaKubes referring to a secret or configmap.
+func setupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &dynakube.DynaKube{}, referencedSecretsIndex, referencedSecrets); err != nil {
+		return errors.WithMessage(err, "failed to index referenced secrets of dynakubes")
+	}
+
+	if err := indexer.IndexField(ctx, &dynakube.DynaKube{}, referencedConfigMapsIndex, referencedConfigMaps); err != nil {
+		return errors.WithMessage(err, "failed to index referenced configmaps of dynakubes")
+	}
+
+	return nil
+}
+
+// referencedSecrets returns the names of the secrets provided by the user for the DynaKube.
+// Secrets created by the operator are owned by the DynaKube and therefore not part of the index.
+func referencedSecrets(obj client.Object) []string {
+	dk, ok := obj.(*dynakube.DynaKube)
+	if !ok {
+		return nil
+	}
+
+	names := []string{dk.Tokens()}
+
+	if dk.Spec.Proxy != nil && dk.Spec.Proxy.ValueFrom != "" {
+		names = append(names, dk.Spec.Proxy.ValueFrom)
+	}
+
+	if dk.Spec.CustomPullSecret != "" {
+		names = append(names, dk.Spec.CustomPullSecret)
+	}
+
+	if dk.Spec.ActiveGate.CustomProperties != nil && dk.Spec.ActiveGate.CustomProperties.ValueFrom != "" {
+		names = append(names, dk.Spec.ActiveGate.CustomProperties.ValueFrom)
+	}
+
+	if dk.Spec.ActiveGate.TLSSecretName != "" {
+		names = append(names, dk.Spec.ActiveGate.TLSSecretName)
+	}
+
+	return names
+}
+
+// referencedConfigMaps returns the names of the configmaps provided by the user for the DynaKube.
+func referencedConfigMaps(obj client.Object) []string {
+	dk, ok := obj.(*dynakube.DynaKube)
+	if !ok || dk.Spec.TrustedCAs == "" {
+		return nil
+	}
+
+	return []string{dk.Spec.TrustedCAs}
+}
+
+// requestsForReferencingDynaKubes maps a secret or configmap to the DynaKubes referring to it via the given index.
+func (controller *Controller) requestsForReferencingDynaKubes(index string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var dkList dynakube.DynaKubeList
+
+		err := controller.client.List(ctx, &dkList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{index: obj.GetName()})
+		if err != nil {
+			log.Error(err, "failed to list dynakubes referring to changed object", "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(dkList.Items))
+		for _, dk := range dkList.Items {
+			log.Info("referenced object changed, reconciling DynaKube", "namespace", dk.Namespace, "name", dk.Name, "object", obj.GetName())
+
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&dk)})
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,78 @@
+package dynakube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReferencedObjects(t *testing.T) {
+	t.Run("defaults to tokens secret named like the dynakube", func(t *testing.T) {
+		dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dynakube"}}
+
+		assert.Equal(t, []string{"dynakube"}, referencedSecrets(dk))
+		assert.Empty(t, referencedConfigMaps(dk))
+	})
+	t.Run("all user-provided secrets and configmaps", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: "dynakube"},
+			Spec: dynakube.DynaKubeSpec{
+				Tokens:           "tokens",
+				Proxy:            &value.Source{ValueFrom: "proxy"},
+				CustomPullSecret: "pull-secret",
+				TrustedCAs:       "trusted-cas",
+				ActiveGate: activegate.Spec{
+					CapabilityProperties: activegate.CapabilityProperties{
+						CustomProperties: &value.Source{ValueFrom: "custom-properties"},
+					},
+					TLSSecretName: "tls",
+				},
+			},
+		}
+
+		assert.Equal(t, []string{"tokens", "proxy", "pull-secret", "custom-properties", "tls"}, referencedSecrets(dk))
+		assert.Equal(t, []string{"trusted-cas"}, referencedConfigMaps(dk))
+	})
+	t.Run("other objects are not indexed", func(t *testing.T) {
+		assert.Nil(t, referencedSecrets(&corev1.Secret{}))
+		assert.Nil(t, referencedConfigMaps(&corev1.Secret{}))
+	})
+}
+
+func TestRequestsForReferencingDynaKubes(t *testing.T) {
+	ctx := context.Background()
+
+	dkWithTokens := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: testNamespace}, Spec: dynakube.DynaKubeSpec{Tokens: "shared", TrustedCAs: "certs"}}
+	dkWithDefaultTokens := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: testNamespace}, Spec: dynakube.DynaKubeSpec{TrustedCAs: "certs"}}
+	dkInOtherNamespace := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "other"}, Spec: dynakube.DynaKubeSpec{Tokens: "shared"}}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dkWithTokens, dkWithDefaultTokens, dkInOtherNamespace).
+		WithIndex(&dynakube.DynaKube{}, referencedSecretsIndex, referencedSecrets).
+		WithIndex(&dynakube.DynaKube{}, referencedConfigMapsIndex, referencedConfigMaps).
+		Build()
+	controller := &Controller{client: fakeClient}
+
+	requestFor := func(dk *dynakube.DynaKube) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: dk.Name, Namespace: dk.Namespace}}
+	}
+
+	secretRequests := controller.requestsForReferencingDynaKubes(referencedSecretsIndex)
+	configMapRequests := controller.requestsForReferencingDynaKubes(referencedConfigMapsIndex)
+
+	assert.Equal(t, []reconcile.Request{requestFor(dkWithTokens)}, secretRequests(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: testNamespace}}))
+	assert.Equal(t, []reconcile.Request{requestFor(dkWithDefaultTokens)}, secretRequests(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: testNamespace}}))
+	assert.Empty(t, secretRequests(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: testNamespace}}))
+	assert.ElementsMatch(t, []reconcile.Request{requestFor(dkWithTokens), requestFor(dkWithDefaultTokens)}, configMapRequests(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "certs", Namespace: testNamespace}}))
+}
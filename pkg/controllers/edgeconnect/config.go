@@ -1,9 +1,42 @@
 package edgeconnect
 
 import (
+	"os"
+	"sync"
+	"time"
+
+	edgeconnectClient "github.com/Dynatrace/dynatrace-operator/pkg/clients/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 )
 
+const (
+	// APITimeoutEnv limits a single request to the EdgeConnect API (e.g. "45s"), retries get a timeout of their own.
+	APITimeoutEnv = "DT_EDGECONNECT_API_TIMEOUT"
+
+	// MaxConcurrentReconcilesEnv sets how many EdgeConnects are reconciled in parallel, defaults to 1.
+	MaxConcurrentReconcilesEnv = "DT_EDGECONNECT_MAX_CONCURRENT_RECONCILES"
+)
+
 var (
 	log = logd.Get().WithName("edgeconnect")
 )
+
+var apiTimeout = sync.OnceValue(func() time.Duration {
+	return getDurationFromEnvWithDefault(APITimeoutEnv, edgeconnectClient.DefaultTimeout)
+})
+
+func getDurationFromEnvWithDefault(envName string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(envName)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Info("invalid duration in env, using default", "env", envName, "value", value, "default", defaultValue.String())
+
+		return defaultValue
+	}
+
+	return duration
+}
@@ -25,4 +25,7 @@ const (
 
 	// SecretConfigConditionType identifies the secret config condition.
 	SecretConfigConditionType = "SecretConfigConditionType"
+
+	// PodDisruptionBudgetConditionType identifies the PodDisruptionBudget condition.
+	PodDisruptionBudgetConditionType = "PodDisruptionBudget"
 )
@@ -6,10 +6,13 @@ import (
 	"slices"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
 	edgeconnectClient "github.com/Dynatrace/dynatrace-operator/pkg/clients/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/config"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/deployment"
@@ -18,21 +21,27 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/dttoken"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/events"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	k8sdeployment "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/deployment"
+	k8spdb "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pdb"
 	k8ssecret "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -66,6 +75,7 @@ type Controller struct {
 	config                   *rest.Config
 	timeProvider             *timeprovider.Provider
 	edgeConnectClientBuilder edgeConnectClientBuilderType
+	statusRecorder           events.StatusRecorder
 }
 
 func Add(mgr manager.Manager, _ string) error {
@@ -80,22 +90,33 @@ func NewController(mgr manager.Manager) *Controller {
 		config:                   mgr.GetConfig(),
 		timeProvider:             timeprovider.New(),
 		edgeConnectClientBuilder: newEdgeConnectClient(),
+		statusRecorder:           events.NewStatusRecorder(mgr.GetEventRecorderFor("edgeconnect-controller")),
 	}
 }
 
 func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	if err := setupIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&edgeconnect.EdgeConnect{}).
 		Named("edgeconnect-controller").
+		WithOptions(k8scontroller.Options{MaxConcurrentReconciles: controllers.MaxConcurrentReconciles(MaxConcurrentReconcilesEnv)}).
 		Owns(&appsv1.Deployment{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(controller.requestsForReferencingEdgeConnects(referencedSecretsIndex))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(controller.requestsForReferencingEdgeConnects(referencedConfigMapsIndex))).
 		Complete(controller)
 }
 
-func (controller *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (controller *Controller) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
 	_log := log.WithValues("namespace", request.Namespace, "name", request.Name)
 
 	_log.Info("reconciling EdgeConnect")
 
+	ctx, span := tracing.Start(ctx, "edgeconnect.reconcile", tracing.NamespaceKey.String(request.Namespace), tracing.EdgeConnectKey.String(request.Name))
+	defer func() { tracing.End(span, err) }()
+
 	ec, err := controller.getEdgeConnect(ctx, request.Name, request.Namespace)
 	if err != nil {
 		_log.Debug("reconciliation of EdgeConnect failed")
@@ -143,7 +164,7 @@ func (controller *Controller) reconcileEdgeConnectDeletion(ctx context.Context,
 		return err
 	}
 
-	tenantEdgeConnect, err := getEdgeConnectByName(edgeConnectClient, ec.Name)
+	tenantEdgeConnect, err := getEdgeConnectByName(ctx, edgeConnectClient, ec.Name)
 	if err != nil {
 		_log.Debug("failed to get EdgeConnect by name")
 
@@ -168,7 +189,7 @@ func (controller *Controller) reconcileEdgeConnectDeletion(ctx context.Context,
 	}
 
 	if ec.IsK8SAutomationEnabled() && ec.IsProvisionerModeEnabled() {
-		err = controller.deleteConnectionSetting(edgeConnectClient, ec)
+		err = controller.deleteConnectionSetting(ctx, edgeConnectClient, ec)
 		if err != nil {
 			_log.Info("reconcile deletion: Deleting connection setting failed")
 
@@ -176,17 +197,17 @@ func (controller *Controller) reconcileEdgeConnectDeletion(ctx context.Context,
 		}
 	}
 
-	return edgeConnectClient.DeleteEdgeConnect(tenantEdgeConnect.ID)
+	return edgeConnectClient.DeleteEdgeConnect(ctx, tenantEdgeConnect.ID)
 }
 
-func (controller *Controller) deleteConnectionSetting(edgeConnectClient edgeconnectClient.Client, ec *edgeconnect.EdgeConnect) error {
-	envSetting, err := GetConnectionSetting(edgeConnectClient, ec.Name, ec.Namespace, ec.Status.KubeSystemUID)
+func (controller *Controller) deleteConnectionSetting(ctx context.Context, edgeConnectClient edgeconnectClient.Client, ec *edgeconnect.EdgeConnect) error {
+	envSetting, err := GetConnectionSetting(ctx, edgeConnectClient, ec.Name, ec.Namespace, ec.Status.KubeSystemUID)
 	if err != nil {
 		return err
 	}
 
 	if (envSetting != edgeconnectClient.EnvironmentSetting{}) {
-		err = edgeConnectClient.DeleteConnectionSetting(*envSetting.ObjectID)
+		err = edgeConnectClient.DeleteConnectionSetting(ctx, *envSetting.ObjectID)
 		if err != nil {
 			return err
 		}
@@ -195,12 +216,29 @@ func (controller *Controller) deleteConnectionSetting(edgeConnectClient edgeconn
 	return nil
 }
 
+// recordStatusEvents emits Events for the phase, condition and version transitions of the persisted status.
+func (controller *Controller) recordStatusEvents(ec *edgeconnect.EdgeConnect, oldStatus edgeconnect.EdgeConnectStatus) {
+	controller.statusRecorder.RecordPhase(ec, oldStatus.DeploymentPhase, ec.Status.DeploymentPhase)
+	controller.statusRecorder.RecordConditions(ec, oldStatus.Conditions, ec.Status.Conditions)
+	controller.statusRecorder.RecordVersion(ec, "EdgeConnect", oldStatus.Version, ec.Status.Version)
+}
+
 func (controller *Controller) reconcileEdgeConnect(ctx context.Context, ec *edgeconnect.EdgeConnect) (reconcile.Result, error) {
 	_log := log.WithValues("namespace", ec.Namespace, "name", ec.Name)
 
 	oldStatus := *ec.Status.DeepCopy()
 
-	err := controller.reconcileEdgeConnectCR(ctx, ec)
+	var err error
+
+	if ec.IsPaused() {
+		// the phase is still determined from the deployment, so the status stays up to date
+		_log.Info("reconciliation of EdgeConnect is paused", "annotation", api.AnnotationPaused)
+		conditions.SetPaused(ec.Conditions())
+	} else {
+		conditions.RemovePaused(ec.Conditions())
+
+		err = controller.reconcileEdgeConnectCR(ctx, ec)
+	}
 
 	if err != nil {
 		ec.Status.SetPhase(status.Error)
@@ -222,6 +260,8 @@ func (controller *Controller) reconcileEdgeConnect(ctx context.Context, ec *edge
 
 			return reconcile.Result{RequeueAfter: fastUpdateInterval}, retErr
 		}
+
+		controller.recordStatusEvents(ec, oldStatus)
 	}
 
 	_log.Info("reconciling EdgeConnect done")
@@ -390,7 +430,22 @@ func (controller *Controller) reconcileEdgeConnectRegular(ctx context.Context, e
 		return err
 	}
 
-	return nil
+	return controller.reconcilePodDisruptionBudget(ctx, ec)
+}
+
+func (controller *Controller) reconcilePodDisruptionBudget(ctx context.Context, ec *edgeconnect.EdgeConnect) error {
+	desiredPDB, err := deployment.NewPodDisruptionBudget(ec)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	pdbReconciler := k8spdb.NewReconciler(controller.client, controller.apiReader, log, ec, consts.PodDisruptionBudgetConditionType)
+
+	if desiredPDB == nil {
+		return pdbReconciler.Delete(ctx, ec.Name)
+	}
+
+	return pdbReconciler.CreateOrUpdate(ctx, desiredPDB)
 }
 
 func (controller *Controller) reconcileEdgeConnectProvisioner(ctx context.Context, ec *edgeconnect.EdgeConnect) error { //nolint:revive
@@ -405,7 +460,7 @@ func (controller *Controller) reconcileEdgeConnectProvisioner(ctx context.Contex
 		return err
 	}
 
-	tenantEdgeConnect, err := getEdgeConnectByName(edgeConnectClient, ec.Name)
+	tenantEdgeConnect, err := getEdgeConnectByName(ctx, edgeConnectClient, ec.Name)
 	if err != nil {
 		return err
 	}
@@ -425,7 +480,7 @@ func (controller *Controller) reconcileEdgeConnectProvisioner(ctx context.Contex
 		if edgeConnectIDFromSecret == "" {
 			_log.Info("EdgeConnect has to be recreated due to missing secret")
 
-			if err := edgeConnectClient.DeleteEdgeConnect(tenantEdgeConnect.ID); err != nil {
+			if err := edgeConnectClient.DeleteEdgeConnect(ctx, tenantEdgeConnect.ID); err != nil {
 				return err
 			}
 
@@ -433,7 +488,7 @@ func (controller *Controller) reconcileEdgeConnectProvisioner(ctx context.Contex
 		} else if tenantEdgeConnect.ID != edgeConnectIDFromSecret {
 			_log.Info("EdgeConnect has to be recreated due to invalid Id")
 
-			if err := edgeConnectClient.DeleteEdgeConnect(tenantEdgeConnect.ID); err != nil {
+			if err := edgeConnectClient.DeleteEdgeConnect(ctx, tenantEdgeConnect.ID); err != nil {
 				return err
 			}
 
@@ -507,6 +562,9 @@ func newEdgeConnectClient() func(ctx context.Context, ec *edgeconnect.EdgeConnec
 				"settings:objects:write",
 			}),
 			edgeconnectClient.WithContext(ctx),
+			edgeconnectClient.WithTimeout(apiTimeout()),
+			edgeconnectClient.WithRetryPolicy(utils.DefaultRetryPolicy()),
+			edgeconnectClient.WithInstrumentation(ec.Namespace, ec.Name),
 		)
 		if err != nil {
 			return nil, errors.WithStack(err)
@@ -516,10 +574,10 @@ func newEdgeConnectClient() func(ctx context.Context, ec *edgeconnect.EdgeConnec
 	}
 }
 
-func getEdgeConnectByName(edgeConnectClient edgeconnectClient.Client, name string) (edgeconnectClient.GetResponse, error) {
+func getEdgeConnectByName(ctx context.Context, edgeConnectClient edgeconnectClient.Client, name string) (edgeconnectClient.GetResponse, error) {
 	_log := log.WithValues("name", name)
 
-	ecs, err := edgeConnectClient.GetEdgeConnects(name)
+	ecs, err := edgeConnectClient.GetEdgeConnects(ctx, name)
 	if err != nil {
 		log.Debug("Unable to get EdgeConnect object")
 
@@ -578,7 +636,7 @@ func (controller *Controller) getEdgeConnectIDFromClientSecret(ctx context.Conte
 func (controller *Controller) createEdgeConnect(ctx context.Context, edgeConnectClient edgeconnectClient.Client, ec *edgeconnect.EdgeConnect) error {
 	_log := log.WithValues("namespace", ec.Namespace, "name", ec.Name)
 
-	createResponse, err := edgeConnectClient.CreateEdgeConnect(edgeconnectClient.NewRequest(ec.Name, ec.HostPatterns(), ec.HostMappings(), ""))
+	createResponse, err := edgeConnectClient.CreateEdgeConnect(ctx, edgeconnectClient.NewRequest(ec.Name, ec.HostPatterns(), ec.HostMappings(), ""))
 	if err != nil {
 		_log.Debug("creating EdgeConnect failed")
 
@@ -639,7 +697,7 @@ func (controller *Controller) updateEdgeConnect(ctx context.Context, edgeConnect
 		return err
 	}
 
-	edgeConnectResponse, err := edgeConnectClient.GetEdgeConnect(id)
+	edgeConnectResponse, err := edgeConnectClient.GetEdgeConnect(ctx, id)
 	if err != nil {
 		_log.Debug("EdgeConnect object not found")
 
@@ -654,7 +712,7 @@ func (controller *Controller) updateEdgeConnect(ctx context.Context, edgeConnect
 
 	log.Debug("updating EdgeConnect", "name", ec.Name)
 
-	err = edgeConnectClient.UpdateEdgeConnect(id, edgeconnectClient.NewRequest(ec.Name, ec.HostPatterns(), ec.HostMappings(), oauthClientID))
+	err = edgeConnectClient.UpdateEdgeConnect(ctx, id, edgeconnectClient.NewRequest(ec.Name, ec.HostPatterns(), ec.HostMappings(), oauthClientID))
 	if err != nil {
 		_log.Debug("updating EdgeConnect failed")
 
@@ -698,6 +756,12 @@ func (controller *Controller) createOrUpdateEdgeConnectDeploymentAndSettings(ctx
 		return err
 	}
 
+	if err := controller.reconcilePodDisruptionBudget(ctx, ec); err != nil {
+		_log.Debug("could not create or update PodDisruptionBudget for EdgeConnect")
+
+		return err
+	}
+
 	if ec.IsK8SAutomationEnabled() {
 		edgeConnectClient, err := controller.buildEdgeConnectClient(ctx, ec)
 		if err != nil {
@@ -706,7 +770,7 @@ func (controller *Controller) createOrUpdateEdgeConnectDeploymentAndSettings(ctx
 			return err
 		}
 
-		err = controller.createOrUpdateConnectionSetting(edgeConnectClient, ec, edgeConnectToken)
+		err = controller.createOrUpdateConnectionSetting(ctx, edgeConnectClient, ec, edgeConnectToken)
 		if err != nil {
 			_log.Debug("creating EdgeConnect connection setting failed")
 
@@ -719,10 +783,10 @@ func (controller *Controller) createOrUpdateEdgeConnectDeploymentAndSettings(ctx
 	return nil
 }
 
-func (controller *Controller) createOrUpdateConnectionSetting(edgeConnectClient edgeconnectClient.Client, ec *edgeconnect.EdgeConnect, latestToken string) error {
+func (controller *Controller) createOrUpdateConnectionSetting(ctx context.Context, edgeConnectClient edgeconnectClient.Client, ec *edgeconnect.EdgeConnect, latestToken string) error {
 	_log := log.WithValues("namespace", ec.Namespace, "name", ec.Name)
 
-	envSetting, err := GetConnectionSetting(edgeConnectClient, ec.Name, ec.Namespace, ec.Status.KubeSystemUID)
+	envSetting, err := GetConnectionSetting(ctx, edgeConnectClient, ec.Name, ec.Namespace, ec.Status.KubeSystemUID)
 	if err != nil {
 		_log.Info("Failed getting EdgeConnect connection setting object")
 
@@ -732,7 +796,7 @@ func (controller *Controller) createOrUpdateConnectionSetting(edgeConnectClient
 	if (envSetting == edgeconnectClient.EnvironmentSetting{}) {
 		_log.Debug("Creating edgeconnectClient connection setting object...")
 
-		err = edgeConnectClient.CreateConnectionSetting(
+		err = edgeConnectClient.CreateConnectionSetting(ctx,
 			edgeconnectClient.EnvironmentSetting{
 				SchemaID: edgeconnectClient.KubernetesConnectionSchemaID,
 				Scope:    edgeconnectClient.KubernetesConnectionScope,
@@ -754,7 +818,7 @@ func (controller *Controller) createOrUpdateConnectionSetting(edgeConnectClient
 			_log.Debug("Updating EdgeConnect connection setting object...")
 
 			envSetting.Value.Token = latestToken
-			err = edgeConnectClient.UpdateConnectionSetting(envSetting)
+			err = edgeConnectClient.UpdateConnectionSetting(ctx, envSetting)
 		}
 
 		if err != nil {
@@ -861,8 +925,8 @@ func (controller *Controller) getToken(ctx context.Context, ec *edgeconnect.Edge
 	return "", ErrTokenNotFound
 }
 
-func GetConnectionSetting(edgeConnectClient edgeconnectClient.Client, name, namespace, uid string) (edgeconnectClient.EnvironmentSetting, error) {
-	connectionSettings, err := edgeConnectClient.GetConnectionSettings()
+func GetConnectionSetting(ctx context.Context, edgeConnectClient edgeconnectClient.Client, name, namespace, uid string) (edgeconnectClient.EnvironmentSetting, error) {
+	connectionSettings, err := edgeConnectClient.GetConnectionSettings(ctx)
 	if err != nil {
 		return edgeconnectClient.EnvironmentSetting{}, err
 	}
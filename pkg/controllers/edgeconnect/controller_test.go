@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
@@ -182,6 +183,53 @@ func TestReconcile(t *testing.T) {
 		require.NoError(t, controller.client.Get(context.TODO(), client.ObjectKey{Name: testName, Namespace: testNamespace}, ec))
 		assert.Equal(t, status.Running, ec.Status.DeploymentPhase)
 	})
+	t.Run("paused EdgeConnect is not reconciled", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testName,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{api.AnnotationPaused: "true"},
+			},
+			Spec: edgeconnect.EdgeConnectSpec{
+				APIServer: "abc12345.dynatrace.com",
+				OAuth: edgeconnect.OAuthSpec{
+					Endpoint:     "https://test.com/sso/oauth2/token",
+					Resource:     "urn:dtenvironment:test12345",
+					ClientSecret: testOauthClientSecret,
+					Provisioner:  false,
+				},
+			},
+		}
+		controller := createFakeClientAndReconciler(t, ec,
+			createClientSecret(testOauthClientSecret, ec.Namespace),
+			createKubeSystemNamespace(),
+		)
+
+		_, err := controller.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+		})
+		require.NoError(t, err)
+
+		var deployment appsv1.Deployment
+
+		err = controller.client.Get(context.TODO(), client.ObjectKey{Name: testName, Namespace: testNamespace}, &deployment)
+		require.True(t, k8serrors.IsNotFound(err))
+
+		require.NoError(t, controller.client.Get(context.TODO(), client.ObjectKey{Name: testName, Namespace: testNamespace}, ec))
+		assert.True(t, meta.IsStatusConditionTrue(ec.Status.Conditions, conditions.PausedConditionType))
+
+		ec.Annotations = nil
+		require.NoError(t, controller.client.Update(context.TODO(), ec))
+
+		_, err = controller.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, controller.client.Get(context.TODO(), client.ObjectKey{Name: testName, Namespace: testNamespace}, &deployment))
+		require.NoError(t, controller.client.Get(context.TODO(), client.ObjectKey{Name: testName, Namespace: testNamespace}, ec))
+		assert.Nil(t, meta.FindStatusCondition(ec.Status.Conditions, conditions.PausedConditionType))
+	})
 	t.Run(`Reconciles doesn't fail if edgeconnectClient not found`, func(t *testing.T) {
 		controller := createFakeClientAndReconciler(t, nil)
 
@@ -336,8 +384,8 @@ func TestReconcileProvisionerCreate(t *testing.T) {
 		ec := createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns)
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything).Return(nil)
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything, mock.Anything).Return(nil)
 
 		controller := createFakeClientAndReconcilerForProvisioner(
 			t,
@@ -386,8 +434,8 @@ func TestReconcileProvisionerCreate(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "edge-connect", edgeConnectDeployment.Spec.Template.Spec.Containers[0].Name)
 
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", testName)
-		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", mock.Anything, testName)
+		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", mock.Anything, edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
 	})
 }
 
@@ -398,8 +446,8 @@ func TestReconcileProvisionerRecreate(t *testing.T) {
 		ec := createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns)
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything).Return(nil)
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything, mock.Anything).Return(nil)
 
 		controller := createFakeClientAndReconcilerForProvisioner(
 			t,
@@ -448,17 +496,17 @@ func TestReconcileProvisionerRecreate(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "edge-connect", edgeConnectDeployment.Spec.Template.Spec.Containers[0].Name)
 
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", testName)
-		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", testCreatedID)
-		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", mock.Anything, testName)
+		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", mock.Anything, testCreatedID)
+		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", mock.Anything, edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
 	})
 
 	t.Run("recreate EdgeConnect due to invalid id", func(t *testing.T) {
 		ec := createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns)
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything).Return(nil)
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything, mock.Anything).Return(nil)
 
 		controller := createFakeClientAndReconcilerForProvisioner(
 			t,
@@ -508,9 +556,9 @@ func TestReconcileProvisionerRecreate(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "edge-connect", edgeConnectDeployment.Spec.Template.Spec.Containers[0].Name)
 
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", testName)
-		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", testRecreatedInvalidID)
-		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", mock.Anything, testName)
+		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", mock.Anything, testRecreatedInvalidID)
+		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", mock.Anything, edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
 	})
 }
 
@@ -519,8 +567,8 @@ func TestReconcileProvisionerDelete(t *testing.T) {
 		ec := createEdgeConnectProvisionerCR([]string{finalizerName}, &metav1.Time{Time: time.Now()}, testHostPatterns)
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		edgeConnectClient.On("DeleteConnectionSetting", mock.Anything).Return(nil)
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		edgeConnectClient.On("DeleteConnectionSetting", mock.Anything, mock.Anything).Return(nil)
 
 		controller := createFakeClientAndReconcilerForProvisioner(
 			t,
@@ -542,15 +590,15 @@ func TestReconcileProvisionerDelete(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, k8serrors.IsNotFound(err))
 
-		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", testCreatedID)
+		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", mock.Anything, testCreatedID)
 	})
 
 	t.Run("delete EdgeConnect - missing client secret", func(t *testing.T) {
 		ec := createEdgeConnectProvisionerCR([]string{finalizerName}, &metav1.Time{Time: time.Now()}, testHostPatterns)
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		edgeConnectClient.On("DeleteConnectionSetting", mock.Anything).Return(nil)
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		edgeConnectClient.On("DeleteConnectionSetting", mock.Anything, mock.Anything).Return(nil)
 
 		controller := createFakeClientAndReconcilerForProvisioner(
 			t,
@@ -571,7 +619,7 @@ func TestReconcileProvisionerDelete(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, k8serrors.IsNotFound(err))
 
-		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", testCreatedID)
+		edgeConnectClient.AssertCalled(t, "DeleteEdgeConnect", mock.Anything, testCreatedID)
 	})
 
 	t.Run("delete EdgeConnect - missing EdgeConnect on the tenant", func(t *testing.T) {
@@ -598,7 +646,7 @@ func TestReconcileProvisionerDelete(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, k8serrors.IsNotFound(err))
 
-		edgeConnectClient.AssertNotCalled(t, "DeleteEdgeConnect", testCreatedID)
+		edgeConnectClient.AssertNotCalled(t, "DeleteEdgeConnect", mock.Anything, testCreatedID)
 	})
 }
 
@@ -624,9 +672,9 @@ func TestReconcileProvisionerUpdate(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", testName)
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnect", testCreatedID)
-		edgeConnectClient.AssertCalled(t, "UpdateEdgeConnect", testCreatedID, edgeconnectClient.NewRequest(testName, testHostPatterns2, testHostMappings, testCreatedOauthClientID))
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", mock.Anything, testName)
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnect", mock.Anything, testCreatedID)
+		edgeConnectClient.AssertCalled(t, "UpdateEdgeConnect", mock.Anything, testCreatedID, edgeconnectClient.NewRequest(testName, testHostPatterns2, testHostMappings, testCreatedOauthClientID))
 	})
 }
 
@@ -640,8 +688,8 @@ func TestReconcileProvisionerWithK8sAutomationsCreate(t *testing.T) {
 		}
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything).Return(nil)
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything, mock.Anything).Return(nil)
 
 		controller := createFakeClientAndReconcilerForProvisioner(
 			t,
@@ -690,8 +738,8 @@ func TestReconcileProvisionerWithK8sAutomationsCreate(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "edge-connect", edgeConnectDeployment.Spec.Template.Spec.Containers[0].Name)
 
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", testName)
-		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", mock.Anything, testName)
+		edgeConnectClient.AssertCalled(t, "CreateEdgeConnect", mock.Anything, edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, ""))
 	})
 }
 
@@ -720,9 +768,9 @@ func TestReconcileProvisionerWithK8sAutomationsUpdate(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", testName)
-		edgeConnectClient.AssertCalled(t, "GetEdgeConnect", testCreatedID)
-		edgeConnectClient.AssertCalled(t, "UpdateEdgeConnect", testCreatedID, edgeconnectClient.NewRequest(testName, testHostPatterns2, testHostMappings, testCreatedOauthClientID))
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnects", mock.Anything, testName)
+		edgeConnectClient.AssertCalled(t, "GetEdgeConnect", mock.Anything, testCreatedID)
+		edgeConnectClient.AssertCalled(t, "UpdateEdgeConnect", mock.Anything, testCreatedID, edgeconnectClient.NewRequest(testName, testHostPatterns2, testHostMappings, testCreatedOauthClientID))
 	})
 }
 
@@ -838,7 +886,7 @@ func createFakeClientAndReconcilerForProvisioner(t *testing.T, ec *edgeconnect.E
 
 func mockNewEdgeConnectClientCreate(edgeConnectClient *edgeconnectmock.Client, hostPatterns []string) func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
 	return func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
-		edgeConnectClient.On("GetEdgeConnects", testName).Return(
+		edgeConnectClient.On("GetEdgeConnects", mock.Anything, testName).Return(
 			edgeconnectClient.ListResponse{
 				TotalCount: 0,
 			},
@@ -846,7 +894,7 @@ func mockNewEdgeConnectClientCreate(edgeConnectClient *edgeconnectmock.Client, h
 		)
 
 		// CreateEdgeConnect creates edge connect
-		edgeConnectClient.On("CreateEdgeConnect", edgeconnectClient.NewRequest(testName, hostPatterns, testHostMappings, "")).Return(
+		edgeConnectClient.On("CreateEdgeConnect", mock.Anything, edgeconnectClient.NewRequest(testName, hostPatterns, testHostMappings, "")).Return(
 			edgeconnectClient.CreateResponse{
 				ID:                  testCreatedID,
 				Name:                testName,
@@ -864,7 +912,7 @@ func mockNewEdgeConnectClientCreate(edgeConnectClient *edgeconnectmock.Client, h
 
 func mockNewEdgeConnectClientRecreate(edgeConnectClient *edgeconnectmock.Client, id string) func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
 	return func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
-		edgeConnectClient.On("GetEdgeConnects", testName).Return(
+		edgeConnectClient.On("GetEdgeConnects", mock.Anything, testName).Return(
 			edgeconnectClient.ListResponse{
 				EdgeConnects: []edgeconnectClient.GetResponse{
 					{
@@ -880,9 +928,9 @@ func mockNewEdgeConnectClientRecreate(edgeConnectClient *edgeconnectmock.Client,
 			nil,
 		)
 
-		edgeConnectClient.On("DeleteEdgeConnect", id).Return(nil)
+		edgeConnectClient.On("DeleteEdgeConnect", mock.Anything, id).Return(nil)
 		// CreateEdgeConnect creates edge connect
-		edgeConnectClient.On("CreateEdgeConnect", edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, "")).Return(
+		edgeConnectClient.On("CreateEdgeConnect", mock.Anything, edgeconnectClient.NewRequest(testName, testHostPatterns, testHostMappings, "")).Return(
 			edgeconnectClient.CreateResponse{
 				ID:                  testCreatedID,
 				Name:                testName,
@@ -900,7 +948,7 @@ func mockNewEdgeConnectClientRecreate(edgeConnectClient *edgeconnectmock.Client,
 
 func mockNewEdgeConnectClientDelete(edgeConnectClient *edgeconnectmock.Client) func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
 	return func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
-		edgeConnectClient.On("GetEdgeConnects", testName).Return(
+		edgeConnectClient.On("GetEdgeConnects", mock.Anything, testName).Return(
 			edgeconnectClient.ListResponse{
 				EdgeConnects: []edgeconnectClient.GetResponse{
 					{
@@ -915,7 +963,7 @@ func mockNewEdgeConnectClientDelete(edgeConnectClient *edgeconnectmock.Client) f
 			},
 			nil,
 		)
-		edgeConnectClient.On("DeleteEdgeConnect", testCreatedID).Return(nil)
+		edgeConnectClient.On("DeleteEdgeConnect", mock.Anything, testCreatedID).Return(nil)
 
 		return edgeConnectClient, nil
 	}
@@ -923,13 +971,13 @@ func mockNewEdgeConnectClientDelete(edgeConnectClient *edgeconnectmock.Client) f
 
 func mockNewEdgeConnectClientDeleteNotFoundOnTenant(edgeConnectClient *edgeconnectmock.Client) func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
 	return func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
-		edgeConnectClient.On("GetEdgeConnects", testName).Return(
+		edgeConnectClient.On("GetEdgeConnects", mock.Anything, testName).Return(
 			edgeconnectClient.ListResponse{
 				TotalCount: 0,
 			},
 			nil,
 		)
-		edgeConnectClient.On("DeleteEdgeConnect", testCreatedID).Return(nil).Maybe()
+		edgeConnectClient.On("DeleteEdgeConnect", mock.Anything, testCreatedID).Return(nil).Maybe()
 
 		return edgeConnectClient, nil
 	}
@@ -937,7 +985,7 @@ func mockNewEdgeConnectClientDeleteNotFoundOnTenant(edgeConnectClient *edgeconne
 
 func mockNewEdgeConnectClientUpdate(edgeConnectClient *edgeconnectmock.Client, fromHostPatterns []string, toHostPatterns []string) func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
 	return func(ctx context.Context, ec *edgeconnect.EdgeConnect, oauthCredentials oauthCredentialsType) (edgeconnectClient.Client, error) {
-		edgeConnectClient.On("GetEdgeConnects", testName).Return(
+		edgeConnectClient.On("GetEdgeConnects", mock.Anything, testName).Return(
 			edgeconnectClient.ListResponse{
 				EdgeConnects: []edgeconnectClient.GetResponse{
 					{
@@ -953,7 +1001,7 @@ func mockNewEdgeConnectClientUpdate(edgeConnectClient *edgeconnectmock.Client, f
 			nil,
 		)
 
-		edgeConnectClient.On("GetEdgeConnect", testCreatedID).Return(
+		edgeConnectClient.On("GetEdgeConnect", mock.Anything, testCreatedID).Return(
 			edgeconnectClient.GetResponse{
 				ID:            testCreatedID,
 				Name:          testName,
@@ -964,10 +1012,10 @@ func mockNewEdgeConnectClientUpdate(edgeConnectClient *edgeconnectmock.Client, f
 		)
 
 		// CreateEdgeConnect creates edge connect
-		edgeConnectClient.On("UpdateEdgeConnect", testCreatedID, edgeconnectClient.NewRequest(testName, toHostPatterns, testHostMappings, testCreatedOauthClientID)).Return(nil)
+		edgeConnectClient.On("UpdateEdgeConnect", mock.Anything, testCreatedID, edgeconnectClient.NewRequest(testName, toHostPatterns, testHostMappings, testCreatedOauthClientID)).Return(nil)
 
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything).Return(nil)
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		edgeConnectClient.On("UpdateConnectionSetting", mock.Anything, mock.Anything).Return(nil)
 
 		return edgeConnectClient, nil
 	}
@@ -1008,18 +1056,18 @@ func TestController_createOrUpdateConnectionSetting(t *testing.T) {
 	t.Run("Create Connection Setting object", func(t *testing.T) {
 		controller := mockController()
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{}, nil)
-		edgeConnectClient.On("CreateConnectionSetting", mock.Anything).Return(nil)
-		err := controller.createOrUpdateConnectionSetting(edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{}, nil)
+		edgeConnectClient.On("CreateConnectionSetting", mock.Anything, mock.Anything).Return(nil)
+		err := controller.createOrUpdateConnectionSetting(context.Background(), edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
 		require.NoError(t, err)
 	})
 	t.Run("Existing Connection Setting object", func(t *testing.T) {
 		controller := mockController()
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
-		err := controller.createOrUpdateConnectionSetting(edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{testEnvironmentSetting}, nil)
+		err := controller.createOrUpdateConnectionSetting(context.Background(), edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
 		require.NoError(t, err)
-		edgeConnectClient.AssertNotCalled(t, "CreateConnectionSetting", mock.Anything)
+		edgeConnectClient.AssertNotCalled(t, "CreateConnectionSetting", mock.Anything, mock.Anything)
 	})
 	t.Run("Existing object with same Cluster ID but different name", func(t *testing.T) {
 		controller := mockController()
@@ -1028,9 +1076,9 @@ func TestController_createOrUpdateConnectionSetting(t *testing.T) {
 		differentEnvironmentSetting.Value.Namespace = "different-namespace"
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return([]edgeconnectClient.EnvironmentSetting{differentEnvironmentSetting}, nil)
-		edgeConnectClient.On("CreateConnectionSetting", mock.Anything).Return(nil)
-		err := controller.createOrUpdateConnectionSetting(edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return([]edgeconnectClient.EnvironmentSetting{differentEnvironmentSetting}, nil)
+		edgeConnectClient.On("CreateConnectionSetting", mock.Anything, mock.Anything).Return(nil)
+		err := controller.createOrUpdateConnectionSetting(context.Background(), edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
 		require.NoError(t, err)
 	})
 	t.Run("Server fails", func(t *testing.T) {
@@ -1040,8 +1088,8 @@ func TestController_createOrUpdateConnectionSetting(t *testing.T) {
 		expectedEnvironmentSetting.Value.Namespace = "different-namespace"
 
 		edgeConnectClient := edgeconnectmock.NewClient(t)
-		edgeConnectClient.On("GetConnectionSettings").Return(nil, errors.New("something went wrong"))
-		err := controller.createOrUpdateConnectionSetting(edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
+		edgeConnectClient.On("GetConnectionSettings", mock.Anything).Return(nil, errors.New("something went wrong"))
+		err := controller.createOrUpdateConnectionSetting(context.Background(), edgeConnectClient, createEdgeConnectProvisionerCR([]string{}, nil, testHostPatterns), "")
 		require.Error(t, err)
 	})
 }
@@ -0,0 +1,24 @@
+package deployment
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	k8spdb "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pdb"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/utils/ptr"
+)
+
+// NewPodDisruptionBudget returns the PodDisruptionBudget protecting the EdgeConnect pods,
+// or nil if there is none needed, because no budget is configured and only a single replica is running.
+func NewPodDisruptionBudget(ec *edgeconnect.EdgeConnect) (*policyv1.PodDisruptionBudget, error) {
+	budget := pdb.GetBudget(ec.Spec.PodDisruptionBudget, ptr.Deref(ec.Spec.Replicas, 1))
+	if budget == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	appLabels := buildAppLabels(ec)
+
+	return k8spdb.Build(ec, ec.Name, appLabels.BuildMatchLabels(), *budget,
+		k8spdb.SetLabels(appLabels.BuildLabels()),
+	)
+}
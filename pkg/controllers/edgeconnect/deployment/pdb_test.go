@@ -0,0 +1,56 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewPodDisruptionBudget(t *testing.T) {
+	newEdgeConnect := func(replicas *int32, budget *pdb.Spec) *edgeconnect.EdgeConnect {
+		return &edgeconnect.EdgeConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: edgeconnect.EdgeConnectSpec{
+				Replicas:            replicas,
+				PodDisruptionBudget: budget,
+			},
+		}
+	}
+
+	t.Run("single replica without budget => no PodDisruptionBudget", func(t *testing.T) {
+		podDisruptionBudget, err := NewPodDisruptionBudget(newEdgeConnect(nil, nil))
+		require.NoError(t, err)
+		assert.Nil(t, podDisruptionBudget)
+	})
+	t.Run("multiple replicas without budget => default PodDisruptionBudget", func(t *testing.T) {
+		ec := newEdgeConnect(ptr.To(int32(2)), nil)
+
+		podDisruptionBudget, err := NewPodDisruptionBudget(ec)
+		require.NoError(t, err)
+		require.NotNil(t, podDisruptionBudget)
+
+		assert.Equal(t, testName, podDisruptionBudget.Name)
+		assert.Equal(t, testNamespace, podDisruptionBudget.Namespace)
+		assert.Equal(t, intstr.FromInt32(1), *podDisruptionBudget.Spec.MaxUnavailable)
+		assert.Equal(t, New(ec).Spec.Selector.MatchLabels, podDisruptionBudget.Spec.Selector.MatchLabels)
+	})
+	t.Run("configured budget => applied to PodDisruptionBudget", func(t *testing.T) {
+		minAvailable := intstr.FromString("50%")
+
+		podDisruptionBudget, err := NewPodDisruptionBudget(newEdgeConnect(nil, &pdb.Spec{MinAvailable: &minAvailable}))
+		require.NoError(t, err)
+		require.NotNil(t, podDisruptionBudget)
+
+		assert.Equal(t, minAvailable, *podDisruptionBudget.Spec.MinAvailable)
+		assert.Nil(t, podDisruptionBudget.Spec.MaxUnavailable)
+	})
+}
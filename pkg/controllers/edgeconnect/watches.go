@@ -0,0 +1,83 @@
+package edgeconnect
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Field indexes of the EdgeConnects, holding the names of the user-provided secrets and configmaps an EdgeConnect refers to.
+const (
+	referencedSecretsIndex    = "spec.referencedSecrets"
+	referencedConfigMapsIndex = "spec.referencedConfigMaps"
+)
+
+// setupIndexes registers the field indexes used to find the EdgeConnects referring to a secret or configmap.
+func setupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &edgeconnect.EdgeConnect{}, referencedSecretsIndex, referencedSecrets); err != nil {
+		return errors.WithMessage(err, "failed to index referenced secrets of edgeconnects")
+	}
+
+	if err := indexer.IndexField(ctx, &edgeconnect.EdgeConnect{}, referencedConfigMapsIndex, referencedConfigMaps); err != nil {
+		return errors.WithMessage(err, "failed to index referenced configmaps of edgeconnects")
+	}
+
+	return nil
+}
+
+// referencedSecrets returns the names of the secrets provided by the user for the EdgeConnect.
+func referencedSecrets(obj client.Object) []string {
+	ec, ok := obj.(*edgeconnect.EdgeConnect)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+
+	if ec.Spec.OAuth.ClientSecret != "" {
+		names = append(names, ec.Spec.OAuth.ClientSecret)
+	}
+
+	if ec.Spec.CustomPullSecret != "" {
+		names = append(names, ec.Spec.CustomPullSecret)
+	}
+
+	return names
+}
+
+// referencedConfigMaps returns the names of the configmaps provided by the user for the EdgeConnect.
+func referencedConfigMaps(obj client.Object) []string {
+	ec, ok := obj.(*edgeconnect.EdgeConnect)
+	if !ok || ec.Spec.CaCertsRef == "" {
+		return nil
+	}
+
+	return []string{ec.Spec.CaCertsRef}
+}
+
+// requestsForReferencingEdgeConnects maps a secret or configmap to the EdgeConnects referring to it via the given index.
+func (controller *Controller) requestsForReferencingEdgeConnects(index string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var ecList edgeconnect.EdgeConnectList
+
+		err := controller.client.List(ctx, &ecList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{index: obj.GetName()})
+		if err != nil {
+			log.Error(err, "failed to list edgeconnects referring to changed object", "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(ecList.Items))
+		for _, ec := range ecList.Items {
+			log.Info("referenced object changed, reconciling EdgeConnect", "namespace", ec.Namespace, "name", ec.Name, "object", obj.GetName())
+
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&ec)})
+		}
+
+		return requests
+	}
+}
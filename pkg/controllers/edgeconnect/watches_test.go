@@ -0,0 +1,45 @@
+package edgeconnect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRequestsForReferencingEdgeConnects(t *testing.T) {
+	ctx := context.Background()
+
+	ec := &edgeconnect.EdgeConnect{
+		ObjectMeta: metav1.ObjectMeta{Name: "edgeconnect", Namespace: testNamespace},
+		Spec: edgeconnect.EdgeConnectSpec{
+			OAuth:      edgeconnect.OAuthSpec{ClientSecret: "oauth"},
+			CaCertsRef: "certs",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(ec).
+		WithIndex(&edgeconnect.EdgeConnect{}, referencedSecretsIndex, referencedSecrets).
+		WithIndex(&edgeconnect.EdgeConnect{}, referencedConfigMapsIndex, referencedConfigMaps).
+		Build()
+	controller := &Controller{client: fakeClient}
+
+	expected := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ec.Name, Namespace: ec.Namespace}}}
+
+	secretRequests := controller.requestsForReferencingEdgeConnects(referencedSecretsIndex)
+	configMapRequests := controller.requestsForReferencingEdgeConnects(referencedConfigMapsIndex)
+
+	assert.Equal(t, expected, secretRequests(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: testNamespace}}))
+	assert.Empty(t, secretRequests(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: "other"}}))
+	assert.Equal(t, expected, configMapRequests(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "certs", Namespace: testNamespace}}))
+	assert.Empty(t, configMapRequests(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: testNamespace}}))
+}
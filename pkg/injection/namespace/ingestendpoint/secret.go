@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
@@ -193,7 +194,7 @@ func MetricsIngestURLForClusterActiveGate(dk *dynakube.DynaKube) (string, error)
 		return "", err
 	}
 
-	serviceName := capability.BuildServiceName(dk.Name)
+	serviceName := capability.BuildCapabilityServiceName(*dk, activegate.MetricsIngestCapability.DisplayName)
 
 	return fmt.Sprintf("http://%s.%s/e/%s/api/v2/metrics/ingest", serviceName, dk.Namespace, tenant), nil
 }
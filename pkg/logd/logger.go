@@ -18,6 +18,7 @@ const (
 
 var (
 	baseLogger     Logger
+	baseWriter     *prettyLogWriter
 	baseLoggerOnce sync.Once
 )
 
@@ -26,12 +27,22 @@ var (
 func Get() Logger {
 	baseLoggerOnce.Do(func() {
 		logLevel := readLogLevelFromEnv()
-		baseLogger = createLogger(NewPrettyLogWriter(), logLevel)
+		baseWriter = &prettyLogWriter{out: os.Stdout}
+		baseLogger = createLogger(baseWriter, logLevel)
 	})
 
 	return baseLogger
 }
 
+// RedirectOutput changes where the base logger and all loggers derived from it write to.
+// Commands that print their result to stdout use it to keep their output free of logs.
+// It must be called before any logs are written concurrently.
+func RedirectOutput(out io.Writer) {
+	Get()
+
+	baseWriter.out = out
+}
+
 func LogBaseLoggerSettings() {
 	logLevel := readLogLevelFromEnv()
 	baseLogger.Info("logging level", "logLevel", logLevel.String())
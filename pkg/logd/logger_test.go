@@ -2,6 +2,7 @@ package logd
 
 import (
 	"bytes"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,19 @@ func TestLogLevelFromEnvEmptyString(t *testing.T) {
 	assert.Equal(t, zapcore.InfoLevel, logLevel)
 }
 
+func TestRedirectOutput(t *testing.T) {
+	logBuffer := bytes.Buffer{}
+
+	derived := Get().WithName("derived")
+
+	RedirectOutput(&logBuffer)
+	t.Cleanup(func() { RedirectOutput(os.Stdout) })
+
+	derived.Info("redirected message")
+
+	assert.Contains(t, logBuffer.String(), "redirected message")
+}
+
 func TestLogger(t *testing.T) {
 	t.Run("log level Info", func(t *testing.T) {
 		logBuffer := bytes.Buffer{}
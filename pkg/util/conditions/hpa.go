@@ -0,0 +1,20 @@
+package conditions
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	HorizontalPodAutoscalerCreatedReason = "HorizontalPodAutoscalerCreated"
+)
+
+func SetHorizontalPodAutoscalerCreated(conditions *[]metav1.Condition, conditionType, name string) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  HorizontalPodAutoscalerCreatedReason,
+		Message: appendCreatedSuffix(name),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
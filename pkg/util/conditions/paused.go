@@ -0,0 +1,26 @@
+package conditions
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PausedConditionType = "Paused"
+	PausedReason        = "ReconciliationPaused"
+)
+
+func SetPaused(conditions *[]metav1.Condition) {
+	condition := metav1.Condition{
+		Type:    PausedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  PausedReason,
+		Message: "Reconciliation is paused by the " + api.AnnotationPaused + " annotation, remove it to resume",
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
+
+func RemovePaused(conditions *[]metav1.Condition) {
+	meta.RemoveStatusCondition(conditions, PausedConditionType)
+}
@@ -0,0 +1,189 @@
+// Package cron parses the standard 5-field cron expressions (minute, hour, day of month, month, day of week)
+// and checks whether a point in time matches them.
+// - Supported syntax per field: `*`, single values, ranges (`1-5`), steps (`*/15`, `0-30/10`, `5/10`) and lists of them (`1,3,5-7`).
+// - Day of week accepts 0-7, both 0 and 7 are Sunday.
+// - As in other cron implementations, if both day of month and day of week are restricted, matching either of them is enough.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	fieldCount = 5
+
+	fieldSep = ","
+	rangeSep = "-"
+	stepSep  = "/"
+	wildcard = "*"
+)
+
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteBounds     = bounds{name: "minute", min: 0, max: 59}
+	hourBounds       = bounds{name: "hour", min: 0, max: 23}
+	dayOfMonthBounds = bounds{name: "day of month", min: 1, max: 31}
+	monthBounds      = bounds{name: "month", min: 1, max: 12}
+	dayOfWeekBounds  = bounds{name: "day of week", min: 0, max: 7}
+)
+
+// Schedule is a parsed cron expression, each field is a bitset of the allowed values.
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+func Parse(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != fieldCount {
+		return nil, errors.Errorf("cron expression '%s' must have %d fields, found %d", expression, fieldCount, len(fields))
+	}
+
+	var (
+		schedule Schedule
+		err      error
+	)
+
+	if schedule.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+
+	if schedule.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+
+	if schedule.dayOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, err
+	}
+
+	if schedule.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+
+	if schedule.dayOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, err
+	}
+
+	// 7 is an alias for Sunday
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+
+	schedule.dayOfMonthRestricted = !strings.HasPrefix(fields[2], wildcard)
+	schedule.dayOfWeekRestricted = !strings.HasPrefix(fields[4], wildcard)
+
+	return &schedule, nil
+}
+
+// Matches checks if the schedule fires at the minute of t, seconds are ignored.
+func (schedule *Schedule) Matches(t time.Time) bool {
+	if !has(schedule.minute, t.Minute()) || !has(schedule.hour, t.Hour()) || !has(schedule.month, int(t.Month())) {
+		return false
+	}
+
+	dayOfMonth := has(schedule.dayOfMonth, t.Day())
+	dayOfWeek := has(schedule.dayOfWeek, int(t.Weekday()))
+
+	if schedule.dayOfMonthRestricted && schedule.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+
+	return dayOfMonth && dayOfWeek
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(field, fieldSep) {
+		partSet, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+
+		set |= partSet
+	}
+
+	return set, nil
+}
+
+func parsePart(part string, b bounds) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, stepSep)
+
+	step := 1
+
+	if hasStep {
+		var err error
+
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step <= 0 {
+			return 0, errors.Errorf("invalid step '%s' in %s field", stepPart, b.name)
+		}
+	}
+
+	start, end := b.min, b.max
+
+	switch {
+	case rangePart == wildcard:
+	case strings.Contains(rangePart, rangeSep):
+		low, high, _ := strings.Cut(rangePart, rangeSep)
+
+		var err error
+
+		if start, err = parseValue(low, b); err != nil {
+			return 0, err
+		}
+
+		if end, err = parseValue(high, b); err != nil {
+			return 0, err
+		}
+
+		if start > end {
+			return 0, errors.Errorf("invalid range '%s' in %s field", rangePart, b.name)
+		}
+	default:
+		value, err := parseValue(rangePart, b)
+		if err != nil {
+			return 0, err
+		}
+
+		start = value
+		// a single value with a step, e.g. 5/10, runs until the end of the field
+		if !hasStep {
+			end = value
+		}
+	}
+
+	var set uint64
+	for value := start; value <= end; value += step {
+		set |= 1 << uint(value)
+	}
+
+	return set, nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Errorf("invalid value '%s' in %s field", value, b.name)
+	}
+
+	if parsed < b.min || parsed > b.max {
+		return 0, errors.Errorf("value %d out of range [%d-%d] in %s field", parsed, b.min, b.max, b.name)
+	}
+
+	return parsed, nil
+}
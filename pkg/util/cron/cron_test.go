@@ -0,0 +1,92 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(value string) time.Time {
+	t, _ := time.Parse(time.DateTime, value)
+
+	return t
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid expressions", func(t *testing.T) {
+		for _, expression := range []string{
+			"* * * * *",
+			"0 22 * * 1-5",
+			"*/15 0-6 1,15 * 0,7",
+			"5/10 1-23/2 * 1-12 *",
+		} {
+			_, err := Parse(expression)
+			require.NoError(t, err, expression)
+		}
+	})
+	t.Run("invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{
+			"",
+			"* * * *",
+			"* * * * * *",
+			"60 * * * *",
+			"* 24 * * *",
+			"* * 0 * *",
+			"* * * 13 *",
+			"* * * * 8",
+			"5-1 * * * *",
+			"*/0 * * * *",
+			"a * * * *",
+			"1- * * * *",
+		} {
+			_, err := Parse(expression)
+			require.Error(t, err, expression)
+		}
+	})
+}
+
+func TestMatches(t *testing.T) {
+	t.Run("minute and hour", func(t *testing.T) {
+		schedule, err := Parse("30 22 * * *")
+		require.NoError(t, err)
+
+		assert.True(t, schedule.Matches(at("2025-01-06 22:30:45")))
+		assert.False(t, schedule.Matches(at("2025-01-06 22:31:00")))
+		assert.False(t, schedule.Matches(at("2025-01-06 21:30:00")))
+	})
+	t.Run("steps", func(t *testing.T) {
+		schedule, err := Parse("5/20 * * * *")
+		require.NoError(t, err)
+
+		assert.True(t, schedule.Matches(at("2025-01-06 10:05:00")))
+		assert.True(t, schedule.Matches(at("2025-01-06 10:45:00")))
+		assert.False(t, schedule.Matches(at("2025-01-06 10:00:00")))
+	})
+	t.Run("day of week, 7 is Sunday", func(t *testing.T) {
+		schedule, err := Parse("0 0 * * 6-7")
+		require.NoError(t, err)
+
+		// 2025-01-04 is a Saturday
+		assert.True(t, schedule.Matches(at("2025-01-04 00:00:00")))
+		assert.True(t, schedule.Matches(at("2025-01-05 00:00:00")))
+		assert.False(t, schedule.Matches(at("2025-01-06 00:00:00")))
+	})
+	t.Run("day of month or day of week if both are restricted", func(t *testing.T) {
+		schedule, err := Parse("0 0 1 * 1")
+		require.NoError(t, err)
+
+		assert.True(t, schedule.Matches(at("2025-01-01 00:00:00")))
+		assert.True(t, schedule.Matches(at("2025-01-06 00:00:00")))
+		assert.False(t, schedule.Matches(at("2025-01-07 00:00:00")))
+	})
+	t.Run("day of month and month", func(t *testing.T) {
+		schedule, err := Parse("0 0 15 6 *")
+		require.NoError(t, err)
+
+		assert.True(t, schedule.Matches(at("2025-06-15 00:00:00")))
+		assert.False(t, schedule.Matches(at("2025-07-15 00:00:00")))
+		assert.False(t, schedule.Matches(at("2025-06-16 00:00:00")))
+	})
+}
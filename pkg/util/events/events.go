@@ -0,0 +1,99 @@
+package events
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	PhaseChangedReason     = "PhaseChanged"
+	VersionRolloutReason   = "VersionRollout"
+	ConditionRemovedReason = "ConditionRemoved"
+)
+
+// StatusRecorder emits Events on a custom resource for the transitions between two versions of its status.
+// Only differences are recorded, so reconciles that leave the status untouched do not emit any Events.
+type StatusRecorder struct {
+	recorder record.EventRecorder
+}
+
+func NewStatusRecorder(recorder record.EventRecorder) StatusRecorder {
+	return StatusRecorder{recorder: recorder}
+}
+
+// RecordPhase emits an Event if the phase changed, a Warning if the new phase is Error.
+func (sr StatusRecorder) RecordPhase(obj runtime.Object, oldPhase, newPhase status.DeploymentPhase) {
+	if oldPhase == newPhase {
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if newPhase == status.Error {
+		eventType = corev1.EventTypeWarning
+	}
+
+	if oldPhase == "" {
+		sr.eventf(obj, eventType, PhaseChangedReason, "Phase set to %s", newPhase)
+
+		return
+	}
+
+	sr.eventf(obj, eventType, PhaseChangedReason, "Phase changed from %s to %s", oldPhase, newPhase)
+}
+
+// RecordConditions emits an Event for every condition that was added, changed or removed.
+// Conditions with status False report a problem, so they are recorded as Warning.
+// The reason of the Event is the reason of the condition.
+func (sr StatusRecorder) RecordConditions(obj runtime.Object, oldConditions, newConditions []metav1.Condition) {
+	for _, newCondition := range newConditions {
+		oldCondition := meta.FindStatusCondition(oldConditions, newCondition.Type)
+		if oldCondition != nil && isConditionEqual(*oldCondition, newCondition) {
+			continue
+		}
+
+		eventType := corev1.EventTypeNormal
+		if newCondition.Status == metav1.ConditionFalse {
+			eventType = corev1.EventTypeWarning
+		}
+
+		sr.eventf(obj, eventType, newCondition.Reason, "%s: %s", newCondition.Type, newCondition.Message)
+	}
+
+	for _, oldCondition := range oldConditions {
+		if meta.FindStatusCondition(newConditions, oldCondition.Type) == nil {
+			sr.eventf(obj, corev1.EventTypeNormal, ConditionRemovedReason, "%s: condition removed", oldCondition.Type)
+		}
+	}
+}
+
+// RecordVersion emits an Event if a new version of the given component is rolled out.
+func (sr StatusRecorder) RecordVersion(obj runtime.Object, component string, oldVersion, newVersion status.VersionStatus) {
+	if newVersion.Version == "" || oldVersion.Version == newVersion.Version {
+		return
+	}
+
+	if oldVersion.Version == "" {
+		sr.eventf(obj, corev1.EventTypeNormal, VersionRolloutReason, "Rolling out %s version %s", component, newVersion.Version)
+
+		return
+	}
+
+	sr.eventf(obj, corev1.EventTypeNormal, VersionRolloutReason, "Rolling out %s version %s, previous version was %s", component, newVersion.Version, oldVersion.Version)
+}
+
+func (sr StatusRecorder) eventf(obj runtime.Object, eventType, reason, messageFmt string, args ...any) {
+	if sr.recorder == nil {
+		return
+	}
+
+	sr.recorder.Eventf(obj, eventType, reason, messageFmt, args...)
+}
+
+// isConditionEqual ignores the timestamps and generation of the conditions, as they change without a real transition.
+func isConditionEqual(a, b metav1.Condition) bool {
+	return a.Status == b.Status && a.Reason == b.Reason && a.Message == b.Message
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordPhase(t *testing.T) {
+	dk := &dynakube.DynaKube{}
+
+	t.Run("unchanged phase emits nothing", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		NewStatusRecorder(recorder).RecordPhase(dk, status.Running, status.Running)
+
+		assert.Empty(t, drain(recorder))
+	})
+	t.Run("error phase is a warning", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		NewStatusRecorder(recorder).RecordPhase(dk, status.Running, status.Error)
+		NewStatusRecorder(recorder).RecordPhase(dk, "", status.Deploying)
+
+		assert.Equal(t, []string{
+			"Warning PhaseChanged Phase changed from Running to Error",
+			"Normal PhaseChanged Phase set to Deploying",
+		}, drain(recorder))
+	})
+	t.Run("no recorder", func(t *testing.T) {
+		StatusRecorder{}.RecordPhase(dk, status.Running, status.Error)
+	})
+}
+
+func TestRecordConditions(t *testing.T) {
+	dk := &dynakube.DynaKube{}
+
+	oldConditions := []metav1.Condition{
+		{Type: "Token", Status: metav1.ConditionTrue, Reason: "TokenReady", Message: "Token ready", LastTransitionTime: metav1.Unix(1, 0)},
+		{Type: "Secret", Status: metav1.ConditionTrue, Reason: "SecretCreated", Message: "secret created"},
+		{Type: "Deprecated", Status: metav1.ConditionTrue, Reason: "Ready", Message: "ready"},
+	}
+
+	t.Run("unchanged conditions emit nothing", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+
+		newConditions := []metav1.Condition{oldConditions[0], oldConditions[1], oldConditions[2]}
+		newConditions[0].LastTransitionTime = metav1.Unix(2, 0)
+
+		NewStatusRecorder(recorder).RecordConditions(dk, oldConditions, newConditions)
+
+		assert.Empty(t, drain(recorder))
+	})
+	t.Run("added, changed and removed conditions", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+
+		newConditions := []metav1.Condition{
+			{Type: "Token", Status: metav1.ConditionFalse, Reason: "TokenError", Message: "missing scopes"},
+			{Type: "Secret", Status: metav1.ConditionTrue, Reason: "SecretUpdated", Message: "secret updated"},
+			{Type: "Version", Status: metav1.ConditionFalse, Reason: "Downgrade", Message: "Downgrade detected"},
+		}
+
+		NewStatusRecorder(recorder).RecordConditions(dk, oldConditions, newConditions)
+
+		assert.Equal(t, []string{
+			"Warning TokenError Token: missing scopes",
+			"Normal SecretUpdated Secret: secret updated",
+			"Warning Downgrade Version: Downgrade detected",
+			"Normal ConditionRemoved Deprecated: condition removed",
+		}, drain(recorder))
+	})
+}
+
+func TestRecordVersion(t *testing.T) {
+	dk := &dynakube.DynaKube{}
+
+	recorder := record.NewFakeRecorder(10)
+	statusRecorder := NewStatusRecorder(recorder)
+
+	statusRecorder.RecordVersion(dk, "OneAgent", status.VersionStatus{}, status.VersionStatus{})
+	statusRecorder.RecordVersion(dk, "OneAgent", status.VersionStatus{Version: "1.2.3"}, status.VersionStatus{Version: "1.2.3"})
+	statusRecorder.RecordVersion(dk, "OneAgent", status.VersionStatus{}, status.VersionStatus{Version: "1.2.3"})
+	statusRecorder.RecordVersion(dk, "ActiveGate", status.VersionStatus{Version: "1.2.3"}, status.VersionStatus{Version: "1.3.0"})
+
+	assert.Equal(t, []string{
+		"Normal VersionRollout Rolling out OneAgent version 1.2.3",
+		"Normal VersionRollout Rolling out ActiveGate version 1.3.0, previous version was 1.2.3",
+	}, drain(recorder))
+}
+
+func drain(recorder *record.FakeRecorder) []string {
+	var events []string
+
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package networkpolicy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/query"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Query(kubeClient client.Client, kubeReader client.Reader, log logd.Logger) query.Generic[*networkingv1.NetworkPolicy, *networkingv1.NetworkPolicyList] {
+	return query.Generic[*networkingv1.NetworkPolicy, *networkingv1.NetworkPolicyList]{
+		Target:     &networkingv1.NetworkPolicy{},
+		ListTarget: &networkingv1.NetworkPolicyList{},
+		ToList: func(nl *networkingv1.NetworkPolicyList) []*networkingv1.NetworkPolicy {
+			out := []*networkingv1.NetworkPolicy{}
+			for _, n := range nl.Items {
+				out = append(out, &n)
+			}
+
+			return out
+		},
+		IsEqual:      isEqual,
+		MustRecreate: mustRecreate,
+
+		KubeClient: kubeClient,
+		KubeReader: kubeReader,
+		Log:        log,
+	}
+}
+
+func isEqual(current, desired *networkingv1.NetworkPolicy) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
+
+func mustRecreate(_, _ *networkingv1.NetworkPolicy) bool {
+	return false
+}
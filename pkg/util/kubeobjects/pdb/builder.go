@@ -0,0 +1,42 @@
+package pdb
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/builder"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// Mandatory fields, provided in constructor as named params
+	setName      = builder.SetName[*policyv1.PodDisruptionBudget]
+	setNamespace = builder.SetNamespace[*policyv1.PodDisruptionBudget]
+
+	// Optional fields, provided in constructor as list of options
+	SetLabels = builder.SetLabels[*policyv1.PodDisruptionBudget]
+)
+
+func Build(owner metav1.Object, name string, matchLabels map[string]string, budget pdb.Spec, options ...builder.Option[*policyv1.PodDisruptionBudget]) (*policyv1.PodDisruptionBudget, error) {
+	neededOpts := []builder.Option[*policyv1.PodDisruptionBudget]{
+		setName(name),
+		setNamespace(owner.GetNamespace()),
+		setSelector(matchLabels),
+		setBudget(budget),
+	}
+	neededOpts = append(neededOpts, options...)
+
+	return builder.Build(owner, &policyv1.PodDisruptionBudget{}, neededOpts...)
+}
+
+func setSelector(matchLabels map[string]string) builder.Option[*policyv1.PodDisruptionBudget] {
+	return func(p *policyv1.PodDisruptionBudget) {
+		p.Spec.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+}
+
+func setBudget(budget pdb.Spec) builder.Option[*policyv1.PodDisruptionBudget] {
+	return func(p *policyv1.PodDisruptionBudget) {
+		p.Spec.MinAvailable = budget.MinAvailable
+		p.Spec.MaxUnavailable = budget.MaxUnavailable
+	}
+}
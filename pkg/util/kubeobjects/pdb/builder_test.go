@@ -0,0 +1,50 @@
+package pdb
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	testStatefulSetName = "statefulset-as-owner-of-pdb"
+	testPDBName         = "test-pdb-name"
+	testNamespace       = "test-namespace"
+)
+
+func createStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testStatefulSetName,
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func TestPDBBuilder(t *testing.T) {
+	matchLabels := map[string]string{"name": "value"}
+	minAvailable := intstr.FromString("50%")
+
+	t.Run("create pdb", func(t *testing.T) {
+		pdb, err := Build(createStatefulSet(), testPDBName, matchLabels, pdb.Spec{MinAvailable: &minAvailable})
+		require.NoError(t, err)
+		require.Len(t, pdb.OwnerReferences, 1)
+		assert.Equal(t, testStatefulSetName, pdb.OwnerReferences[0].Name)
+		assert.Equal(t, testPDBName, pdb.Name)
+		assert.Equal(t, testNamespace, pdb.Namespace)
+		assert.Equal(t, matchLabels, pdb.Spec.Selector.MatchLabels)
+		assert.Equal(t, &minAvailable, pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+		assert.Empty(t, pdb.Labels)
+	})
+	t.Run("create pdb with label", func(t *testing.T) {
+		pdb, err := Build(createStatefulSet(), testPDBName, matchLabels, pdb.Spec{}, SetLabels(matchLabels))
+		require.NoError(t, err)
+		assert.Equal(t, matchLabels, pdb.Labels)
+	})
+}
@@ -0,0 +1,38 @@
+package pdb
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/query"
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Query(kubeClient client.Client, kubeReader client.Reader, log logd.Logger) query.Generic[*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList] {
+	return query.Generic[*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList]{
+		Target:     &policyv1.PodDisruptionBudget{},
+		ListTarget: &policyv1.PodDisruptionBudgetList{},
+		ToList: func(pl *policyv1.PodDisruptionBudgetList) []*policyv1.PodDisruptionBudget {
+			out := []*policyv1.PodDisruptionBudget{}
+			for _, p := range pl.Items {
+				out = append(out, &p)
+			}
+
+			return out
+		},
+		IsEqual:      isEqual,
+		MustRecreate: mustRecreate,
+
+		KubeClient: kubeClient,
+		KubeReader: kubeReader,
+		Log:        log,
+	}
+}
+
+func isEqual(current, desired *policyv1.PodDisruptionBudget) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
+
+func mustRecreate(_, _ *policyv1.PodDisruptionBudget) bool {
+	return false
+}
@@ -0,0 +1,81 @@
+package pdb
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/builder"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/query"
+	"github.com/pkg/errors"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Owner is the custom resource the PodDisruptionBudgets are created for, the outcome is recorded in its conditions.
+type Owner interface {
+	client.Object
+	Conditions() *[]metav1.Condition
+}
+
+// Reconciler maintains the PodDisruptionBudgets of a custom resource, which are tracked by a single condition.
+type Reconciler struct {
+	owner         Owner
+	query         query.Generic[*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList]
+	conditionType string
+}
+
+func NewReconciler(clt client.Client, apiReader client.Reader, log logd.Logger, owner Owner, conditionType string) *Reconciler {
+	return &Reconciler{
+		owner:         owner,
+		query:         Query(clt, apiReader, log).WithOwner(owner),
+		conditionType: conditionType,
+	}
+}
+
+// Reconcile creates or updates the PodDisruptionBudget for the pods matching the labels, if a budget is set.
+// Without a budget, the PodDisruptionBudget is deleted again.
+func (r *Reconciler) Reconcile(ctx context.Context, name string, matchLabels map[string]string, budget *pdb.Spec, options ...builder.Option[*policyv1.PodDisruptionBudget]) error {
+	if budget == nil {
+		return r.Delete(ctx, name)
+	}
+
+	desired, err := Build(r.owner, name, matchLabels, *budget, options...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return r.CreateOrUpdate(ctx, desired)
+}
+
+// CreateOrUpdate applies the desired PodDisruptionBudget and records it in the condition.
+func (r *Reconciler) CreateOrUpdate(ctx context.Context, desired *policyv1.PodDisruptionBudget) error {
+	updated, err := r.query.CreateOrUpdate(ctx, desired)
+	if err != nil {
+		conditions.SetKubeAPIError(r.owner.Conditions(), r.conditionType, err)
+
+		return err
+	} else if updated {
+		conditions.SetPodDisruptionBudgetCreated(r.owner.Conditions(), r.conditionType, desired.Name)
+	}
+
+	return nil
+}
+
+// Delete removes the PodDisruptionBudget and the condition, the API isn't called if there is no condition, as nothing was created before.
+func (r *Reconciler) Delete(ctx context.Context, name string) error {
+	if meta.FindStatusCondition(*r.owner.Conditions(), r.conditionType) == nil {
+		return nil
+	}
+	defer meta.RemoveStatusCondition(r.owner.Conditions(), r.conditionType)
+
+	return r.query.Delete(ctx, &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: r.owner.GetNamespace(),
+		},
+	})
+}
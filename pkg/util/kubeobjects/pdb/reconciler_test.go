@@ -0,0 +1,99 @@
+package pdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const testConditionType = "TestPodDisruptionBudget"
+
+var pdbLog = logd.Get().WithName("test-pdb")
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+	matchLabels := map[string]string{"app": "test"}
+	key := client.ObjectKey{Name: testPDBName, Namespace: testNamespace}
+
+	newOwner := func() *dynakube.DynaKube {
+		return &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dynakube", Namespace: testNamespace}}
+	}
+
+	t.Run("budget => created, updated and recorded in the condition", func(t *testing.T) {
+		owner := newOwner()
+		fakeClient := fake.NewClient(owner)
+		reconciler := NewReconciler(fakeClient, fakeClient, pdbLog, owner, testConditionType)
+		maxUnavailable := intstr.FromInt32(1)
+
+		require.NoError(t, reconciler.Reconcile(ctx, testPDBName, matchLabels, &pdb.Spec{MaxUnavailable: &maxUnavailable}, SetLabels(matchLabels)))
+
+		var podDisruptionBudget policyv1.PodDisruptionBudget
+		require.NoError(t, fakeClient.Get(ctx, key, &podDisruptionBudget))
+		assert.Equal(t, matchLabels, podDisruptionBudget.Spec.Selector.MatchLabels)
+		assert.Equal(t, matchLabels, podDisruptionBudget.Labels)
+		assert.Equal(t, maxUnavailable, *podDisruptionBudget.Spec.MaxUnavailable)
+		require.Len(t, podDisruptionBudget.OwnerReferences, 1)
+
+		condition := meta.FindStatusCondition(owner.Status.Conditions, testConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.PodDisruptionBudgetCreatedReason, condition.Reason)
+
+		minAvailable := intstr.FromString("50%")
+		require.NoError(t, reconciler.Reconcile(ctx, testPDBName, matchLabels, &pdb.Spec{MinAvailable: &minAvailable}))
+
+		require.NoError(t, fakeClient.Get(ctx, key, &podDisruptionBudget))
+		assert.Equal(t, minAvailable, *podDisruptionBudget.Spec.MinAvailable)
+		assert.Nil(t, podDisruptionBudget.Spec.MaxUnavailable)
+	})
+	t.Run("no budget => deleted and condition removed", func(t *testing.T) {
+		owner := newOwner()
+		fakeClient := fake.NewClient(owner)
+		reconciler := NewReconciler(fakeClient, fakeClient, pdbLog, owner, testConditionType)
+
+		require.NoError(t, reconciler.Reconcile(ctx, testPDBName, matchLabels, &pdb.Spec{}))
+		require.NoError(t, reconciler.Reconcile(ctx, testPDBName, matchLabels, nil))
+
+		err := fakeClient.Get(ctx, key, &policyv1.PodDisruptionBudget{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(owner.Status.Conditions, testConditionType))
+	})
+	t.Run("no budget and no condition => nothing to delete", func(t *testing.T) {
+		owner := newOwner()
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Delete: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.DeleteOption) error {
+				return errors.New("unexpected delete")
+			},
+		})
+
+		require.NoError(t, NewReconciler(fakeClient, fakeClient, pdbLog, owner, testConditionType).Reconcile(ctx, testPDBName, matchLabels, nil))
+	})
+	t.Run("kube api error => recorded in the condition", func(t *testing.T) {
+		owner := newOwner()
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.CreateOption) error {
+				return errors.New("BOOM")
+			},
+		})
+
+		require.Error(t, NewReconciler(fakeClient, fakeClient, pdbLog, owner, testConditionType).Reconcile(ctx, testPDBName, matchLabels, &pdb.Spec{}))
+
+		condition := meta.FindStatusCondition(owner.Status.Conditions, testConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.KubeAPIErrorReason, condition.Reason)
+	})
+}
@@ -2,6 +2,8 @@ package pod
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -29,3 +31,42 @@ func GetName(pod corev1.Pod) string {
 
 	return pod.GenerateName
 }
+
+var fatalWaitingReasons = []string{
+	"CrashLoopBackOff",
+	"ImagePullBackOff",
+	"ErrImagePull",
+	"InvalidImageName",
+	"CreateContainerConfigError",
+}
+
+// IsReady checks the Ready condition of the pod.
+func IsReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// GetUnhealthyReason returns why the pod won't become ready on its own, like a crash loop or an image that can't be pulled.
+// It returns an empty string for healthy and still starting pods.
+func GetUnhealthyReason(pod corev1.Pod, maxRestarts int32) string {
+	if pod.Status.Phase == corev1.PodFailed {
+		return "pod failed"
+	}
+
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.State.Waiting != nil && slices.Contains(fatalWaitingReasons, container.State.Waiting.Reason) {
+			return container.State.Waiting.Reason
+		}
+
+		if maxRestarts > 0 && container.RestartCount >= maxRestarts {
+			return fmt.Sprintf("restarted %d times", container.RestartCount)
+		}
+	}
+
+	return ""
+}
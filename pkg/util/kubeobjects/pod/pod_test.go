@@ -60,3 +60,41 @@ func TestGetName(t *testing.T) {
 		assert.Equal(t, podGenerateName, got)
 	})
 }
+
+func TestIsReady(t *testing.T) {
+	t.Run("ready condition true => ready", func(t *testing.T) {
+		testPod := corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}}}
+		assert.True(t, IsReady(testPod))
+	})
+	t.Run("ready condition false => not ready", func(t *testing.T) {
+		testPod := corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}}}
+		assert.False(t, IsReady(testPod))
+	})
+	t.Run("no conditions => not ready", func(t *testing.T) {
+		assert.False(t, IsReady(corev1.Pod{}))
+	})
+}
+
+func TestGetUnhealthyReason(t *testing.T) {
+	t.Run("starting pod => healthy", func(t *testing.T) {
+		testPod := corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+		}}}}
+		assert.Empty(t, GetUnhealthyReason(testPod, 3))
+	})
+	t.Run("crash loop => unhealthy", func(t *testing.T) {
+		testPod := corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}}}
+		assert.Equal(t, "CrashLoopBackOff", GetUnhealthyReason(testPod, 3))
+	})
+	t.Run("too many restarts => unhealthy", func(t *testing.T) {
+		testPod := corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 3}}}}
+		assert.Equal(t, "restarted 3 times", GetUnhealthyReason(testPod, 3))
+		assert.Empty(t, GetUnhealthyReason(testPod, 0))
+	})
+	t.Run("failed pod => unhealthy", func(t *testing.T) {
+		testPod := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}}
+		assert.Equal(t, "pod failed", GetUnhealthyReason(testPod, 3))
+	})
+}
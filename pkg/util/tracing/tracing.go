@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// FlagEndpoint is the URL of the OTLP/HTTP endpoint traces are exported to, e.g. "http://otel-collector:4318".
+	FlagEndpoint = "otlp-traces-endpoint"
+
+	// FlagSampleRatio is the fraction of traces that are sampled, spans of sampled parents are always sampled.
+	FlagSampleRatio = "otlp-traces-sample-ratio"
+
+	// The standard OTel SDK environment variables, which enable tracing as well if set.
+	endpointEnv       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	tracesEndpointEnv = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	instrumentationName = "github.com/Dynatrace/dynatrace-operator"
+
+	defaultSampleRatio = 1.0
+)
+
+var (
+	log = logd.Get().WithName("tracing")
+
+	// tracer is only set once tracing is set up, so disabled tracing leaves contexts untouched.
+	tracer trace.Tracer
+)
+
+// Attribute keys of the spans created by the operator.
+const (
+	NamespaceKey   = semconv.K8SNamespaceNameKey
+	DynaKubeKey    = attribute.Key("dynatrace.dynakube.name")
+	EdgeConnectKey = attribute.Key("dynatrace.edgeconnect.name")
+	ReconcilerKey  = attribute.Key("dynatrace.reconciler")
+	SecretKey      = attribute.Key("k8s.secret.name")
+)
+
+// Config configures the export of traces, tracing is disabled if no endpoint is configured.
+type Config struct {
+	Endpoint    string
+	SampleRatio float64
+}
+
+// AddFlags registers the tracing flags, the endpoint defaults to the standard OTEL_EXPORTER_OTLP_(TRACES_)ENDPOINT env.
+func AddFlags(flags *pflag.FlagSet, config *Config) {
+	flags.StringVar(&config.Endpoint, FlagEndpoint, "", "OTLP/HTTP endpoint to export traces of the operator to, tracing is disabled if neither this nor "+tracesEndpointEnv+" is set.")
+	flags.Float64Var(&config.SampleRatio, FlagSampleRatio, defaultSampleRatio, "Fraction of traces to sample, between 0 and 1.")
+}
+
+func (config Config) enabled() bool {
+	return config.Endpoint != "" || os.Getenv(endpointEnv) != "" || os.Getenv(tracesEndpointEnv) != ""
+}
+
+// Setup installs a global tracer provider exporting spans of the given service via OTLP/HTTP.
+// Returns a function flushing pending spans, which has to be called before the process exits.
+// If tracing is not enabled, spans stay no-ops and nothing is exported.
+func Setup(ctx context.Context, serviceName string, config Config) (func(context.Context) error, error) {
+	if !config.enabled() {
+		log.Info("tracing disabled, no OTLP endpoint configured")
+
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	if config.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(config.Endpoint))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create OTLP trace exporter")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version.Version),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	tracer = provider.Tracer(instrumentationName)
+
+	log.Info("tracing enabled", "endpoint", config.Endpoint, "sampleRatio", config.SampleRatio)
+
+	return provider.Shutdown, nil
+}
+
+// SetRecorderOverride is a testing function, it enables tracing with a recorder of the ended spans instead of an exporter.
+func SetRecorderOverride(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(instrumentationName)
+
+	t.Cleanup(func() {
+		tracer = nil
+	})
+
+	return recorder
+}
+
+// Start creates a span as child of the span in ctx, the returned context carries the new span.
+// If tracing is disabled, ctx is returned as is together with a no-op span.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if tracer == nil {
+		return ctx, noop.Span{}
+	}
+
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends the span, marking it as failed if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// WrapConfig traces the requests of the Kubernetes clients created from the config as children of the span in their context.
+// Requests without a span in their context, like the ones of the informers, are not traced, so they don't start a trace each.
+func WrapConfig(config *rest.Config) {
+	config.Wrap(func(next http.RoundTripper) http.RoundTripper {
+		return &transport{next: next}
+	})
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (transport *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanFromContext(req.Context()).SpanContext().IsValid() {
+		return transport.next.RoundTrip(req)
+	}
+
+	_, span := Start(req.Context(), "HTTP "+req.Method,
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLPath(req.URL.Path),
+		semconv.ServerAddress(req.URL.Hostname()),
+	)
+
+	resp, err := transport.next.RoundTrip(req)
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+
+	End(span, err)
+
+	return resp, err
+}
@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+	"k8s.io/client-go/rest"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("disabled without endpoint", func(t *testing.T) {
+		t.Setenv(endpointEnv, "")
+		t.Setenv(tracesEndpointEnv, "")
+
+		shutdown, err := Setup(context.Background(), "test", Config{})
+		require.NoError(t, err)
+		require.NoError(t, shutdown(context.Background()))
+
+		assert.Nil(t, tracer)
+	})
+	t.Run("enabled by env", func(t *testing.T) {
+		t.Setenv(tracesEndpointEnv, "http://localhost:4318/v1/traces")
+
+		assert.True(t, Config{}.enabled())
+	})
+}
+
+func TestStart(t *testing.T) {
+	t.Run("disabled tracing leaves context untouched", func(t *testing.T) {
+		ctx := context.Background()
+
+		spanCtx, span := Start(ctx, "disabled")
+		End(span, errors.New("BOOM"))
+
+		assert.Equal(t, ctx, spanCtx)
+		assert.False(t, span.SpanContext().IsValid())
+	})
+	t.Run("records child spans and errors", func(t *testing.T) {
+		recorder := SetRecorderOverride(t)
+
+		ctx, parent := Start(context.Background(), "parent", DynaKubeKey.String("dynakube"))
+		_, child := Start(ctx, "child")
+		End(child, errors.New("BOOM"))
+		End(parent, nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "BOOM", spans[0].Status().Description)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+		assert.Equal(t, "parent", spans[1].Name())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+		assert.Contains(t, spans[1].Attributes(), DynaKubeKey.String("dynakube"))
+	})
+}
+
+func TestWrapConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	config := &rest.Config{}
+	WrapConfig(config)
+
+	httpClient := &http.Client{Transport: config.WrapTransport(http.DefaultTransport)}
+
+	send := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/namespaces/test/secrets/test", nil)
+		require.NoError(t, err)
+
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	t.Run("requests are traced as children of the span in their context", func(t *testing.T) {
+		recorder := SetRecorderOverride(t)
+
+		ctx, parent := Start(context.Background(), "parent")
+		send(ctx)
+		End(parent, nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		assert.Equal(t, "HTTP GET", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Contains(t, spans[0].Attributes(), semconv.URLPath("/api/v1/namespaces/test/secrets/test"))
+		assert.Contains(t, spans[0].Attributes(), semconv.HTTPResponseStatusCode(http.StatusNotFound))
+	})
+	t.Run("requests without a span in their context are not traced", func(t *testing.T) {
+		recorder := SetRecorderOverride(t)
+
+		send(context.Background())
+
+		assert.Empty(t, recorder.Ended())
+	})
+}
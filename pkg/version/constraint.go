@@ -0,0 +1,176 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const buildDateLayout = "20060102-150405"
+
+var (
+	conditionRegex = regexp.MustCompile(`^(=|!=|<=|>=|<|>|~|\^)?v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+-\d+))?$`)
+
+	// operatorSpacingRegex allows a space between the operator and the version, like "< 1.320"
+	operatorSpacingRegex = regexp.MustCompile(`(!=|<=|>=|=|<|>|~|\^)\s+`)
+)
+
+// Constraint restricts versions, like "~1.311", "<1.320" or ">=1.300, <1.320".
+// Conditions separated by commas or spaces all have to match, alternatives are separated by "||".
+// Versions given in the conditions may be partial, the missing parts match any value.
+type Constraint struct {
+	alternatives [][]condition
+}
+
+type condition struct {
+	operator  string
+	timestamp string
+	parts     []int
+}
+
+func ParseConstraint(expr string) (*Constraint, error) {
+	constraint := &Constraint{}
+
+	for _, alternative := range strings.Split(expr, "||") {
+		alternative = operatorSpacingRegex.ReplaceAllString(strings.TrimSpace(alternative), "$1")
+
+		fields := strings.FieldsFunc(alternative, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(fields) == 0 {
+			return nil, errors.Errorf("constraint '%s' contains an empty condition", expr)
+		}
+
+		conditions := make([]condition, 0, len(fields))
+
+		for _, field := range fields {
+			cond, err := parseCondition(field)
+			if err != nil {
+				return nil, err
+			}
+
+			conditions = append(conditions, cond)
+		}
+
+		constraint.alternatives = append(constraint.alternatives, conditions)
+	}
+
+	return constraint, nil
+}
+
+func parseCondition(field string) (condition, error) {
+	match := conditionRegex.FindStringSubmatch(field)
+	if match == nil {
+		return condition{}, errors.Errorf("invalid condition '%s'", field)
+	}
+
+	cond := condition{operator: match[1], timestamp: match[5]}
+
+	for _, part := range match[2:5] {
+		if part == "" {
+			break
+		}
+
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return condition{}, errors.Errorf("invalid condition '%s'", field)
+		}
+
+		cond.parts = append(cond.parts, value)
+	}
+
+	if cond.timestamp != "" && len(cond.parts) < 3 {
+		return condition{}, errors.Errorf("invalid condition '%s'", field)
+	}
+
+	return cond, nil
+}
+
+// Matches checks if the version satisfies all conditions of any of the alternatives.
+func (constraint *Constraint) Matches(version SemanticVersion) bool {
+	for _, conditions := range constraint.alternatives {
+		matchesAll := true
+
+		for _, cond := range conditions {
+			if !cond.matches(version) {
+				matchesAll = false
+
+				break
+			}
+		}
+
+		if matchesAll {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cond condition) matches(version SemanticVersion) bool {
+	comparison := cond.compare(version)
+
+	switch cond.operator {
+	case "", "=":
+		return comparison == 0
+	case "!=":
+		return comparison != 0
+	case "<":
+		return comparison < 0
+	case "<=":
+		return comparison <= 0
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	case "~":
+		// same minor version, or same major version if only the major version is given
+		return comparison >= 0 && cond.comparePrefix(version, min(2, len(cond.parts))) == 0
+	case "^":
+		return comparison >= 0 && cond.comparePrefix(version, 1) == 0
+	default:
+		return false
+	}
+}
+
+// compare compares the version to the one of the condition, considering only the parts given in the condition.
+func (cond condition) compare(version SemanticVersion) int {
+	if comparison := cond.comparePrefix(version, len(cond.parts)); comparison != 0 || cond.timestamp == "" {
+		return comparison
+	}
+
+	return strings.Compare(version.timestamp, cond.timestamp)
+}
+
+func (cond condition) comparePrefix(version SemanticVersion, length int) int {
+	actual := []int{version.major, version.minor, version.release}
+
+	for i := range length {
+		if actual[i] != cond.parts[i] {
+			return actual[i] - cond.parts[i]
+		}
+	}
+
+	return 0
+}
+
+func (version SemanticVersion) Major() int {
+	return version.major
+}
+
+func (version SemanticVersion) Minor() int {
+	return version.minor
+}
+
+// BuildDate parses the timestamp part of the version, which is set when the version is built.
+func (version SemanticVersion) BuildDate() (time.Time, error) {
+	buildDate, err := time.Parse(buildDateLayout, version.timestamp)
+	if err != nil {
+		return time.Time{}, errors.Errorf("version %s has no valid build date", version)
+	}
+
+	return buildDate, nil
+}
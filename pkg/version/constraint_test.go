@@ -0,0 +1,94 @@
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConstraint(t *testing.T) {
+	t.Run("valid constraints", func(t *testing.T) {
+		for _, expr := range []string{"1", "~1.311", "<1.320", "< 1.320", ">=1.300, <1.320", "^1.300.5", "1.311.70.20250415-123456", "~1.310 || ~1.312", "v1.311"} {
+			_, err := ParseConstraint(expr)
+			assert.NoError(t, err, expr)
+		}
+	})
+	t.Run("invalid constraints", func(t *testing.T) {
+		for _, expr := range []string{"", "latest", "~1.x", "=>1.300", "1.300 ||", "1.300.20250415-123456", "1.2.3.4.5"} {
+			_, err := ParseConstraint(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+}
+
+func TestConstraintMatches(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		version    string
+		matches    bool
+	}{
+		{"~1.311", "1.311.0.20250101-000000", true},
+		{"~1.311", "1.311.70.20250415-123456", true},
+		{"~1.311", "1.312.0.20250101-000000", false},
+		{"~1.311", "1.310.90.20250101-000000", false},
+		{"~1.311.70", "1.311.69.20250101-000000", false},
+		{"~1.311.70", "1.311.80.20250101-000000", true},
+		{"~1", "1.400.0.20250101-000000", true},
+		{"^1.300", "1.400.0.20250101-000000", true},
+		{"^1.300", "2.0.0.20250101-000000", false},
+		{"<1.320", "1.319.99.20250101-000000", true},
+		{"<1.320", "1.320.0.20250101-000000", false},
+		{"<=1.320", "1.320.99.20250101-000000", true},
+		{">1.320", "1.320.99.20250101-000000", false},
+		{">1.320", "1.321.0.20250101-000000", true},
+		{">=1.300, <1.320", "1.310.0.20250101-000000", true},
+		{">=1.300 <1.320", "1.320.0.20250101-000000", false},
+		{"!=1.315", "1.315.2.20250101-000000", false},
+		{"!=1.315", "1.316.2.20250101-000000", true},
+		{"1.311", "1.311.2.20250101-000000", true},
+		{"1.311.70.20250415-123456", "1.311.70.20250415-123456", true},
+		{"1.311.70.20250415-123456", "1.311.70.20250416-000000", false},
+		{"~1.310 || ~1.312", "1.311.0.20250101-000000", false},
+		{"~1.310 || ~1.312", "1.312.0.20250101-000000", true},
+	}
+
+	for _, testCase := range testCases {
+		constraint, err := ParseConstraint(testCase.constraint)
+		require.NoError(t, err)
+
+		version, err := ExtractSemanticVersion(testCase.version)
+		require.NoError(t, err)
+
+		assert.Equal(t, testCase.matches, constraint.Matches(version), "%s matches %s", testCase.constraint, testCase.version)
+	}
+}
+
+func TestBuildDate(t *testing.T) {
+	version, err := ExtractSemanticVersion("1.311.70.20250415-123456")
+	require.NoError(t, err)
+
+	buildDate, err := version.BuildDate()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 4, 15, 12, 34, 56, 0, time.UTC), buildDate)
+
+	version, err = ExtractSemanticVersion("1.311.70.1-2")
+	require.NoError(t, err)
+
+	_, err = version.BuildDate()
+	require.Error(t, err)
+}
+
+func TestExtractPartialSemanticVersion(t *testing.T) {
+	version, err := ExtractPartialSemanticVersion("1.311.70")
+	require.NoError(t, err)
+	assert.Equal(t, "1.311.70.", version.String())
+
+	version, err = ExtractPartialSemanticVersion("1.311.70.20250415-123456")
+	require.NoError(t, err)
+	assert.Equal(t, "1.311.70.20250415-123456", version.String())
+
+	_, err = ExtractPartialSemanticVersion("1.311")
+	require.Error(t, err)
+}
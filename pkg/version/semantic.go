@@ -16,7 +16,12 @@ type SemanticVersion struct {
 	release   int
 }
 
-var versionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)\.(\d+-\d+)$`)
+var (
+	versionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)\.(\d+-\d+)$`)
+
+	// partialVersionRegex also matches versions without the build timestamp, like image tags
+	partialVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:\.(\d+-\d+))?$`)
+)
 
 // Max sub match = orignal string + 4 groups from versionRegex ^.
 const maxStringSubMatch = 5
@@ -44,7 +49,16 @@ func CompareSemanticVersions(a SemanticVersion, b SemanticVersion) int {
 }
 
 func ExtractSemanticVersion(versionString string) (SemanticVersion, error) {
-	version := versionRegex.FindStringSubmatch(versionString)
+	return extractSemanticVersion(versionRegex, versionString)
+}
+
+// ExtractPartialSemanticVersion is like ExtractSemanticVersion, but the build timestamp is optional.
+func ExtractPartialSemanticVersion(versionString string) (SemanticVersion, error) {
+	return extractSemanticVersion(partialVersionRegex, versionString)
+}
+
+func extractSemanticVersion(regex *regexp.Regexp, versionString string) (SemanticVersion, error) {
+	version := regex.FindStringSubmatch(versionString)
 
 	if len(version) < maxStringSubMatch {
 		return SemanticVersion{}, errors.Errorf("version malformed: %s", versionString)
@@ -11,6 +11,9 @@ const (
 	// AnnotationDynatraceInjected is set to "true" by the webhook to Pods to indicate that it has been injected.
 	AnnotationDynatraceInjected = "dynakube.dynatrace.com/injected"
 
+	// LabelDynatraceInjected is set to "true" by the webhook to Pods to indicate that it has been injected, so they can be selected by NetworkPolicies.
+	LabelDynatraceInjected = "dynakube.dynatrace.com/injected"
+
 	// AnnotationDynatraceReason is add to provide extra info why an injection didn't happen.
 	AnnotationDynatraceReason = "dynakube.dynatrace.com/reason"
 
@@ -27,6 +30,9 @@ const (
 
 	WebhookContainerName = "webhook"
 
+	// ServerPortName is the name of the container port the webhook server listens on.
+	ServerPortName = "server-port"
+
 	// InstallContainerName is the name used for the install container
 	InstallContainerName = "dynatrace-operator"
 )
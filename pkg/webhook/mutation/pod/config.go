@@ -2,8 +2,21 @@ package pod
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
 	log = logd.Get().WithName("pod-mutation")
+
+	podInjectionsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "webhook",
+		Name:      "pod_injections_total",
+		Help:      "Number of pods handled by the pod mutating webhook, by result (injected, skipped, failed) and reason",
+	}, []string{"namespace", "dynakube", "flow", "result", "reason"})
 )
+
+func init() {
+	metrics.Registry.MustRegister(podInjectionsMetric)
+}
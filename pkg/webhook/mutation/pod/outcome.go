@@ -0,0 +1,93 @@
+package pod
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// InjectionReason is a stable code telling why a pod was injected or skipped, or why its injection failed.
+// It is used as label of the injection metrics, so it must not contain variable parts like names.
+type InjectionReason string
+
+const (
+	ReasonInjected             InjectionReason = "Injected"
+	ReasonReinvoked            InjectionReason = "Reinvoked"
+	ReasonInvalidPod           InjectionReason = "InvalidPod"
+	ReasonNamespaceUnavailable InjectionReason = "NamespaceUnavailable"
+	ReasonNoDynaKube           InjectionReason = "NoDynaKube"
+	ReasonDynaKubeUnavailable  InjectionReason = "DynaKubeUnavailable"
+	ReasonInjectionDisabled    InjectionReason = "InjectionDisabled"
+	ReasonOpenShiftDebugPod    InjectionReason = "OpenShiftDebugPod"
+	ReasonInjectorFailed       InjectionReason = "InjectorFailed"
+	ReasonInvalidPatch         InjectionReason = "InvalidPatch"
+)
+
+// InjectionResult is the result of handling a pod by the webhook.
+type InjectionResult string
+
+const (
+	ResultInjected InjectionResult = "injected"
+	ResultSkipped  InjectionResult = "skipped"
+	ResultFailed   InjectionResult = "failed"
+)
+
+// Injection flows, v2 is the node-image-pull flow using the bootstrapper.
+const (
+	flowV1 = "v1"
+	flowV2 = "v2"
+)
+
+// Attribute keys of the admission spans.
+const (
+	injectionFlowKey   = attribute.Key("dynatrace.injection.flow")
+	injectionResultKey = attribute.Key("dynatrace.injection.result")
+	injectionReasonKey = attribute.Key("dynatrace.injection.reason")
+)
+
+// injectionOutcome describes how a single admission request was handled, for the injection metrics.
+// dynakube and flow stay empty if the request was rejected before they were known.
+type injectionOutcome struct {
+	result   InjectionResult
+	reason   InjectionReason
+	dynakube string
+	flow     string
+}
+
+func injected(reason InjectionReason) injectionOutcome {
+	return injectionOutcome{result: ResultInjected, reason: reason}
+}
+
+func skipped(reason InjectionReason) injectionOutcome {
+	return injectionOutcome{result: ResultSkipped, reason: reason}
+}
+
+func failed(reason InjectionReason) injectionOutcome {
+	return injectionOutcome{result: ResultFailed, reason: reason}
+}
+
+func (outcome injectionOutcome) withDynakube(dynakube, flow string) injectionOutcome {
+	outcome.dynakube = dynakube
+	outcome.flow = flow
+
+	return outcome
+}
+
+func (outcome injectionOutcome) record(namespace string) {
+	podInjectionsMetric.With(prometheus.Labels{
+		"namespace": namespace,
+		"dynakube":  outcome.dynakube,
+		"flow":      outcome.flow,
+		"result":    string(outcome.result),
+		"reason":    string(outcome.reason),
+	}).Inc()
+}
+
+func (outcome injectionOutcome) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		tracing.DynaKubeKey.String(outcome.dynakube),
+		injectionFlowKey.String(outcome.flow),
+		injectionResultKey.String(string(outcome.result)),
+		injectionReasonKey.String(string(outcome.reason)),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/lastapplied"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -12,36 +13,37 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-func (wh *webhook) createMutationRequestBase(ctx context.Context, request admission.Request) (*dtwebhook.MutationRequest, error) {
+// createMutationRequestBase returns nil if the pod does not need to be mutated, the returned reason tells why the request could not be created.
+func (wh *webhook) createMutationRequestBase(ctx context.Context, request admission.Request) (*dtwebhook.MutationRequest, InjectionReason, error) {
 	pod, err := getPodFromRequest(request, wh.decoder)
 	if err != nil {
-		return nil, err
+		return nil, ReasonInvalidPod, err
 	}
 
 	namespace, err := getNamespaceFromRequest(ctx, wh.apiReader, request)
 	if err != nil {
-		return nil, err
+		return nil, ReasonNamespaceUnavailable, err
 	}
 
 	dynakubeName, err := getDynakubeName(*namespace)
 	if err != nil && !wh.deployedViaOLM {
-		return nil, err
+		return nil, ReasonNoDynaKube, err
 	} else if err != nil {
 		// in case of olm deployment, all pods are sent to us
 		// but not all of them need to be mutated,
 		// therefore their namespace might not have a dynakube assigned
 		// in which case we don't need to do anything
-		return nil, nil //nolint
+		return nil, ReasonNoDynaKube, nil
 	}
 
 	dynakube, err := wh.getDynakube(ctx, dynakubeName)
 	if err != nil {
-		return nil, err
+		return nil, ReasonDynaKubeUnavailable, err
 	}
 
 	mutationRequest := dtwebhook.NewMutationRequest(ctx, *namespace, nil, pod, *dynakube)
 
-	return mutationRequest, nil
+	return mutationRequest, "", nil
 }
 
 func getPodFromRequest(req admission.Request, decoder admission.Decoder) (*corev1.Pod, error) {
@@ -90,5 +92,12 @@ func (wh *webhook) getDynakube(ctx context.Context, dynakubeName string) (*dynak
 		return nil, err
 	}
 
+	if dk.IsPaused() {
+		// the spec may have been edited since the pause, the components were deployed with the one that was reconciled last
+		if err := lastapplied.Restore(ctx, wh.apiReader, &dk); err != nil {
+			return nil, err
+		}
+	}
+
 	return &dk, nil
 }
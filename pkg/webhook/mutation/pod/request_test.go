@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/lastapplied"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	webhookmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/webhook"
 	"github.com/stretchr/testify/assert"
@@ -39,7 +42,7 @@ func TestCreateMutationRequestBase(t *testing.T) {
 				getTestPod(),
 				dk,
 			})
-		mutationRequest, err := podWebhook.createMutationRequestBase(context.Background(), *createTestAdmissionRequest(getTestPod()))
+		mutationRequest, _, err := podWebhook.createMutationRequestBase(context.Background(), *createTestAdmissionRequest(getTestPod()))
 		require.NoError(t, err)
 		require.NotNil(t, mutationRequest)
 
@@ -105,6 +108,46 @@ func TestGetDynakube(t *testing.T) {
 		assert.Equal(t, expected.ObjectMeta, dynakube.ObjectMeta)
 		assert.Equal(t, expected.Spec.OneAgent.CloudNativeFullStack, dynakube.Spec.OneAgent.CloudNativeFullStack)
 	})
+	t.Run("paused => spec edited during the pause is ignored", func(t *testing.T) {
+		ctx := context.Background()
+		reconciled := getTestDynakube()
+		fakeClient := fake.NewClient(reconciled)
+		require.NoError(t, lastapplied.Update(ctx, fakeClient, fakeClient, reconciled))
+
+		edited := getTestDynakubeDefaultAppMon()
+		edited.ResourceVersion = reconciled.ResourceVersion
+		edited.Annotations = map[string]string{api.AnnotationPaused: "true"}
+		require.NoError(t, fakeClient.Update(ctx, edited))
+
+		podWebhook := createTestWebhook(webhookmock.NewPodInjector(t), webhookmock.NewPodInjector(t), nil)
+		podWebhook.apiReader = fakeClient
+
+		dynakube, err := podWebhook.getDynakube(ctx, testDynakubeName)
+		require.NoError(t, err)
+		assert.True(t, dynakube.IsPaused())
+		assert.NotNil(t, dynakube.Spec.OneAgent.CloudNativeFullStack)
+		assert.Nil(t, dynakube.Spec.OneAgent.ApplicationMonitoring)
+
+		edited.Annotations = nil
+		require.NoError(t, fakeClient.Update(ctx, edited))
+
+		dynakube, err = podWebhook.getDynakube(ctx, testDynakubeName)
+		require.NoError(t, err)
+		assert.NotNil(t, dynakube.Spec.OneAgent.ApplicationMonitoring)
+	})
+	t.Run("paused without reconciled spec => current spec", func(t *testing.T) {
+		expected := getTestDynakube()
+		expected.Annotations = map[string]string{api.AnnotationPaused: "true"}
+		podWebhook := createTestWebhook(
+			webhookmock.NewPodInjector(t),
+			webhookmock.NewPodInjector(t),
+			[]client.Object{expected},
+		)
+
+		dynakube, err := podWebhook.getDynakube(context.Background(), testDynakubeName)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Spec.OneAgent.CloudNativeFullStack, dynakube.Spec.OneAgent.CloudNativeFullStack)
+	})
 }
 
 func createTestMutationRequest(dk *dynakube.DynaKube) *dtwebhook.MutationRequest {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	dtingestendpoint "github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/ingestendpoint"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	metacommon "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/metadata"
 	corev1 "k8s.io/api/core/v1"
@@ -39,12 +40,22 @@ func (mut *Mutator) Injected(request *dtwebhook.BaseRequest) bool {
 func (mut *Mutator) Mutate(ctx context.Context, request *dtwebhook.MutationRequest) error {
 	log.Info("injecting metadata-enrichment into pod", "podName", request.PodName())
 
-	workload, err := metacommon.RetrieveWorkload(mut.metaClient, request)
+	var workload *metacommon.WorkloadInfo
+
+	err := request.Trace(ctx, "webhook.pod.retrieveWorkload", func() error {
+		var err error
+
+		workload, err = metacommon.RetrieveWorkload(mut.metaClient, request)
+
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	err = mut.ensureIngestEndpointSecret(request)
+	err = request.Trace(ctx, "webhook.pod.ensureIngestEndpointSecret", func() error {
+		return mut.ensureIngestEndpointSecret(request)
+	}, tracing.SecretKey.String(consts.EnrichmentEndpointSecretName))
 	if err != nil {
 		return err
 	}
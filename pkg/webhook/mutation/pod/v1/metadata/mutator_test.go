@@ -12,6 +12,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	metacommon "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/metadata"
 	"github.com/stretchr/testify/assert"
@@ -148,6 +149,25 @@ func TestMutate(t *testing.T) {
 		assert.Len(t, request.InstallContainer.Env, 6)
 		assert.Len(t, request.InstallContainer.VolumeMounts, 1)
 	})
+	t.Run("should trace the workload lookup and the ingest endpoint secret as child spans", func(t *testing.T) {
+		recorder := tracing.SetRecorderOverride(t)
+
+		mutator := createTestPodMutator([]client.Object{getTestInitSecret()})
+		request := createTestMutationRequest(getTestDynakube(), nil, false)
+
+		ctx, parent := tracing.Start(context.Background(), "webhook.pod.inject")
+		err := mutator.Mutate(ctx, request)
+		tracing.End(parent, err)
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 3)
+		assert.Equal(t, "webhook.pod.retrieveWorkload", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, "webhook.pod.ensureIngestEndpointSecret", spans[1].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent().SpanID())
+		assert.Contains(t, spans[1].Attributes(), tracing.SecretKey.String(consts.EnrichmentEndpointSecretName))
+	})
 }
 
 func TestReinvoke(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/initgeneration"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/mounts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	oacommon "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/oneagent"
 	"github.com/pkg/errors"
@@ -51,7 +52,10 @@ func (mut *Mutator) Mutate(ctx context.Context, request *dtwebhook.MutationReque
 
 	log.Info("injecting OneAgent into pod", "podName", request.PodName())
 
-	if err := mut.ensureInitSecret(request); err != nil {
+	err := request.Trace(ctx, "webhook.pod.ensureInitSecret", func() error {
+		return mut.ensureInitSecret(request)
+	}, tracing.SecretKey.String(consts.AgentInitSecretName))
+	if err != nil {
 		return err
 	}
 
@@ -63,6 +63,7 @@ func (wh *Injector) Handle(ctx context.Context, mutationRequest *dtwebhook.Mutat
 	}
 
 	setDynatraceInjectedAnnotation(mutationRequest)
+	setDynatraceInjectedLabel(mutationRequest)
 
 	log.Info("injection finished for pod", "podName", mutationRequest.PodName(), "namespace", mutationRequest.Namespace.Name)
 
@@ -146,3 +147,11 @@ func setDynatraceInjectedAnnotation(mutationRequest *dtwebhook.MutationRequest)
 
 	mutationRequest.Pod.Annotations[dtwebhook.AnnotationDynatraceInjected] = "true"
 }
+
+func setDynatraceInjectedLabel(mutationRequest *dtwebhook.MutationRequest) {
+	if mutationRequest.Pod.Labels == nil {
+		mutationRequest.Pod.Labels = make(map[string]string)
+	}
+
+	mutationRequest.Pod.Labels[dtwebhook.LabelDynatraceInjected] = "true"
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/container"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	maputils "github.com/Dynatrace/dynatrace-operator/pkg/util/map"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/events"
 	oacommon "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/oneagent"
@@ -52,15 +53,15 @@ func NewInjector(kubeClient client.Client, apiReader client.Reader, metaClient c
 	}
 }
 
-func (wh *Injector) Handle(_ context.Context, mutationRequest *dtwebhook.MutationRequest) error {
+func (wh *Injector) Handle(ctx context.Context, mutationRequest *dtwebhook.MutationRequest) error {
 	wh.recorder.Setup(mutationRequest)
 
-	if !wh.isInputSecretPresent(mutationRequest, bootstrapperconfig.GetSourceConfigSecretName(mutationRequest.DynaKube.Name), consts.BootstrapperInitSecretName) {
+	if !wh.isInputSecretPresent(ctx, mutationRequest, bootstrapperconfig.GetSourceConfigSecretName(mutationRequest.DynaKube.Name), consts.BootstrapperInitSecretName) {
 		return nil
 	}
 
 	if mutationRequest.DynaKube.IsAGCertificateNeeded() || mutationRequest.DynaKube.Spec.TrustedCAs != "" {
-		if !wh.isInputSecretPresent(mutationRequest, bootstrapperconfig.GetSourceCertsSecretName(mutationRequest.DynaKube.Name), consts.BootstrapperInitCertsSecretName) {
+		if !wh.isInputSecretPresent(ctx, mutationRequest, bootstrapperconfig.GetSourceCertsSecretName(mutationRequest.DynaKube.Name), consts.BootstrapperInitCertsSecretName) {
 			return nil
 		}
 	}
@@ -79,12 +80,13 @@ func (wh *Injector) Handle(_ context.Context, mutationRequest *dtwebhook.Mutatio
 
 		log.Info("no change, all containers already injected", "podName", mutationRequest.PodName())
 	} else {
-		if err := wh.handlePodMutation(mutationRequest); err != nil {
+		if err := wh.handlePodMutation(ctx, mutationRequest); err != nil {
 			return err
 		}
 	}
 
 	setDynatraceInjectedAnnotation(mutationRequest)
+	setDynatraceInjectedLabel(mutationRequest)
 
 	log.Info("injection finished for pod", "podName", mutationRequest.PodName(), "namespace", mutationRequest.Namespace.Name)
 
@@ -102,10 +104,12 @@ func (wh *Injector) isInjected(mutationRequest *dtwebhook.MutationRequest) bool
 	return false
 }
 
-func (wh *Injector) handlePodMutation(mutationRequest *dtwebhook.MutationRequest) error {
+func (wh *Injector) handlePodMutation(ctx context.Context, mutationRequest *dtwebhook.MutationRequest) error {
 	mutationRequest.InstallContainer = createInitContainerBase(mutationRequest.Pod, mutationRequest.DynaKube, wh.isOpenShift)
 
-	err := addContainerAttributes(mutationRequest)
+	err := mutationRequest.Trace(ctx, "webhook.pod.addContainerAttributes", func() error {
+		return addContainerAttributes(mutationRequest)
+	})
 	if err != nil {
 		return err
 	}
@@ -117,7 +121,9 @@ func (wh *Injector) handlePodMutation(mutationRequest *dtwebhook.MutationRequest
 		return nil
 	}
 
-	err = wh.addPodAttributes(mutationRequest)
+	err = mutationRequest.Trace(ctx, "webhook.pod.addPodAttributes", func() error {
+		return wh.addPodAttributes(mutationRequest)
+	})
 	if err != nil {
 		log.Info("failed to add pod attributes to init-container")
 
@@ -158,8 +164,10 @@ func isCustomImageSet(mutationRequest *dtwebhook.MutationRequest) bool {
 	return true
 }
 
-func (wh *Injector) isInputSecretPresent(mutationRequest *dtwebhook.MutationRequest, sourceSecretName, targetSecretName string) bool {
-	err := wh.replicateSecret(mutationRequest, sourceSecretName, targetSecretName)
+func (wh *Injector) isInputSecretPresent(ctx context.Context, mutationRequest *dtwebhook.MutationRequest, sourceSecretName, targetSecretName string) bool {
+	err := mutationRequest.Trace(ctx, "webhook.pod.replicateSecret", func() error {
+		return wh.replicateSecret(mutationRequest, sourceSecretName, targetSecretName)
+	}, tracing.SecretKey.String(targetSecretName))
 
 	if k8serrors.IsNotFound(err) {
 		log.Info(fmt.Sprintf("unable to copy source of %s as it is not available, injection not possible", sourceSecretName), "pod", mutationRequest.PodName())
@@ -203,3 +211,11 @@ func setDynatraceInjectedAnnotation(mutationRequest *dtwebhook.MutationRequest)
 	mutationRequest.Pod.Annotations[dtwebhook.AnnotationDynatraceInjected] = "true"
 	delete(mutationRequest.Pod.Annotations, dtwebhook.AnnotationDynatraceReason)
 }
+
+func setDynatraceInjectedLabel(mutationRequest *dtwebhook.MutationRequest) {
+	if mutationRequest.Pod.Labels == nil {
+		mutationRequest.Pod.Labels = make(map[string]string)
+	}
+
+	mutationRequest.Pod.Labels[dtwebhook.LabelDynatraceInjected] = "true"
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/bootstrapperconfig"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/container"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/events"
 	oacommon "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/oneagent"
@@ -195,6 +196,48 @@ func TestHandle(t *testing.T) {
 		require.False(t, ok)
 	})
 
+	t.Run("secret replication and attributes are traced as child spans", func(t *testing.T) {
+		recorder := tracing.SetRecorderOverride(t)
+
+		injector := createTestInjectorBase()
+		request := createTestMutationRequest(getTestDynakubeWithAGCerts())
+
+		source := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      bootstrapperconfig.GetSourceConfigSecretName(request.DynaKube.Name),
+				Namespace: request.DynaKube.Namespace,
+			},
+		}
+		sourceCerts := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      bootstrapperconfig.GetSourceCertsSecretName(request.DynaKube.Name),
+				Namespace: request.DynaKube.Namespace,
+			},
+		}
+		clt := fake.NewClient(&source, &sourceCerts)
+		injector.kubeClient = clt
+		injector.apiReader = clt
+
+		injectCtx, parent := tracing.Start(ctx, "webhook.pod.inject")
+		request.Context = injectCtx
+		err := injector.Handle(injectCtx, request)
+		tracing.End(parent, err)
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 5)
+
+		expectedNames := []string{"webhook.pod.replicateSecret", "webhook.pod.replicateSecret", "webhook.pod.addContainerAttributes", "webhook.pod.addPodAttributes"}
+		for i, name := range expectedNames {
+			assert.Equal(t, name, spans[i].Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), spans[i].Parent().SpanID())
+		}
+
+		assert.Contains(t, spans[0].Attributes(), tracing.SecretKey.String(consts.BootstrapperInitSecretName))
+		assert.Contains(t, spans[1].Attributes(), tracing.SecretKey.String(consts.BootstrapperInitCertsSecretName))
+		assert.Equal(t, injectCtx, request.Context)
+	})
+
 	t.Run("no init and no certs, but don't replicate certs because we don't need it (AG is not enabled)", func(t *testing.T) {
 		injector := createTestInjectorBase()
 		request := createTestMutationRequest(getTestDynakube())
@@ -518,3 +561,23 @@ func TestSetDynatraceInjectedAnnotation(t *testing.T) {
 		assert.Equal(t, "true", request.Pod.Annotations[dtwebhook.AnnotationDynatraceInjected])
 	})
 }
+
+func TestSetDynatraceInjectedLabel(t *testing.T) {
+	t.Run("add label and keep existing labels", func(t *testing.T) {
+		request := dtwebhook.MutationRequest{
+			BaseRequest: &dtwebhook.BaseRequest{
+				Pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "test"},
+					},
+				},
+			},
+		}
+
+		setDynatraceInjectedLabel(&request)
+
+		require.Len(t, request.Pod.Labels, 2)
+		assert.Equal(t, "true", request.Pod.Labels[dtwebhook.LabelDynatraceInjected])
+		assert.Equal(t, "test", request.Pod.Labels["app"])
+	})
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/container"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
 	k8spod "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
 	maputils "github.com/Dynatrace/dynatrace-operator/pkg/util/map"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/events"
+	oacommon "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/oneagent"
 	podv2 "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/v2"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,45 +55,85 @@ type webhook struct {
 }
 
 func (wh *webhook) Handle(ctx context.Context, request admission.Request) admission.Response {
+	ctx, span := tracing.Start(ctx, "webhook.pod.admission", tracing.NamespaceKey.String(request.Namespace))
+
+	response, outcome := wh.handle(ctx, request)
+	outcome.record(request.Namespace)
+
+	span.SetAttributes(outcome.attributes()...)
+	span.End()
+
+	return response
+}
+
+func (wh *webhook) handle(ctx context.Context, request admission.Request) (admission.Response, injectionOutcome) {
 	emptyPatch := admission.Patched("")
-	mutationRequest, err := wh.createMutationRequestBase(ctx, request)
+	mutationRequest, reason, err := wh.createMutationRequestBase(ctx, request)
 
 	if err != nil {
 		emptyPatch.Result.Message = fmt.Sprintf("unable to inject into pod (err=%s)", err.Error())
 		log.Error(err, "building mutation request base encountered an error")
 
-		return emptyPatch
+		return emptyPatch, failed(reason)
 	}
 
 	if mutationRequest == nil {
 		emptyPatch.Result.Message = "injection into pod not required"
 
-		return emptyPatch
+		return emptyPatch, skipped(reason)
 	}
 
 	podName := mutationRequest.PodName()
 
-	if !mutationRequired(mutationRequest) || wh.isOcDebugPod(mutationRequest.Pod) {
-		return emptyPatch
+	injector, flow := wh.v1, flowV1
+	if podv2.IsEnabled(mutationRequest) {
+		injector, flow = wh.v2, flowV2
+	}
+
+	dynakubeName := mutationRequest.DynaKube.Name
+
+	if !mutationRequired(mutationRequest) {
+		return emptyPatch, skipped(ReasonInjectionDisabled).withDynakube(dynakubeName, flow)
+	}
+
+	if wh.isOcDebugPod(mutationRequest.Pod) {
+		return emptyPatch, skipped(ReasonOpenShiftDebugPod).withDynakube(dynakubeName, flow)
 	}
 
 	wh.recorder.Setup(mutationRequest)
 
-	if podv2.IsEnabled(mutationRequest) {
-		err := wh.v2.Handle(ctx, mutationRequest)
-		if err != nil {
-			return silentErrorResponse(mutationRequest.Pod, err)
-		}
-	} else {
-		err := wh.v1.Handle(ctx, mutationRequest)
-		if err != nil {
-			return silentErrorResponse(mutationRequest.Pod, err)
-		}
+	alreadyInjected := container.FindInitContainerInPodSpec(&mutationRequest.Pod.Spec, dtwebhook.InstallContainerName) != nil
+
+	injectCtx, span := tracing.Start(ctx, "webhook.pod.inject", tracing.DynaKubeKey.String(dynakubeName), injectionFlowKey.String(flow))
+	mutationRequest.Context = injectCtx
+	err = injector.Handle(injectCtx, mutationRequest)
+	tracing.End(span, err)
+
+	if err != nil {
+		return silentErrorResponse(mutationRequest.Pod, err), failed(ReasonInjectorFailed).withDynakube(dynakubeName, flow)
 	}
 
 	log.Info("injection finished for pod", "podName", podName, "namespace", request.Namespace)
 
-	return createResponseForPod(mutationRequest.Pod, request)
+	response, err := createResponseForPod(mutationRequest.Pod, request)
+	if err != nil {
+		return silentErrorResponse(mutationRequest.Pod, err), failed(ReasonInvalidPatch).withDynakube(dynakubeName, flow)
+	}
+
+	return response, injectionOutcomeOf(mutationRequest.Pod, alreadyInjected).withDynakube(dynakubeName, flow)
+}
+
+// injectionOutcomeOf tells whether the injector instrumented the pod, injectors skipping the pod leave the reason in its annotations.
+func injectionOutcomeOf(pod *corev1.Pod, alreadyInjected bool) injectionOutcome {
+	if pod.Annotations[oacommon.AnnotationInjected] == "false" {
+		return skipped(InjectionReason(pod.Annotations[oacommon.AnnotationReason]))
+	}
+
+	if alreadyInjected {
+		return injected(ReasonReinvoked)
+	}
+
+	return injected(ReasonInjected)
 }
 
 func mutationRequired(mutationRequest *dtwebhook.MutationRequest) bool {
@@ -121,13 +164,13 @@ func (wh *webhook) isOcDebugPod(pod *corev1.Pod) bool {
 }
 
 // createResponseForPod tries to format pod as json
-func createResponseForPod(pod *corev1.Pod, req admission.Request) admission.Response {
+func createResponseForPod(pod *corev1.Pod, req admission.Request) (admission.Response, error) {
 	marshaledPod, err := json.MarshalIndent(pod, "", "  ")
 	if err != nil {
-		return silentErrorResponse(pod, err)
+		return admission.Response{}, err
 	}
 
-	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod), nil
 }
 
 func silentErrorResponse(pod *corev1.Pod, err error) admission.Response {
@@ -13,7 +13,10 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/events"
+	oacommon "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/common/oneagent"
 	webhookmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -310,6 +313,91 @@ func TestHandle(t *testing.T) {
 	})
 }
 
+func TestHandleOutcome(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing namespace ==> failed", func(t *testing.T) {
+		wh := createTestWebhook(webhookmock.NewPodInjector(t), webhookmock.NewPodInjector(t), []client.Object{})
+
+		_, outcome := wh.handle(ctx, *createTestAdmissionRequest(getTestPod()))
+		assert.Equal(t, failed(ReasonNamespaceUnavailable), outcome)
+	})
+
+	t.Run("DK name missing from NS but OLM ==> skipped", func(t *testing.T) {
+		ns := getTestNamespace()
+		ns.Labels = map[string]string{}
+		wh := createTestWebhook(webhookmock.NewPodInjector(t), webhookmock.NewPodInjector(t), []client.Object{ns})
+		wh.deployedViaOLM = true
+
+		_, outcome := wh.handle(ctx, *createTestAdmissionRequest(getTestPod()))
+		assert.Equal(t, skipped(ReasonNoDynaKube), outcome)
+	})
+
+	t.Run("no inject annotation ==> skipped with dynakube", func(t *testing.T) {
+		wh := createTestWebhook(webhookmock.NewPodInjector(t), webhookmock.NewPodInjector(t), []client.Object{getTestNamespace(), getTestDynakube()})
+
+		_, outcome := wh.handle(ctx, *createTestAdmissionRequest(getTestPodWithInjectionDisabled()))
+		assert.Equal(t, skipped(ReasonInjectionDisabled).withDynakube(testDynakubeName, flowV1), outcome)
+	})
+
+	t.Run("OC debug pod ==> skipped", func(t *testing.T) {
+		wh := createTestWebhook(webhookmock.NewPodInjector(t), webhookmock.NewPodInjector(t), []client.Object{getTestNamespace(), getTestDynakube()})
+
+		_, outcome := wh.handle(ctx, *createTestAdmissionRequest(getTestPodWithOcDebugPodAnnotations()))
+		assert.Equal(t, ReasonOpenShiftDebugPod, outcome.reason)
+	})
+
+	t.Run("v2 injector skips pod ==> skipped with reason of injector", func(t *testing.T) {
+		dk := getTestDynakubeDefaultAppMon()
+		dk.Annotations = map[string]string{
+			exp.OANodeImagePullKey: "true",
+		}
+
+		v2Injector := webhookmock.NewPodInjector(t)
+		v2Injector.On("Handle", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			oacommon.SetNotInjectedAnnotations(args.Get(1).(*dtwebhook.MutationRequest).Pod, "NoCodeModulesImage")
+		}).Return(nil)
+		wh := createTestWebhook(webhookmock.NewPodInjector(t), v2Injector, []client.Object{getTestNamespace(), dk})
+
+		installconfig.SetModulesOverride(t, installconfig.Modules{CSIDriver: false})
+
+		_, outcome := wh.handle(ctx, *createTestAdmissionRequest(getTestPod()))
+		assert.Equal(t, skipped("NoCodeModulesImage").withDynakube(testDynakubeName, flowV2), outcome)
+	})
+
+	t.Run("v1 injector error ==> failed", func(t *testing.T) {
+		v1Injector := webhookmock.NewPodInjector(t)
+		v1Injector.On("Handle", mock.Anything, mock.Anything).Return(errors.New("BOOM"))
+		wh := createTestWebhook(v1Injector, webhookmock.NewPodInjector(t), []client.Object{getTestNamespace(), getTestDynakubeDefaultAppMon()})
+
+		installconfig.SetModulesOverride(t, installconfig.Modules{CSIDriver: false})
+
+		_, outcome := wh.handle(ctx, *createTestAdmissionRequest(getTestPod()))
+		assert.Equal(t, failed(ReasonInjectorFailed).withDynakube(testDynakubeName, flowV1), outcome)
+	})
+
+	t.Run("injected pods are counted", func(t *testing.T) {
+		v1Injector := webhookmock.NewPodInjector(t)
+		v1Injector.On("Handle", mock.Anything, mock.Anything).Return(nil)
+		wh := createTestWebhook(v1Injector, webhookmock.NewPodInjector(t), []client.Object{getTestNamespace(), getTestDynakubeDefaultAppMon()})
+
+		installconfig.SetModulesOverride(t, installconfig.Modules{CSIDriver: false})
+
+		counter := podInjectionsMetric.With(prometheus.Labels{
+			"namespace": testNamespaceName,
+			"dynakube":  testDynakubeName,
+			"flow":      flowV1,
+			"result":    string(ResultInjected),
+			"reason":    string(ReasonInjected),
+		})
+		before := testutil.ToFloat64(counter)
+
+		wh.Handle(ctx, *createTestAdmissionRequest(getTestPod()))
+
+		assert.InDelta(t, before+1, testutil.ToFloat64(counter), 0)
+	})
+}
+
 func getTestPodWithInjectionDisabled() *corev1.Pod {
 	pod := getTestPod()
 	pod.Annotations = map[string]string{
@@ -5,6 +5,9 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -25,6 +28,22 @@ type MutationRequest struct {
 	InstallContainer *corev1.Container
 }
 
+// Trace runs step in a child span of ctx. The context of the request carries the span while step runs,
+// so the calls to the Kubernetes API done by step are traced as children of the span.
+func (request *MutationRequest) Trace(ctx context.Context, name string, step func() error, attributes ...attribute.KeyValue) error {
+	parentCtx := request.Context
+	defer func() { request.Context = parentCtx }()
+
+	var span trace.Span
+
+	request.Context, span = tracing.Start(ctx, name, attributes...)
+
+	err := step()
+	tracing.End(span, err)
+
+	return err
+}
+
 func (request *MutationRequest) ToReinvocationRequest() *ReinvocationRequest {
 	return &ReinvocationRequest{
 		BaseRequest: request.BaseRequest,
@@ -116,7 +116,7 @@ func CreateTenantConfig(ecName string, clientSecret tenant.EdgeConnectSecret, ed
 		edgeConnectRequest := edgeconnectClient.NewRequest(ecName, []string{testHostPattern}, []edgeconnect.HostMapping{}, "")
 		edgeConnectRequest.ManagedByDynatraceOperator = false
 
-		res, err := ecClt.CreateEdgeConnect(edgeConnectRequest)
+		res, err := ecClt.CreateEdgeConnect(ctx, edgeConnectRequest)
 		require.NoError(t, err)
 		assert.Equal(t, ecName, res.Name)
 
@@ -164,7 +164,7 @@ func DeleteTenantConfig(clientSecret tenant.EdgeConnectSecret, edgeConnectTenant
 		ecClt, err := BuildEcClient(ctx, clientSecret)
 		require.NoError(t, err)
 
-		err = ecClt.DeleteEdgeConnect(edgeConnectTenantConfig.ID)
+		err = ecClt.DeleteEdgeConnect(ctx, edgeConnectTenantConfig.ID)
 		require.NoError(t, err)
 
 		return ctx
@@ -176,7 +176,7 @@ func CheckEcExistsOnTheTenant(clientSecret tenant.EdgeConnectSecret, edgeConnect
 		ecClt, err := BuildEcClient(ctx, clientSecret)
 		require.NoError(t, err)
 
-		_, err = ecClt.GetEdgeConnect(edgeConnectTenantConfig.ID)
+		_, err = ecClt.GetEdgeConnect(ctx, edgeConnectTenantConfig.ID)
 		require.NoError(t, err)
 
 		return ctx
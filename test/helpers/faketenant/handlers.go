@@ -0,0 +1,261 @@
+package faketenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+)
+
+const (
+	agentInstallerPath   = apiPath + "/v1/deployment/installer/agent"
+	gatewayInstallerPath = apiPath + "/v1/deployment/installer/gateway"
+
+	EntitiesEndpoint         = "/v2/entities"
+	SettingsObjectsEndpoint  = "/v2/settings/objects"
+	TokenLookupEndpoint      = "/v2/apiTokens/lookup"
+	ActiveGateTokensEndpoint = "/v2/activeGateTokens"
+
+	latestRelease = "latest"
+)
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+agentInstallerPath+"/connectioninfo", s.withScope(dtclient.TokenScopeInstallerDownload, s.oneAgentConnectionInfo))
+	mux.HandleFunc("GET "+agentInstallerPath+"/processmoduleconfig", s.withScope(dtclient.TokenScopeInstallerDownload, s.processModuleConfig))
+	mux.HandleFunc("GET "+agentInstallerPath+"/versions/{os}/{type}", s.withScope(dtclient.TokenScopeInstallerDownload, s.agentVersions))
+	mux.HandleFunc("GET "+agentInstallerPath+"/{os}/{type}/{release}", s.withScope(dtclient.TokenScopeInstallerDownload, s.latestAgent))
+	mux.HandleFunc("GET "+agentInstallerPath+"/{os}/{type}/latest/metainfo", s.withScope(dtclient.TokenScopeInstallerDownload, s.latestAgentVersion))
+	mux.HandleFunc("GET "+agentInstallerPath+"/{os}/{type}/version/{version}", s.withScope(dtclient.TokenScopeInstallerDownload, s.agent))
+
+	mux.HandleFunc("GET "+gatewayInstallerPath+"/connectioninfo", s.withScope(dtclient.TokenScopeInstallerDownload, s.activeGateConnectionInfo))
+	mux.HandleFunc("GET "+gatewayInstallerPath+"/{os}/latest/metainfo", s.withScope(dtclient.TokenScopeInstallerDownload, s.latestActiveGateVersion))
+
+	mux.HandleFunc("GET "+apiPath+dtclient.LatestOneAgentImageEndpoint, s.withScope(dtclient.TokenScopeInstallerDownload, s.latestImage(func(tenant Tenant) dtclient.LatestImageInfo { return tenant.OneAgentImage })))
+	mux.HandleFunc("GET "+apiPath+dtclient.LatestCodeModulesImageEndpoint, s.withScope(dtclient.TokenScopeInstallerDownload, s.latestImage(func(tenant Tenant) dtclient.LatestImageInfo { return tenant.CodeModulesImage })))
+	mux.HandleFunc("GET "+apiPath+dtclient.LatestActiveGateImageEndpoint, s.withScope(dtclient.TokenScopeInstallerDownload, s.latestImage(func(tenant Tenant) dtclient.LatestImageInfo { return tenant.ActiveGateImage })))
+
+	mux.HandleFunc("GET "+apiPath+EntitiesEndpoint, s.withScope(dtclient.TokenScopeEntitiesRead, s.entities))
+	mux.HandleFunc("GET "+apiPath+SettingsObjectsEndpoint, s.withScope(dtclient.TokenScopeSettingsRead, s.getSettingsObjects))
+	mux.HandleFunc("POST "+apiPath+SettingsObjectsEndpoint, s.withScope(dtclient.TokenScopeSettingsWrite, s.postSettingsObjects))
+	mux.HandleFunc("GET "+apiPath+dtclient.EffectiveSettingsEndpoint, s.withScope(dtclient.TokenScopeSettingsRead, s.effectiveSettings))
+	mux.HandleFunc("POST "+apiPath+TokenLookupEndpoint, s.withScope("", s.tokenLookup))
+	mux.HandleFunc("POST "+apiPath+ActiveGateTokensEndpoint, s.withScope(dtclient.TokenScopeActiveGateTokenCreate, s.createActiveGateToken))
+
+	return mux
+}
+
+func (s *Server) withScope(scope string, handler func(http.ResponseWriter, *http.Request, Tenant)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if !s.intercept(writer, request) || !s.authorize(writer, request, scope) {
+			return
+		}
+
+		s.mutex.Lock()
+		tenant := s.tenant
+		s.mutex.Unlock()
+
+		handler(writer, request, tenant)
+	}
+}
+
+func (s *Server) oneAgentConnectionInfo(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"tenantUUID":             tenant.TenantUUID,
+		"tenantToken":            tenant.TenantToken,
+		"communicationEndpoints": tenant.CommunicationEndpoints,
+	})
+}
+
+func (s *Server) activeGateConnectionInfo(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"tenantUUID":             tenant.TenantUUID,
+		"tenantToken":            tenant.TenantToken,
+		"communicationEndpoints": strings.Join(tenant.CommunicationEndpoints, ","),
+	})
+}
+
+func (s *Server) processModuleConfig(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	if request.URL.Query().Get("revision") == strconv.FormatUint(uint64(tenant.ProcessModuleConfig.Revision), 10) {
+		writer.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	s.writeJSON(writer, request, http.StatusOK, tenant.ProcessModuleConfig)
+}
+
+func (s *Server) agentVersions(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"availableVersions": tenant.AgentVersions,
+	})
+}
+
+func (s *Server) latestAgentVersion(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	if len(tenant.AgentVersions) == 0 {
+		writeError(writer, http.StatusNotFound, "no agent available")
+
+		return
+	}
+
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"latestAgentVersion": tenant.AgentVersions[len(tenant.AgentVersions)-1],
+	})
+}
+
+func (s *Server) latestAgent(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	if request.PathValue("release") != latestRelease || len(tenant.AgentVersions) == 0 {
+		writeError(writer, http.StatusNotFound, "no agent available")
+
+		return
+	}
+
+	writeBinary(writer, tenant.AgentPayload)
+}
+
+func (s *Server) agent(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	if !slices.Contains(tenant.AgentVersions, request.PathValue("version")) {
+		writeError(writer, http.StatusNotFound, "agent version not available")
+
+		return
+	}
+
+	writeBinary(writer, tenant.AgentPayload)
+}
+
+func (s *Server) latestActiveGateVersion(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"latestGatewayVersion": tenant.ActiveGateVersion,
+	})
+}
+
+func (s *Server) latestImage(image func(Tenant) dtclient.LatestImageInfo) func(http.ResponseWriter, *http.Request, Tenant) {
+	return func(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+		s.writeJSON(writer, request, http.StatusOK, image(tenant))
+	}
+}
+
+func (s *Server) entities(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"entities":   tenant.MonitoredEntities,
+		"totalCount": len(tenant.MonitoredEntities),
+		"pageSize":   len(tenant.MonitoredEntities),
+	})
+}
+
+func (s *Server) getSettingsObjects(writer http.ResponseWriter, request *http.Request, _ Tenant) {
+	query := request.URL.Query()
+	items := []map[string]any{}
+
+	for _, object := range s.SettingsObjects() {
+		if query.Has("schemaIds") && query.Get("schemaIds") != object.SchemaID {
+			continue
+		}
+
+		if query.Has("scopes") && query.Get("scopes") != object.Scope {
+			continue
+		}
+
+		items = append(items, map[string]any{
+			"objectId": object.ObjectID,
+			"value":    object.Value,
+		})
+	}
+
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"items":      items,
+		"totalCount": len(items),
+	})
+}
+
+func (s *Server) postSettingsObjects(writer http.ResponseWriter, request *http.Request, _ Tenant) {
+	var objects []SettingsObject
+	if err := json.NewDecoder(request.Body).Decode(&objects); err != nil {
+		writeError(writer, http.StatusBadRequest, "could not parse settings objects: "+err.Error())
+
+		return
+	}
+
+	validateOnly := request.URL.Query().Get("validateOnly") == "true"
+	response := make([]map[string]string, 0, len(objects))
+
+	s.mutex.Lock()
+
+	for _, object := range objects {
+		object.ObjectID = fmt.Sprintf("object-%d", len(s.settings)+1)
+		if !validateOnly {
+			s.settings = append(s.settings, object)
+		}
+
+		response = append(response, map[string]string{"objectId": object.ObjectID})
+	}
+
+	s.mutex.Unlock()
+
+	body, _ := json.Marshal(response)
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(body)
+}
+
+func (s *Server) effectiveSettings(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	if request.URL.Query().Get("schemaIds") != dtclient.MetadataEnrichmentSettingsSchemaID {
+		s.writeJSON(writer, request, http.StatusOK, map[string]any{"items": []any{}, "totalCount": 0})
+
+		return
+	}
+
+	s.writeJSON(writer, request, http.StatusOK, dtclient.GetRulesSettingsResponse{
+		Items:      []dtclient.RuleItem{{Value: dtclient.RulesResponseValue{Rules: tenant.EnrichmentRules}}},
+		TotalCount: 1,
+	})
+}
+
+func (s *Server) tokenLookup(writer http.ResponseWriter, request *http.Request, tenant Tenant) {
+	var lookup struct {
+		Token string `json:"token"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&lookup); err != nil {
+		writeError(writer, http.StatusBadRequest, "could not parse token lookup: "+err.Error())
+
+		return
+	}
+
+	scopes, ok := tenant.Tokens[lookup.Token]
+	if !ok {
+		writeError(writer, http.StatusNotFound, "token not found")
+
+		return
+	}
+
+	s.writeJSON(writer, request, http.StatusOK, map[string]any{
+		"scopes": scopes,
+	})
+}
+
+func (s *Server) createActiveGateToken(writer http.ResponseWriter, request *http.Request, _ Tenant) {
+	var params dtclient.ActiveGateAuthTokenParams
+	if err := json.NewDecoder(request.Body).Decode(&params); err != nil {
+		writeError(writer, http.StatusBadRequest, "could not parse token parameters: "+err.Error())
+
+		return
+	}
+
+	s.mutex.Lock()
+	id := fmt.Sprintf("dt0g02.%08d", len(s.activeGateTokens)+1)
+	token := dtclient.ActiveGateAuthTokenInfo{TokenID: id, Token: id + "." + params.Name}
+	s.activeGateTokens = append(s.activeGateTokens, token)
+	s.mutex.Unlock()
+
+	s.writeJSON(writer, request, http.StatusCreated, token)
+}
+
+func writeBinary(writer http.ResponseWriter, payload []byte) {
+	writer.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = writer.Write(payload)
+}
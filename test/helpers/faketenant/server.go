@@ -0,0 +1,335 @@
+// Package faketenant provides an in-process fake of the Dynatrace API used by the operator.
+// It keeps state between requests (settings objects, ActiveGate tokens, ...) and allows injecting
+// latency, throttling and schema drift, so clients can be tested end to end without network access.
+package faketenant
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+)
+
+const (
+	DefaultAPIToken          = "api-token"
+	DefaultPaasToken         = "paas-token"
+	DefaultTenantUUID        = "abc12345"
+	DefaultTenantToken       = "tenant-token"
+	DefaultAgentVersion      = "1.303.0.20240930-183404"
+	DefaultActiveGateVersion = "1.303.0.20240930-183404"
+	DefaultImageSource       = "registry.fake-tenant.local"
+
+	apiPath = "/api"
+)
+
+// AllTokenScopes are the scopes granted to the default API token.
+var AllTokenScopes = dtclient.TokenScopes{
+	dtclient.TokenScopeInstallerDownload,
+	dtclient.TokenScopeMetricsIngest,
+	dtclient.TokenScopeEntitiesRead,
+	dtclient.TokenScopeSettingsRead,
+	dtclient.TokenScopeSettingsWrite,
+	dtclient.TokenScopeActiveGateTokenCreate,
+}
+
+// Tenant holds the data served by the fake API.
+type Tenant struct {
+	// Tokens maps the accepted tokens to their scopes, they are accepted as Api-Token and as Bearer token.
+	Tokens map[string]dtclient.TokenScopes
+
+	TenantUUID             string
+	TenantToken            string
+	CommunicationEndpoints []string
+
+	// AgentVersions are the available OneAgent versions, the last one is the latest.
+	AgentVersions     []string
+	ActiveGateVersion string
+	// AgentPayload is returned for every agent download.
+	AgentPayload []byte
+
+	OneAgentImage    dtclient.LatestImageInfo
+	CodeModulesImage dtclient.LatestImageInfo
+	ActiveGateImage  dtclient.LatestImageInfo
+
+	ProcessModuleConfig dtclient.ProcessModuleConfig
+	MonitoredEntities   []dtclient.MonitoredEntity
+	EnrichmentRules     []dynakube.EnrichmentRule
+}
+
+// DefaultTenant returns a tenant accepting DefaultAPIToken with all scopes and DefaultPaasToken for downloads.
+func DefaultTenant() Tenant {
+	return Tenant{
+		Tokens: map[string]dtclient.TokenScopes{
+			DefaultAPIToken:  AllTokenScopes,
+			DefaultPaasToken: {dtclient.TokenScopeInstallerDownload},
+		},
+		TenantUUID:             DefaultTenantUUID,
+		TenantToken:            DefaultTenantToken,
+		CommunicationEndpoints: []string{"https://" + DefaultTenantUUID + ".fake-tenant.local:443/communication"},
+		AgentVersions:          []string{DefaultAgentVersion},
+		ActiveGateVersion:      DefaultActiveGateVersion,
+		AgentPayload:           []byte("agent"),
+		OneAgentImage:          dtclient.LatestImageInfo{Source: DefaultImageSource + "/oneagent", Tag: DefaultAgentVersion},
+		CodeModulesImage:       dtclient.LatestImageInfo{Source: DefaultImageSource + "/codemodules", Tag: DefaultAgentVersion},
+		ActiveGateImage:        dtclient.LatestImageInfo{Source: DefaultImageSource + "/activegate", Tag: DefaultActiveGateVersion},
+		ProcessModuleConfig: dtclient.ProcessModuleConfig{
+			Revision: 1,
+			Properties: []dtclient.ProcessModuleProperty{
+				{Section: "general", Key: "tenant", Value: DefaultTenantUUID},
+			},
+		},
+	}
+}
+
+// SettingsObject is a settings object created through the API.
+type SettingsObject struct {
+	ObjectID      string          `json:"objectId"`
+	SchemaID      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion"`
+	Scope         string          `json:"scope,omitempty"`
+	Value         json.RawMessage `json:"value"`
+}
+
+// Request is a request received by the fake API, recorded before any fault is injected.
+type Request struct {
+	Method   string
+	Endpoint string
+	Query    string
+}
+
+// DriftFunc modifies a JSON object response before it is sent, e.g. to rename or drop fields.
+type DriftFunc func(body map[string]any)
+
+type Option func(*Server)
+
+// WithTenant replaces the DefaultTenant served by the fake API.
+func WithTenant(tenant Tenant) Option {
+	return func(s *Server) {
+		s.tenant = tenant
+	}
+}
+
+// Server is a fake Dynatrace tenant, use APIURL as the API URL of a DynaKube or client.
+type Server struct {
+	*httptest.Server
+
+	tenant           Tenant
+	settings         []SettingsObject
+	activeGateTokens []dtclient.ActiveGateAuthTokenInfo
+	requests         []Request
+
+	latency    time.Duration
+	throttled  int
+	retryAfter time.Duration
+	drifts     map[string]DriftFunc
+
+	mutex sync.Mutex
+}
+
+// New starts a fake tenant that is closed when the test finishes.
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	s := &Server{
+		tenant: DefaultTenant(),
+		drifts: map[string]DriftFunc{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(s.routes())
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// APIURL returns the URL of the API, including the /api path like the API URL of a tenant.
+func (s *Server) APIURL() string {
+	return s.URL + apiPath
+}
+
+// Update changes the tenant data while the server is running, e.g. to release a new agent version.
+func (s *Server) Update(update func(tenant *Tenant)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	update(&s.tenant)
+}
+
+// SetLatency delays every following response by the given duration.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.latency = latency
+}
+
+// Throttle answers the next count requests with 429 and the given Retry-After, which is omitted if zero.
+func (s *Server) Throttle(count int, retryAfter time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.throttled = count
+	s.retryAfter = retryAfter
+}
+
+// Drift applies drift to the JSON object responses of the given endpoint, see the dtclient endpoint constants.
+// Passing nil removes the drift again.
+func (s *Server) Drift(endpoint string, drift DriftFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if drift == nil {
+		delete(s.drifts, endpoint)
+
+		return
+	}
+
+	s.drifts[endpoint] = drift
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+// RequestCount returns the number of requests received for the given endpoint.
+func (s *Server) RequestCount(endpoint string) int {
+	count := 0
+
+	for _, request := range s.Requests() {
+		if request.Endpoint == endpoint {
+			count++
+		}
+	}
+
+	return count
+}
+
+// SettingsObjects returns the settings objects created so far.
+func (s *Server) SettingsObjects() []SettingsObject {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]SettingsObject{}, s.settings...)
+}
+
+// ActiveGateTokens returns the ActiveGate auth tokens created so far.
+func (s *Server) ActiveGateTokens() []dtclient.ActiveGateAuthTokenInfo {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]dtclient.ActiveGateAuthTokenInfo{}, s.activeGateTokens...)
+}
+
+// intercept records the request and applies latency and throttling, it returns false if the request was already answered.
+func (s *Server) intercept(writer http.ResponseWriter, request *http.Request) bool {
+	s.mutex.Lock()
+	s.requests = append(s.requests, Request{
+		Method:   request.Method,
+		Endpoint: strings.TrimPrefix(request.URL.Path, apiPath),
+		Query:    request.URL.RawQuery,
+	})
+	latency := s.latency
+	throttle := s.throttled > 0
+	retryAfter := s.retryAfter
+
+	if throttle {
+		s.throttled--
+	}
+	s.mutex.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-request.Context().Done():
+			return false
+		}
+	}
+
+	if throttle {
+		if retryAfter > 0 {
+			writer.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		}
+
+		writeError(writer, http.StatusTooManyRequests, "too many requests")
+
+		return false
+	}
+
+	return true
+}
+
+// authorize checks the token of the request for the given scope, an empty scope only requires a known token.
+func (s *Server) authorize(writer http.ResponseWriter, request *http.Request, scope string) bool {
+	authorization := request.Header.Get("Authorization")
+
+	token, found := strings.CutPrefix(authorization, dtclient.APITokenHeader)
+	if !found {
+		token, found = strings.CutPrefix(authorization, "Bearer ")
+	}
+
+	s.mutex.Lock()
+	scopes, known := s.tenant.Tokens[token]
+	s.mutex.Unlock()
+
+	switch {
+	case !found || !known:
+		writeError(writer, http.StatusUnauthorized, "Token Authentication failed")
+
+		return false
+	case scope != "" && !scopes.Contains(scope):
+		writeError(writer, http.StatusForbidden, "Token is missing required scope "+scope)
+
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) writeJSON(writer http.ResponseWriter, request *http.Request, status int, response any) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	s.mutex.Lock()
+	drift := s.drifts[strings.TrimPrefix(request.URL.Path, apiPath)]
+	s.mutex.Unlock()
+
+	if drift != nil {
+		object := map[string]any{}
+		if json.Unmarshal(body, &object) == nil {
+			drift(object)
+			body, _ = json.Marshal(object)
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_, _ = writer.Write(body)
+}
+
+func writeError(writer http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(map[string]dtclient.ServerError{
+		"error": {Code: status, Message: message},
+	})
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_, _ = writer.Write(body)
+}
@@ -0,0 +1,193 @@
+package faketenant
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeSystemUUID = "kube-system-uuid"
+
+func newTestClient(t *testing.T, server *Server, opts ...dtclient.Option) dtclient.Client {
+	t.Helper()
+
+	dtc, err := dtclient.NewClient(server.APIURL(), DefaultAPIToken, DefaultPaasToken, opts...)
+	require.NoError(t, err)
+
+	return dtc
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves connection info", func(t *testing.T) {
+		server := New(t)
+		dtc := newTestClient(t, server)
+
+		oneAgentConnectionInfo, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultTenantUUID, oneAgentConnectionInfo.TenantUUID)
+		assert.Len(t, oneAgentConnectionInfo.CommunicationHosts, 1)
+
+		activeGateConnectionInfo, err := dtc.GetActiveGateConnectionInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultTenantToken, activeGateConnectionInfo.TenantToken)
+	})
+	t.Run("serves agent versions and downloads", func(t *testing.T) {
+		server := New(t)
+		server.Update(func(tenant *Tenant) {
+			tenant.AgentVersions = append(tenant.AgentVersions, "1.305.0.20241010-100000")
+		})
+		dtc := newTestClient(t, server)
+
+		latest, err := dtc.GetLatestAgentVersion(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.NoError(t, err)
+		assert.Equal(t, "1.305.0.20241010-100000", latest)
+
+		versions, err := dtc.GetAgentVersions(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{DefaultAgentVersion, "1.305.0.20241010-100000"}, versions)
+
+		agent := &bytes.Buffer{}
+		require.NoError(t, dtc.GetAgent(ctx, dtclient.OsUnix, dtclient.InstallerTypePaaS, "", "", DefaultAgentVersion, nil, false, agent))
+		assert.Equal(t, "agent", agent.String())
+
+		require.Error(t, dtc.GetAgent(ctx, dtclient.OsUnix, dtclient.InstallerTypePaaS, "", "", "1.0.0", nil, false, &bytes.Buffer{}))
+
+		activeGateVersion, err := dtc.GetLatestActiveGateVersion(ctx, dtclient.OsUnix)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultActiveGateVersion, activeGateVersion)
+	})
+	t.Run("serves latest images and process module config", func(t *testing.T) {
+		server := New(t)
+		dtc := newTestClient(t, server)
+
+		image, err := dtc.GetLatestActiveGateImage(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultActiveGateVersion, image.Tag)
+
+		config, err := dtc.GetProcessModuleConfig(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), config.Revision)
+
+		config, err = dtc.GetProcessModuleConfig(ctx, 1)
+		require.NoError(t, err)
+		assert.Empty(t, config.Properties)
+	})
+	t.Run("looks up token scopes", func(t *testing.T) {
+		server := New(t)
+		dtc := newTestClient(t, server)
+
+		scopes, err := dtc.GetTokenScopes(ctx, DefaultPaasToken)
+		require.NoError(t, err)
+		assert.Equal(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, scopes)
+
+		_, err = dtc.GetTokenScopes(ctx, "unknown")
+		require.Error(t, err)
+	})
+	t.Run("keeps created settings and tokens", func(t *testing.T) {
+		server := New(t)
+		server.Update(func(tenant *Tenant) {
+			tenant.MonitoredEntities = []dtclient.MonitoredEntity{{EntityID: "KUBERNETES_CLUSTER-1"}}
+		})
+		dtc := newTestClient(t, server)
+
+		entities, err := dtc.GetMonitoredEntitiesForKubeSystemUUID(ctx, testKubeSystemUUID)
+		require.NoError(t, err)
+		require.Len(t, entities, 1)
+
+		objectID, err := dtc.CreateOrUpdateKubernetesSetting(ctx, "cluster", testKubeSystemUUID, entities[0].EntityID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, objectID)
+
+		settings, err := dtc.GetSettingsForMonitoredEntity(ctx, &entities[0], dtclient.KubernetesSettingsSchemaID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, settings.TotalCount)
+
+		tokenInfo, err := dtc.GetActiveGateAuthToken(ctx, "dynakube")
+		require.NoError(t, err)
+		assert.Equal(t, []dtclient.ActiveGateAuthTokenInfo{*tokenInfo}, server.ActiveGateTokens())
+	})
+	t.Run("rejects unknown tokens and missing scopes", func(t *testing.T) {
+		server := New(t)
+
+		dtc, err := dtclient.NewClient(server.APIURL(), "unknown", "unknown")
+		require.NoError(t, err)
+
+		_, err = dtc.GetLatestAgentVersion(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.ErrorContains(t, err, "401")
+
+		dtc, err = dtclient.NewClient(server.APIURL(), DefaultPaasToken, DefaultPaasToken)
+		require.NoError(t, err)
+
+		_, err = dtc.GetActiveGateAuthToken(ctx, "dynakube")
+		require.ErrorContains(t, err, "403")
+	})
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("throttled requests are retried", func(t *testing.T) {
+		server := New(t)
+		server.Throttle(2, 0)
+		dtc := newTestClient(t, server, dtclient.Retry(dtclient.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}))
+
+		_, err := dtc.GetLatestAgentVersion(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.NoError(t, err)
+		assert.Len(t, server.Requests(), 3)
+	})
+	t.Run("throttled requests fail without retries", func(t *testing.T) {
+		server := New(t)
+		server.Throttle(1, time.Second)
+		dtc := newTestClient(t, server)
+
+		_, err := dtc.GetLatestAgentVersion(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.ErrorContains(t, err, "429")
+	})
+	t.Run("latency hits the client deadline", func(t *testing.T) {
+		server := New(t)
+		server.SetLatency(time.Second)
+		dtc := newTestClient(t, server)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := dtc.GetLatestAgentVersion(timeoutCtx, dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("schema drift changes responses", func(t *testing.T) {
+		server := New(t)
+		server.Drift(dtclient.OneAgentConnectionInfoEndpoint, func(body map[string]any) {
+			body["tenantId"] = body["tenantUUID"]
+			delete(body, "tenantUUID")
+		})
+		dtc := newTestClient(t, server)
+
+		connectionInfo, err := dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, connectionInfo.TenantUUID)
+
+		server.Drift(dtclient.OneAgentConnectionInfoEndpoint, nil)
+
+		connectionInfo, err = dtc.GetOneAgentConnectionInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultTenantUUID, connectionInfo.TenantUUID)
+	})
+	t.Run("requests are recorded by endpoint", func(t *testing.T) {
+		server := New(t)
+		dtc := newTestClient(t, server)
+
+		_, _ = dtc.GetLatestActiveGateImage(ctx)
+		_, _ = dtc.GetLatestActiveGateImage(ctx)
+
+		assert.Equal(t, 2, server.RequestCount(dtclient.LatestActiveGateImageEndpoint))
+		assert.Equal(t, http.MethodGet, server.Requests()[0].Method)
+	})
+}
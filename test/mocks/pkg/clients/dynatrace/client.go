@@ -1170,6 +1170,68 @@ func (_c *Client_GetMonitoredEntitiesForKubeSystemUUID_Call) RunAndReturn(run fu
 	return _c
 }
 
+// GetOAuthScopes provides a mock function for the type Client
+func (_mock *Client) GetOAuthScopes(ctx context.Context) (dynatrace.TokenScopes, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOAuthScopes")
+	}
+
+	var r0 dynatrace.TokenScopes
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (dynatrace.TokenScopes, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) dynatrace.TokenScopes); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dynatrace.TokenScopes)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Client_GetOAuthScopes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOAuthScopes'
+type Client_GetOAuthScopes_Call struct {
+	*mock.Call
+}
+
+// GetOAuthScopes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) GetOAuthScopes(ctx interface{}) *Client_GetOAuthScopes_Call {
+	return &Client_GetOAuthScopes_Call{Call: _e.mock.On("GetOAuthScopes", ctx)}
+}
+
+func (_c *Client_GetOAuthScopes_Call) Run(run func(ctx context.Context)) *Client_GetOAuthScopes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Client_GetOAuthScopes_Call) Return(tokenScopes dynatrace.TokenScopes, err error) *Client_GetOAuthScopes_Call {
+	_c.Call.Return(tokenScopes, err)
+	return _c
+}
+
+func (_c *Client_GetOAuthScopes_Call) RunAndReturn(run func(ctx context.Context) (dynatrace.TokenScopes, error)) *Client_GetOAuthScopes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOneAgentConnectionInfo provides a mock function for the type Client
 func (_mock *Client) GetOneAgentConnectionInfo(ctx context.Context) (dynatrace.OneAgentConnectionInfo, error) {
 	ret := _mock.Called(ctx)
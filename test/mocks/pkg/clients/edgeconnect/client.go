@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/edgeconnect"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,16 +39,16 @@ func (_m *Client) EXPECT() *Client_Expecter {
 }
 
 // CreateConnectionSetting provides a mock function for the type Client
-func (_mock *Client) CreateConnectionSetting(es edgeconnect.EnvironmentSetting) error {
-	ret := _mock.Called(es)
+func (_mock *Client) CreateConnectionSetting(ctx context.Context, es edgeconnect.EnvironmentSetting) error {
+	ret := _mock.Called(ctx, es)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateConnectionSetting")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(edgeconnect.EnvironmentSetting) error); ok {
-		r0 = returnFunc(es)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, edgeconnect.EnvironmentSetting) error); ok {
+		r0 = returnFunc(ctx, es)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -59,19 +61,25 @@ type Client_CreateConnectionSetting_Call struct {
 }
 
 // CreateConnectionSetting is a helper method to define mock.On call
+//   - ctx context.Context
 //   - es edgeconnect.EnvironmentSetting
-func (_e *Client_Expecter) CreateConnectionSetting(es interface{}) *Client_CreateConnectionSetting_Call {
-	return &Client_CreateConnectionSetting_Call{Call: _e.mock.On("CreateConnectionSetting", es)}
+func (_e *Client_Expecter) CreateConnectionSetting(ctx interface{}, es interface{}) *Client_CreateConnectionSetting_Call {
+	return &Client_CreateConnectionSetting_Call{Call: _e.mock.On("CreateConnectionSetting", ctx, es)}
 }
 
-func (_c *Client_CreateConnectionSetting_Call) Run(run func(es edgeconnect.EnvironmentSetting)) *Client_CreateConnectionSetting_Call {
+func (_c *Client_CreateConnectionSetting_Call) Run(run func(ctx context.Context, es edgeconnect.EnvironmentSetting)) *Client_CreateConnectionSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 edgeconnect.EnvironmentSetting
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(edgeconnect.EnvironmentSetting)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 edgeconnect.EnvironmentSetting
+		if args[1] != nil {
+			arg1 = args[1].(edgeconnect.EnvironmentSetting)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -82,14 +90,14 @@ func (_c *Client_CreateConnectionSetting_Call) Return(err error) *Client_CreateC
 	return _c
 }
 
-func (_c *Client_CreateConnectionSetting_Call) RunAndReturn(run func(es edgeconnect.EnvironmentSetting) error) *Client_CreateConnectionSetting_Call {
+func (_c *Client_CreateConnectionSetting_Call) RunAndReturn(run func(ctx context.Context, es edgeconnect.EnvironmentSetting) error) *Client_CreateConnectionSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // CreateEdgeConnect provides a mock function for the type Client
-func (_mock *Client) CreateEdgeConnect(request *edgeconnect.Request) (edgeconnect.CreateResponse, error) {
-	ret := _mock.Called(request)
+func (_mock *Client) CreateEdgeConnect(ctx context.Context, request *edgeconnect.Request) (edgeconnect.CreateResponse, error) {
+	ret := _mock.Called(ctx, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateEdgeConnect")
@@ -97,16 +105,16 @@ func (_mock *Client) CreateEdgeConnect(request *edgeconnect.Request) (edgeconnec
 
 	var r0 edgeconnect.CreateResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*edgeconnect.Request) (edgeconnect.CreateResponse, error)); ok {
-		return returnFunc(request)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *edgeconnect.Request) (edgeconnect.CreateResponse, error)); ok {
+		return returnFunc(ctx, request)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*edgeconnect.Request) edgeconnect.CreateResponse); ok {
-		r0 = returnFunc(request)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *edgeconnect.Request) edgeconnect.CreateResponse); ok {
+		r0 = returnFunc(ctx, request)
 	} else {
 		r0 = ret.Get(0).(edgeconnect.CreateResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(*edgeconnect.Request) error); ok {
-		r1 = returnFunc(request)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *edgeconnect.Request) error); ok {
+		r1 = returnFunc(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -119,19 +127,25 @@ type Client_CreateEdgeConnect_Call struct {
 }
 
 // CreateEdgeConnect is a helper method to define mock.On call
+//   - ctx context.Context
 //   - request *edgeconnect.Request
-func (_e *Client_Expecter) CreateEdgeConnect(request interface{}) *Client_CreateEdgeConnect_Call {
-	return &Client_CreateEdgeConnect_Call{Call: _e.mock.On("CreateEdgeConnect", request)}
+func (_e *Client_Expecter) CreateEdgeConnect(ctx interface{}, request interface{}) *Client_CreateEdgeConnect_Call {
+	return &Client_CreateEdgeConnect_Call{Call: _e.mock.On("CreateEdgeConnect", ctx, request)}
 }
 
-func (_c *Client_CreateEdgeConnect_Call) Run(run func(request *edgeconnect.Request)) *Client_CreateEdgeConnect_Call {
+func (_c *Client_CreateEdgeConnect_Call) Run(run func(ctx context.Context, request *edgeconnect.Request)) *Client_CreateEdgeConnect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *edgeconnect.Request
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*edgeconnect.Request)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *edgeconnect.Request
+		if args[1] != nil {
+			arg1 = args[1].(*edgeconnect.Request)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -142,22 +156,22 @@ func (_c *Client_CreateEdgeConnect_Call) Return(createResponse edgeconnect.Creat
 	return _c
 }
 
-func (_c *Client_CreateEdgeConnect_Call) RunAndReturn(run func(request *edgeconnect.Request) (edgeconnect.CreateResponse, error)) *Client_CreateEdgeConnect_Call {
+func (_c *Client_CreateEdgeConnect_Call) RunAndReturn(run func(ctx context.Context, request *edgeconnect.Request) (edgeconnect.CreateResponse, error)) *Client_CreateEdgeConnect_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteConnectionSetting provides a mock function for the type Client
-func (_mock *Client) DeleteConnectionSetting(objectID string) error {
-	ret := _mock.Called(objectID)
+func (_mock *Client) DeleteConnectionSetting(ctx context.Context, objectID string) error {
+	ret := _mock.Called(ctx, objectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteConnectionSetting")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(objectID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, objectID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -170,19 +184,25 @@ type Client_DeleteConnectionSetting_Call struct {
 }
 
 // DeleteConnectionSetting is a helper method to define mock.On call
+//   - ctx context.Context
 //   - objectID string
-func (_e *Client_Expecter) DeleteConnectionSetting(objectID interface{}) *Client_DeleteConnectionSetting_Call {
-	return &Client_DeleteConnectionSetting_Call{Call: _e.mock.On("DeleteConnectionSetting", objectID)}
+func (_e *Client_Expecter) DeleteConnectionSetting(ctx interface{}, objectID interface{}) *Client_DeleteConnectionSetting_Call {
+	return &Client_DeleteConnectionSetting_Call{Call: _e.mock.On("DeleteConnectionSetting", ctx, objectID)}
 }
 
-func (_c *Client_DeleteConnectionSetting_Call) Run(run func(objectID string)) *Client_DeleteConnectionSetting_Call {
+func (_c *Client_DeleteConnectionSetting_Call) Run(run func(ctx context.Context, objectID string)) *Client_DeleteConnectionSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -193,22 +213,22 @@ func (_c *Client_DeleteConnectionSetting_Call) Return(err error) *Client_DeleteC
 	return _c
 }
 
-func (_c *Client_DeleteConnectionSetting_Call) RunAndReturn(run func(objectID string) error) *Client_DeleteConnectionSetting_Call {
+func (_c *Client_DeleteConnectionSetting_Call) RunAndReturn(run func(ctx context.Context, objectID string) error) *Client_DeleteConnectionSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteEdgeConnect provides a mock function for the type Client
-func (_mock *Client) DeleteEdgeConnect(edgeConnectID string) error {
-	ret := _mock.Called(edgeConnectID)
+func (_mock *Client) DeleteEdgeConnect(ctx context.Context, edgeConnectID string) error {
+	ret := _mock.Called(ctx, edgeConnectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteEdgeConnect")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(edgeConnectID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, edgeConnectID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -221,19 +241,25 @@ type Client_DeleteEdgeConnect_Call struct {
 }
 
 // DeleteEdgeConnect is a helper method to define mock.On call
+//   - ctx context.Context
 //   - edgeConnectID string
-func (_e *Client_Expecter) DeleteEdgeConnect(edgeConnectID interface{}) *Client_DeleteEdgeConnect_Call {
-	return &Client_DeleteEdgeConnect_Call{Call: _e.mock.On("DeleteEdgeConnect", edgeConnectID)}
+func (_e *Client_Expecter) DeleteEdgeConnect(ctx interface{}, edgeConnectID interface{}) *Client_DeleteEdgeConnect_Call {
+	return &Client_DeleteEdgeConnect_Call{Call: _e.mock.On("DeleteEdgeConnect", ctx, edgeConnectID)}
 }
 
-func (_c *Client_DeleteEdgeConnect_Call) Run(run func(edgeConnectID string)) *Client_DeleteEdgeConnect_Call {
+func (_c *Client_DeleteEdgeConnect_Call) Run(run func(ctx context.Context, edgeConnectID string)) *Client_DeleteEdgeConnect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -244,14 +270,14 @@ func (_c *Client_DeleteEdgeConnect_Call) Return(err error) *Client_DeleteEdgeCon
 	return _c
 }
 
-func (_c *Client_DeleteEdgeConnect_Call) RunAndReturn(run func(edgeConnectID string) error) *Client_DeleteEdgeConnect_Call {
+func (_c *Client_DeleteEdgeConnect_Call) RunAndReturn(run func(ctx context.Context, edgeConnectID string) error) *Client_DeleteEdgeConnect_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetConnectionSettings provides a mock function for the type Client
-func (_mock *Client) GetConnectionSettings() ([]edgeconnect.EnvironmentSetting, error) {
-	ret := _mock.Called()
+func (_mock *Client) GetConnectionSettings(ctx context.Context) ([]edgeconnect.EnvironmentSetting, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetConnectionSettings")
@@ -259,18 +285,18 @@ func (_mock *Client) GetConnectionSettings() ([]edgeconnect.EnvironmentSetting,
 
 	var r0 []edgeconnect.EnvironmentSetting
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]edgeconnect.EnvironmentSetting, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]edgeconnect.EnvironmentSetting, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []edgeconnect.EnvironmentSetting); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []edgeconnect.EnvironmentSetting); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]edgeconnect.EnvironmentSetting)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -283,13 +309,20 @@ type Client_GetConnectionSettings_Call struct {
 }
 
 // GetConnectionSettings is a helper method to define mock.On call
-func (_e *Client_Expecter) GetConnectionSettings() *Client_GetConnectionSettings_Call {
-	return &Client_GetConnectionSettings_Call{Call: _e.mock.On("GetConnectionSettings")}
+//   - ctx context.Context
+func (_e *Client_Expecter) GetConnectionSettings(ctx interface{}) *Client_GetConnectionSettings_Call {
+	return &Client_GetConnectionSettings_Call{Call: _e.mock.On("GetConnectionSettings", ctx)}
 }
 
-func (_c *Client_GetConnectionSettings_Call) Run(run func()) *Client_GetConnectionSettings_Call {
+func (_c *Client_GetConnectionSettings_Call) Run(run func(ctx context.Context)) *Client_GetConnectionSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -299,14 +332,14 @@ func (_c *Client_GetConnectionSettings_Call) Return(environmentSettings []edgeco
 	return _c
 }
 
-func (_c *Client_GetConnectionSettings_Call) RunAndReturn(run func() ([]edgeconnect.EnvironmentSetting, error)) *Client_GetConnectionSettings_Call {
+func (_c *Client_GetConnectionSettings_Call) RunAndReturn(run func(ctx context.Context) ([]edgeconnect.EnvironmentSetting, error)) *Client_GetConnectionSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetEdgeConnect provides a mock function for the type Client
-func (_mock *Client) GetEdgeConnect(edgeConnectID string) (edgeconnect.GetResponse, error) {
-	ret := _mock.Called(edgeConnectID)
+func (_mock *Client) GetEdgeConnect(ctx context.Context, edgeConnectID string) (edgeconnect.GetResponse, error) {
+	ret := _mock.Called(ctx, edgeConnectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetEdgeConnect")
@@ -314,16 +347,16 @@ func (_mock *Client) GetEdgeConnect(edgeConnectID string) (edgeconnect.GetRespon
 
 	var r0 edgeconnect.GetResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (edgeconnect.GetResponse, error)); ok {
-		return returnFunc(edgeConnectID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (edgeconnect.GetResponse, error)); ok {
+		return returnFunc(ctx, edgeConnectID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) edgeconnect.GetResponse); ok {
-		r0 = returnFunc(edgeConnectID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) edgeconnect.GetResponse); ok {
+		r0 = returnFunc(ctx, edgeConnectID)
 	} else {
 		r0 = ret.Get(0).(edgeconnect.GetResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(edgeConnectID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, edgeConnectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -336,19 +369,25 @@ type Client_GetEdgeConnect_Call struct {
 }
 
 // GetEdgeConnect is a helper method to define mock.On call
+//   - ctx context.Context
 //   - edgeConnectID string
-func (_e *Client_Expecter) GetEdgeConnect(edgeConnectID interface{}) *Client_GetEdgeConnect_Call {
-	return &Client_GetEdgeConnect_Call{Call: _e.mock.On("GetEdgeConnect", edgeConnectID)}
+func (_e *Client_Expecter) GetEdgeConnect(ctx interface{}, edgeConnectID interface{}) *Client_GetEdgeConnect_Call {
+	return &Client_GetEdgeConnect_Call{Call: _e.mock.On("GetEdgeConnect", ctx, edgeConnectID)}
 }
 
-func (_c *Client_GetEdgeConnect_Call) Run(run func(edgeConnectID string)) *Client_GetEdgeConnect_Call {
+func (_c *Client_GetEdgeConnect_Call) Run(run func(ctx context.Context, edgeConnectID string)) *Client_GetEdgeConnect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -359,14 +398,14 @@ func (_c *Client_GetEdgeConnect_Call) Return(getResponse edgeconnect.GetResponse
 	return _c
 }
 
-func (_c *Client_GetEdgeConnect_Call) RunAndReturn(run func(edgeConnectID string) (edgeconnect.GetResponse, error)) *Client_GetEdgeConnect_Call {
+func (_c *Client_GetEdgeConnect_Call) RunAndReturn(run func(ctx context.Context, edgeConnectID string) (edgeconnect.GetResponse, error)) *Client_GetEdgeConnect_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetEdgeConnects provides a mock function for the type Client
-func (_mock *Client) GetEdgeConnects(name string) (edgeconnect.ListResponse, error) {
-	ret := _mock.Called(name)
+func (_mock *Client) GetEdgeConnects(ctx context.Context, name string) (edgeconnect.ListResponse, error) {
+	ret := _mock.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetEdgeConnects")
@@ -374,16 +413,16 @@ func (_mock *Client) GetEdgeConnects(name string) (edgeconnect.ListResponse, err
 
 	var r0 edgeconnect.ListResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (edgeconnect.ListResponse, error)); ok {
-		return returnFunc(name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (edgeconnect.ListResponse, error)); ok {
+		return returnFunc(ctx, name)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) edgeconnect.ListResponse); ok {
-		r0 = returnFunc(name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) edgeconnect.ListResponse); ok {
+		r0 = returnFunc(ctx, name)
 	} else {
 		r0 = ret.Get(0).(edgeconnect.ListResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(name)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -396,19 +435,25 @@ type Client_GetEdgeConnects_Call struct {
 }
 
 // GetEdgeConnects is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *Client_Expecter) GetEdgeConnects(name interface{}) *Client_GetEdgeConnects_Call {
-	return &Client_GetEdgeConnects_Call{Call: _e.mock.On("GetEdgeConnects", name)}
+func (_e *Client_Expecter) GetEdgeConnects(ctx interface{}, name interface{}) *Client_GetEdgeConnects_Call {
+	return &Client_GetEdgeConnects_Call{Call: _e.mock.On("GetEdgeConnects", ctx, name)}
 }
 
-func (_c *Client_GetEdgeConnects_Call) Run(run func(name string)) *Client_GetEdgeConnects_Call {
+func (_c *Client_GetEdgeConnects_Call) Run(run func(ctx context.Context, name string)) *Client_GetEdgeConnects_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -419,22 +464,22 @@ func (_c *Client_GetEdgeConnects_Call) Return(listResponse edgeconnect.ListRespo
 	return _c
 }
 
-func (_c *Client_GetEdgeConnects_Call) RunAndReturn(run func(name string) (edgeconnect.ListResponse, error)) *Client_GetEdgeConnects_Call {
+func (_c *Client_GetEdgeConnects_Call) RunAndReturn(run func(ctx context.Context, name string) (edgeconnect.ListResponse, error)) *Client_GetEdgeConnects_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateConnectionSetting provides a mock function for the type Client
-func (_mock *Client) UpdateConnectionSetting(es edgeconnect.EnvironmentSetting) error {
-	ret := _mock.Called(es)
+func (_mock *Client) UpdateConnectionSetting(ctx context.Context, es edgeconnect.EnvironmentSetting) error {
+	ret := _mock.Called(ctx, es)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateConnectionSetting")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(edgeconnect.EnvironmentSetting) error); ok {
-		r0 = returnFunc(es)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, edgeconnect.EnvironmentSetting) error); ok {
+		r0 = returnFunc(ctx, es)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -447,19 +492,25 @@ type Client_UpdateConnectionSetting_Call struct {
 }
 
 // UpdateConnectionSetting is a helper method to define mock.On call
+//   - ctx context.Context
 //   - es edgeconnect.EnvironmentSetting
-func (_e *Client_Expecter) UpdateConnectionSetting(es interface{}) *Client_UpdateConnectionSetting_Call {
-	return &Client_UpdateConnectionSetting_Call{Call: _e.mock.On("UpdateConnectionSetting", es)}
+func (_e *Client_Expecter) UpdateConnectionSetting(ctx interface{}, es interface{}) *Client_UpdateConnectionSetting_Call {
+	return &Client_UpdateConnectionSetting_Call{Call: _e.mock.On("UpdateConnectionSetting", ctx, es)}
 }
 
-func (_c *Client_UpdateConnectionSetting_Call) Run(run func(es edgeconnect.EnvironmentSetting)) *Client_UpdateConnectionSetting_Call {
+func (_c *Client_UpdateConnectionSetting_Call) Run(run func(ctx context.Context, es edgeconnect.EnvironmentSetting)) *Client_UpdateConnectionSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 edgeconnect.EnvironmentSetting
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(edgeconnect.EnvironmentSetting)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 edgeconnect.EnvironmentSetting
+		if args[1] != nil {
+			arg1 = args[1].(edgeconnect.EnvironmentSetting)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -470,22 +521,22 @@ func (_c *Client_UpdateConnectionSetting_Call) Return(err error) *Client_UpdateC
 	return _c
 }
 
-func (_c *Client_UpdateConnectionSetting_Call) RunAndReturn(run func(es edgeconnect.EnvironmentSetting) error) *Client_UpdateConnectionSetting_Call {
+func (_c *Client_UpdateConnectionSetting_Call) RunAndReturn(run func(ctx context.Context, es edgeconnect.EnvironmentSetting) error) *Client_UpdateConnectionSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateEdgeConnect provides a mock function for the type Client
-func (_mock *Client) UpdateEdgeConnect(edgeConnectID string, request *edgeconnect.Request) error {
-	ret := _mock.Called(edgeConnectID, request)
+func (_mock *Client) UpdateEdgeConnect(ctx context.Context, edgeConnectID string, request *edgeconnect.Request) error {
+	ret := _mock.Called(ctx, edgeConnectID, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateEdgeConnect")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, *edgeconnect.Request) error); ok {
-		r0 = returnFunc(edgeConnectID, request)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *edgeconnect.Request) error); ok {
+		r0 = returnFunc(ctx, edgeConnectID, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -498,25 +549,31 @@ type Client_UpdateEdgeConnect_Call struct {
 }
 
 // UpdateEdgeConnect is a helper method to define mock.On call
+//   - ctx context.Context
 //   - edgeConnectID string
 //   - request *edgeconnect.Request
-func (_e *Client_Expecter) UpdateEdgeConnect(edgeConnectID interface{}, request interface{}) *Client_UpdateEdgeConnect_Call {
-	return &Client_UpdateEdgeConnect_Call{Call: _e.mock.On("UpdateEdgeConnect", edgeConnectID, request)}
+func (_e *Client_Expecter) UpdateEdgeConnect(ctx interface{}, edgeConnectID interface{}, request interface{}) *Client_UpdateEdgeConnect_Call {
+	return &Client_UpdateEdgeConnect_Call{Call: _e.mock.On("UpdateEdgeConnect", ctx, edgeConnectID, request)}
 }
 
-func (_c *Client_UpdateEdgeConnect_Call) Run(run func(edgeConnectID string, request *edgeconnect.Request)) *Client_UpdateEdgeConnect_Call {
+func (_c *Client_UpdateEdgeConnect_Call) Run(run func(ctx context.Context, edgeConnectID string, request *edgeconnect.Request)) *Client_UpdateEdgeConnect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 *edgeconnect.Request
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*edgeconnect.Request)
+			arg1 = args[1].(string)
+		}
+		var arg2 *edgeconnect.Request
+		if args[2] != nil {
+			arg2 = args[2].(*edgeconnect.Request)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -527,7 +584,7 @@ func (_c *Client_UpdateEdgeConnect_Call) Return(err error) *Client_UpdateEdgeCon
 	return _c
 }
 
-func (_c *Client_UpdateEdgeConnect_Call) RunAndReturn(run func(edgeConnectID string, request *edgeconnect.Request) error) *Client_UpdateEdgeConnect_Call {
+func (_c *Client_UpdateEdgeConnect_Call) RunAndReturn(run func(ctx context.Context, edgeConnectID string, request *edgeconnect.Request) error) *Client_UpdateEdgeConnect_Call {
 	_c.Call.Return(run)
 	return _c
 }
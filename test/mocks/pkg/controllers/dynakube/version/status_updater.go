@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/update"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	mock "github.com/stretchr/testify/mock"
@@ -425,6 +426,52 @@ func (_c *StatusUpdater_Name_Call) RunAndReturn(run func() string) *StatusUpdate
 	return _c
 }
 
+// Rollout provides a mock function for the type StatusUpdater
+func (_mock *StatusUpdater) Rollout() *update.RolloutStatus {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollout")
+	}
+
+	var r0 *update.RolloutStatus
+	if returnFunc, ok := ret.Get(0).(func() *update.RolloutStatus); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*update.RolloutStatus)
+		}
+	}
+	return r0
+}
+
+// StatusUpdater_Rollout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rollout'
+type StatusUpdater_Rollout_Call struct {
+	*mock.Call
+}
+
+// Rollout is a helper method to define mock.On call
+func (_e *StatusUpdater_Expecter) Rollout() *StatusUpdater_Rollout_Call {
+	return &StatusUpdater_Rollout_Call{Call: _e.mock.On("Rollout")}
+}
+
+func (_c *StatusUpdater_Rollout_Call) Run(run func()) *StatusUpdater_Rollout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StatusUpdater_Rollout_Call) Return(rolloutStatus *update.RolloutStatus) *StatusUpdater_Rollout_Call {
+	_c.Call.Return(rolloutStatus)
+	return _c
+}
+
+func (_c *StatusUpdater_Rollout_Call) RunAndReturn(run func() *update.RolloutStatus) *StatusUpdater_Rollout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Target provides a mock function for the type StatusUpdater
 func (_mock *StatusUpdater) Target() *status.VersionStatus {
 	ret := _mock.Called()